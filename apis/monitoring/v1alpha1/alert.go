@@ -7,13 +7,22 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
+// AlertFinalizer is added to alerts by Searchlight operator. It is removed once the Icinga
+// objects and Incidents of a deleted alert are cleaned up.
+const AlertFinalizer = "monitoring.appscode.com/alert"
+
 type Alert interface {
 	GetName() string
 	GetNamespace() string
 	Command() string
 	GetCheckInterval() time.Duration
 	GetAlertInterval() time.Duration
+	GetMaxCheckAttempts() int32
+	GetRetryInterval() time.Duration
+	GetCheckTimeout() time.Duration
+	GetFlapping() (enabled bool, low, high int32)
 	IsValid(kc kubernetes.Interface) error
+	GetNotifierSecretNamespace() string
 	GetNotifierSecretName() string
 	GetReceivers() []Receiver
 	ObjectReference() *core.ObjectReference
@@ -0,0 +1,101 @@
+package v1alpha1
+
+import (
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AlertStatus is the most recently observed status of an alert.
+type AlertStatus struct {
+	// ObservedGeneration is the most recent generation observed for this alert. It corresponds to the
+	// alert's generation, which is updated on mutation by the API Server.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the alert's state.
+	// +optional
+	Conditions []AlertCondition `json:"conditions,omitempty"`
+
+	// Targets are the objects this alert is currently applied to.
+	// +optional
+	Targets []AlertTarget `json:"targets,omitempty"`
+}
+
+type AlertConditionType string
+
+// These are valid conditions of an alert.
+const (
+	// AlertConditionValid means the alert passed validation against its check command and notifiers.
+	AlertConditionValid AlertConditionType = "Valid"
+	// AlertConditionSynced means Icinga objects for every target of the alert were applied successfully.
+	AlertConditionSynced AlertConditionType = "Synced"
+	// AlertConditionPaused means Icinga services of the alert have been removed.
+	AlertConditionPaused AlertConditionType = "Paused"
+)
+
+type AlertCondition struct {
+	// Type of alert condition.
+	Type AlertConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status core.ConditionStatus `json:"status"`
+	// Last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// The reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// A human readable message indicating details about the transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+type AlertTarget struct {
+	// Namespace of the target object. Empty for nodes.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the target object. Empty for ClusterAlert.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Name of the Icinga host created for the target
+	IcingaHost string `json:"icingaHost"`
+	// Last known state of the Icinga service, such as OK, Warning, Critical or Unknown
+	// +optional
+	State string `json:"state,omitempty"`
+	// The time at which Icinga last checked the service for this target.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+	// Error returned by the last attempt to apply this target in Icinga.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// GetCondition returns the condition of the given type, or nil if it is not set.
+func (s AlertStatus) GetCondition(t AlertConditionType) *AlertCondition {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == t {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition adds or replaces the condition of the same type. LastTransitionTime
+// is only moved forward when the status of the condition changes.
+func (s *AlertStatus) SetCondition(c AlertCondition) {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type != c.Type {
+			continue
+		}
+		if s.Conditions[i].Status == c.Status {
+			c.LastTransitionTime = s.Conditions[i].LastTransitionTime
+		} else if c.LastTransitionTime.IsZero() {
+			c.LastTransitionTime = metav1.Now()
+		}
+		s.Conditions[i] = c
+		return
+	}
+	if c.LastTransitionTime.IsZero() {
+		c.LastTransitionTime = metav1.Now()
+	}
+	s.Conditions = append(s.Conditions, c)
+}
@@ -0,0 +1,233 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindAlertTemplate     = "AlertTemplate"
+	ResourcePluralAlertTemplate   = "alerttemplates"
+	ResourceSingularAlertTemplate = "alerttemplate"
+)
+
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AlertTemplate contains defaults for ClusterAlerts, NodeAlerts and PodAlerts which refer
+// to it in spec.templateRef. Fields set in an alert take precedence over its template.
+type AlertTemplate struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the AlertTemplate.
+	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status
+	Spec AlertTemplateSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AlertTemplateList is a collection of AlertTemplate.
+type AlertTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of AlertTemplate.
+	Items []AlertTemplate `json:"items"`
+}
+
+// AlertTemplateSpec describes the AlertTemplate the user wishes to create.
+type AlertTemplateSpec struct {
+	// How frequently Icinga Service will be checked
+	CheckInterval metav1.Duration `json:"checkInterval,omitempty"`
+
+	// How frequently notifications will be send
+	AlertInterval metav1.Duration `json:"alertInterval,omitempty"`
+
+	// Number of times Icinga Service is checked before a non-OK state is considered hard
+	// and notifications are sent
+	// +optional
+	MaxCheckAttempts int32 `json:"maxCheckAttempts,omitempty"`
+
+	// How frequently Icinga Service will be re-checked while in a soft non-OK state
+	// +optional
+	RetryInterval metav1.Duration `json:"retryInterval,omitempty"`
+
+	// Timeout of CheckCommand
+	// +optional
+	CheckTimeout metav1.Duration `json:"checkTimeout,omitempty"`
+
+	// Secret containing notifier credentials
+	NotifierSecretName string `json:"notifierSecretName,omitempty"`
+
+	// Receivers of alerts which don't specify receivers themselves
+	Receivers []Receiver `json:"receivers,omitempty"`
+
+	// Vars contains Icinga Service variables to be used in CheckCommand. Vars of an alert
+	// take precedence over the vars of its template with the same name.
+	Vars map[string]string `json:"vars,omitempty"`
+
+	// VarsFrom contains Icinga Service variables whose values are read from secrets in the
+	// namespace of the template at check time. Vars of an alert take precedence over them, too.
+	// +optional
+	VarsFrom map[string]VarSource `json:"varsFrom,omitempty"`
+}
+
+func (t AlertTemplate) IsValid() error {
+	if t.Spec.CheckInterval.Duration < 0 || t.Spec.AlertInterval.Duration < 0 {
+		return fmt.Errorf("intervals must not be negative")
+	}
+	if t.Spec.MaxCheckAttempts < 0 {
+		return fmt.Errorf("maxCheckAttempts must not be negative")
+	}
+	if t.Spec.RetryInterval.Duration < 0 || t.Spec.CheckTimeout.Duration < 0 {
+		return fmt.Errorf("retryInterval and checkTimeout must not be negative")
+	}
+	for _, r := range t.Spec.Receivers {
+		if r.EscalateAfter != nil && r.EscalateAfter.Duration <= 0 {
+			return fmt.Errorf("escalateAfter of receiver %s must be positive", r.Notifier)
+		}
+	}
+	return validateVarsFrom(t.Spec.Vars, t.Spec.VarsFrom)
+}
+
+func (t AlertTemplate) ObjectReference() *core.ObjectReference {
+	return &core.ObjectReference{
+		APIVersion:      SchemeGroupVersion.String(),
+		Kind:            ResourceKindAlertTemplate,
+		Namespace:       t.Namespace,
+		Name:            t.Name,
+		UID:             t.UID,
+		ResourceVersion: t.ResourceVersion,
+	}
+}
+
+// templatedFields are the fields of an alert spec which can be inherited from an AlertTemplate.
+type templatedFields struct {
+	checkInterval      *metav1.Duration
+	alertInterval      *metav1.Duration
+	maxCheckAttempts   *int32
+	retryInterval      *metav1.Duration
+	checkTimeout       *metav1.Duration
+	notifierSecretName *string
+	receivers          *[]Receiver
+	vars               *map[string]string
+	varsFrom           *map[string]VarSource
+}
+
+// merge sets the fields which are not set in an alert spec from the template.
+func (t AlertTemplate) merge(f templatedFields) {
+	if f.checkInterval.Duration == 0 {
+		*f.checkInterval = t.Spec.CheckInterval
+	}
+	if f.alertInterval.Duration == 0 {
+		*f.alertInterval = t.Spec.AlertInterval
+	}
+	if *f.maxCheckAttempts == 0 {
+		*f.maxCheckAttempts = t.Spec.MaxCheckAttempts
+	}
+	if f.retryInterval.Duration == 0 {
+		*f.retryInterval = t.Spec.RetryInterval
+	}
+	if f.checkTimeout.Duration == 0 {
+		*f.checkTimeout = t.Spec.CheckTimeout
+	}
+	if *f.notifierSecretName == "" {
+		*f.notifierSecretName = t.Spec.NotifierSecretName
+	}
+	if len(*f.receivers) == 0 && len(t.Spec.Receivers) > 0 {
+		*f.receivers = make([]Receiver, len(t.Spec.Receivers))
+		for i := range t.Spec.Receivers {
+			t.Spec.Receivers[i].DeepCopyInto(&(*f.receivers)[i])
+		}
+	}
+	// a var set by the alert, either in vars or varsFrom, hides the var of the template in both
+	if len(t.Spec.Vars) > 0 {
+		vars := make(map[string]string, len(t.Spec.Vars)+len(*f.vars))
+		for k, v := range t.Spec.Vars {
+			if _, found := (*f.varsFrom)[k]; !found {
+				vars[k] = v
+			}
+		}
+		for k, v := range *f.vars {
+			vars[k] = v
+		}
+		*f.vars = vars
+	}
+	if len(t.Spec.VarsFrom) > 0 {
+		varsFrom := make(map[string]VarSource, len(t.Spec.VarsFrom)+len(*f.varsFrom))
+		for k, v := range t.Spec.VarsFrom {
+			if _, found := (*f.vars)[k]; !found {
+				varsFrom[k] = *v.DeepCopy()
+			}
+		}
+		for k, v := range *f.varsFrom {
+			varsFrom[k] = v
+		}
+		*f.varsFrom = varsFrom
+	}
+}
+
+// WithTemplate returns a copy of the ClusterAlert with the defaults of its AlertTemplate.
+func (a ClusterAlert) WithTemplate(t *AlertTemplate) *ClusterAlert {
+	out := a.DeepCopy()
+	if t != nil {
+		s := &out.Spec
+		t.merge(templatedFields{&s.CheckInterval, &s.AlertInterval, &s.MaxCheckAttempts, &s.RetryInterval, &s.CheckTimeout, &s.NotifierSecretName, &s.Receivers, &s.Vars, &s.VarsFrom})
+	}
+	return out
+}
+
+// WithTemplate returns a copy of the NodeAlert with the defaults of its AlertTemplate.
+func (a NodeAlert) WithTemplate(t *AlertTemplate) *NodeAlert {
+	out := a.DeepCopy()
+	if t != nil {
+		s := &out.Spec
+		t.merge(templatedFields{&s.CheckInterval, &s.AlertInterval, &s.MaxCheckAttempts, &s.RetryInterval, &s.CheckTimeout, &s.NotifierSecretName, &s.Receivers, &s.Vars, &s.VarsFrom})
+	}
+	return out
+}
+
+// WithTemplate returns a copy of the PodAlert with the defaults of its AlertTemplate.
+func (a PodAlert) WithTemplate(t *AlertTemplate) *PodAlert {
+	out := a.DeepCopy()
+	if t != nil {
+		s := &out.Spec
+		t.merge(templatedFields{&s.CheckInterval, &s.AlertInterval, &s.MaxCheckAttempts, &s.RetryInterval, &s.CheckTimeout, &s.NotifierSecretName, &s.Receivers, &s.Vars, &s.VarsFrom})
+	}
+	return out
+}
+
+// GetTemplateRef returns the name of the AlertTemplate of an alert, or an empty string if it has none.
+func GetTemplateRef(alert Alert) string {
+	switch a := alert.(type) {
+	case *ClusterAlert:
+		return a.Spec.TemplateRef
+	case *NodeAlert:
+		return a.Spec.TemplateRef
+	case *PodAlert:
+		return a.Spec.TemplateRef
+	}
+	return ""
+}
+
+// WithTemplate returns a copy of an alert with the defaults of its AlertTemplate. Alerts
+// of kinds which don't support templates are returned as is.
+func WithTemplate(alert Alert, t *AlertTemplate) Alert {
+	switch a := alert.(type) {
+	case *ClusterAlert:
+		return a.WithTemplate(t)
+	case *NodeAlert:
+		return a.WithTemplate(t)
+	case *PodAlert:
+		return a.WithTemplate(t)
+	}
+	return alert
+}
@@ -0,0 +1,74 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	core "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// IsAnnotationAlert reports whether an alert name refers to a check declared in pod annotations.
+func IsAnnotationAlert(name string) bool {
+	return strings.HasPrefix(name, AnnotationAlertPrefix)
+}
+
+// AnnotationAlerts returns the PodAlerts declared in the annotations of a pod, sorted by name.
+// Annotations which can't be parsed are skipped and reported in the returned error.
+func AnnotationAlerts(pod *core.Pod) ([]*PodAlert, error) {
+	var alerts []*PodAlert
+	var errs []error
+	for key, val := range pod.Annotations {
+		if !strings.HasPrefix(key, AnnotationKeyCheckPrefix) {
+			continue
+		}
+		alert, err := newAnnotationAlert(pod, strings.TrimPrefix(key, AnnotationKeyCheckPrefix), val)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].Name < alerts[j].Name })
+	return alerts, utilerrors.NewAggregate(errs)
+}
+
+// GetAnnotationAlert returns the PodAlert with the given name declared in the annotations of a pod.
+func GetAnnotationAlert(pod *core.Pod, name string) (*PodAlert, error) {
+	check := strings.TrimPrefix(name, AnnotationAlertPrefix)
+	val, ok := pod.Annotations[AnnotationKeyCheckPrefix+check]
+	if !IsAnnotationAlert(name) || !ok {
+		return nil, fmt.Errorf("pod %s/%s has no annotation %s%s", pod.Namespace, pod.Name, AnnotationKeyCheckPrefix, check)
+	}
+	return newAnnotationAlert(pod, check, val)
+}
+
+func newAnnotationAlert(pod *core.Pod, check, val string) (*PodAlert, error) {
+	if errs := validation.IsDNS1123Label(check); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid check name in annotation %s%s: %s", AnnotationKeyCheckPrefix, check, strings.Join(errs, ", "))
+	}
+	alert := &PodAlert{}
+	if err := json.Unmarshal([]byte(val), &alert.Spec); err != nil {
+		return nil, fmt.Errorf("failed to parse annotation %s%s: %v", AnnotationKeyCheckPrefix, check, err)
+	}
+	// Annotations are not admitted by the webhook, which checks that the user may read the secrets and
+	// use the NotifierConfigs an alert refers to. They can only be inherited from an AlertTemplate.
+	if len(alert.Spec.VarsFrom) > 0 {
+		return nil, fmt.Errorf("annotation %s%s can't set varsFrom, use an AlertTemplate instead", AnnotationKeyCheckPrefix, check)
+	}
+	for _, r := range alert.Spec.Receivers {
+		if r.NotifierConfig != "" {
+			return nil, fmt.Errorf("annotation %s%s can't set notifierConfig of receivers, use an AlertTemplate instead", AnnotationKeyCheckPrefix, check)
+		}
+	}
+	alert.Name = AnnotationAlertPrefix + check
+	alert.Namespace = pod.Namespace
+	// a check declared in annotations applies to its pod only
+	podName := pod.Name
+	alert.Spec.PodName = &podName
+	alert.Spec.Selector = nil
+	return alert, nil
+}
@@ -1,5 +1,23 @@
 package v1alpha1
 
 const (
-	AnnotationKeyAlerts = "monitoring.appscode.com/alerts"
+	AnnotationKeyAlerts       = "monitoring.appscode.com/alerts"
+	AnnotationKeyGlobalAlerts = "monitoring.appscode.com/global-alerts"
+
+	// AnnotationKeyCheckPrefix is the prefix of pod annotations declaring checks, e.g.
+	// monitoring.appscode.com/check.http-health. Their values are PodAlert specs in JSON.
+	AnnotationKeyCheckPrefix = "monitoring.appscode.com/check."
+	// AnnotationAlertPrefix is the prefix of the names of alerts declared in pod annotations.
+	// PodAlerts can't use it, so that their Icinga services don't collide.
+	AnnotationAlertPrefix = "annotation."
+
+	// Annotations of namespaces overriding the incident retention of the operator for their Incidents.
+	// Durations are Go durations, e.g. 720h, and 0 disables a limit.
+	AnnotationKeyIncidentTTL         = "monitoring.appscode.com/incident-ttl"
+	AnnotationKeyIncidentStaleTTL    = "monitoring.appscode.com/incident-stale-ttl"
+	AnnotationKeyIncidentMaxPerAlert = "monitoring.appscode.com/incident-max-per-alert"
+
+	// AnnotationKeyPausedByPlugin is set on alerts paused because the SearchlightPlugin of their check was
+	// deleted. Its value is the name of the SearchlightPlugin.
+	AnnotationKeyPausedByPlugin = "monitoring.appscode.com/paused-by-plugin"
 )
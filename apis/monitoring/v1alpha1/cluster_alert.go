@@ -17,7 +17,6 @@ const (
 )
 
 // +genclient
-// +genclient:skipVerbs=updateStatus
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -30,6 +29,10 @@ type ClusterAlert struct {
 	// Spec is the desired state of the ClusterAlert.
 	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status
 	Spec ClusterAlertSpec `json:"spec,omitempty"`
+
+	// Status is the most recently observed status of the ClusterAlert.
+	// +optional
+	Status AlertStatus `json:"status,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -50,12 +53,42 @@ type ClusterAlertSpec struct {
 	// Icinga CheckCommand name
 	Check string `json:"check,omitempty"`
 
+	// Name of an AlertTemplate in the same namespace whose values are used for fields
+	// not set in this spec
+	// +optional
+	TemplateRef string `json:"templateRef,omitempty"`
+
 	// How frequently Icinga Service will be checked
 	CheckInterval metav1.Duration `json:"checkInterval,omitempty"`
 
 	// How frequently notifications will be send
 	AlertInterval metav1.Duration `json:"alertInterval,omitempty"`
 
+	// Number of times Icinga Service is checked before a non-OK state is considered hard
+	// and notifications are sent
+	// +optional
+	MaxCheckAttempts int32 `json:"maxCheckAttempts,omitempty"`
+
+	// How frequently Icinga Service will be re-checked while in a soft non-OK state
+	// +optional
+	RetryInterval metav1.Duration `json:"retryInterval,omitempty"`
+
+	// Timeout of CheckCommand
+	// +optional
+	CheckTimeout metav1.Duration `json:"checkTimeout,omitempty"`
+
+	// Indicates that flap detection is enabled for Icinga Service
+	// +optional
+	EnableFlapping bool `json:"enableFlapping,omitempty"`
+
+	// Percentage of state changes below which Icinga Service stops flapping
+	// +optional
+	FlappingThresholdLow int32 `json:"flappingThresholdLow,omitempty"`
+
+	// Percentage of state changes above which Icinga Service starts flapping
+	// +optional
+	FlappingThresholdHigh int32 `json:"flappingThresholdHigh,omitempty"`
+
 	// Secret containing notifier credentials
 	NotifierSecretName string `json:"notifierSecretName,omitempty"`
 
@@ -66,6 +99,11 @@ type ClusterAlertSpec struct {
 	// Vars contains Icinga Service variables to be used in CheckCommand
 	Vars map[string]string `json:"vars,omitempty"`
 
+	// VarsFrom contains Icinga Service variables whose values are read from secrets in the
+	// namespace of the alert at check time
+	// +optional
+	VarsFrom map[string]VarSource `json:"varsFrom,omitempty"`
+
 	// Indicates that Check is paused
 	// Icinga Services are removed
 	Paused bool `json:"paused,omitempty"`
@@ -93,6 +131,22 @@ func (a ClusterAlert) GetAlertInterval() time.Duration {
 	return a.Spec.AlertInterval.Duration
 }
 
+func (a ClusterAlert) GetMaxCheckAttempts() int32 {
+	return a.Spec.MaxCheckAttempts
+}
+
+func (a ClusterAlert) GetRetryInterval() time.Duration {
+	return a.Spec.RetryInterval.Duration
+}
+
+func (a ClusterAlert) GetCheckTimeout() time.Duration {
+	return a.Spec.CheckTimeout.Duration
+}
+
+func (a ClusterAlert) GetFlapping() (enabled bool, low, high int32) {
+	return a.Spec.EnableFlapping, a.Spec.FlappingThresholdLow, a.Spec.FlappingThresholdHigh
+}
+
 func (a ClusterAlert) IsValid(kc kubernetes.Interface) error {
 	if a.Spec.Paused {
 		return nil
@@ -103,7 +157,11 @@ func (a ClusterAlert) IsValid(kc kubernetes.Interface) error {
 		return fmt.Errorf("'%s' is not a valid cluster check command", a.Spec.Check)
 	}
 
-	if err := validateVariables(cmd.Vars, a.Spec.Vars); err != nil {
+	if err := validateVariables(cmd.Vars, a.Spec.Vars, a.Spec.VarsFrom); err != nil {
+		return err
+	}
+
+	if err := validateCheckSettings(a); err != nil {
 		return err
 	}
 
@@ -123,6 +181,10 @@ func (a ClusterAlert) IsValid(kc kubernetes.Interface) error {
 	return checkNotifiers(kc, a)
 }
 
+func (a ClusterAlert) GetNotifierSecretNamespace() string {
+	return a.Namespace
+}
+
 func (a ClusterAlert) GetNotifierSecretName() string {
 	return a.Spec.NotifierSecretName
 }
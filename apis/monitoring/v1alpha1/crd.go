@@ -43,6 +43,11 @@ func (a ClusterAlert) CustomResourceDefinition() *apiextensions.CustomResourceDe
 				Type:     "boolean",
 				JSONPath: ".spec.paused",
 			},
+			{
+				Name:     "Synced",
+				Type:     "string",
+				JSONPath: `.status.conditions[?(@.type=="Synced")].status`,
+			},
 			{
 				Name:     "Age",
 				Type:     "date",
@@ -86,6 +91,11 @@ func (a NodeAlert) CustomResourceDefinition() *apiextensions.CustomResourceDefin
 				Type:     "boolean",
 				JSONPath: ".spec.paused",
 			},
+			{
+				Name:     "Synced",
+				Type:     "string",
+				JSONPath: `.status.conditions[?(@.type=="Synced")].status`,
+			},
 			{
 				Name:     "Age",
 				Type:     "date",
@@ -129,6 +139,274 @@ func (a PodAlert) CustomResourceDefinition() *apiextensions.CustomResourceDefini
 				Type:     "boolean",
 				JSONPath: ".spec.paused",
 			},
+			{
+				Name:     "Synced",
+				Type:     "string",
+				JSONPath: `.status.conditions[?(@.type=="Synced")].status`,
+			},
+			{
+				Name:     "Age",
+				Type:     "date",
+				JSONPath: ".metadata.creationTimestamp",
+			},
+		},
+	})
+}
+
+func (a WorkloadAlert) CustomResourceDefinition() *apiextensions.CustomResourceDefinition {
+	return crdutils.NewCustomResourceDefinition(crdutils.Config{
+		Group:         SchemeGroupVersion.Group,
+		Plural:        ResourcePluralWorkloadAlert,
+		Singular:      ResourceSingularWorkloadAlert,
+		Kind:          ResourceKindWorkloadAlert,
+		ShortNames:    []string{"wla"},
+		Categories:    []string{"monitoring", "appscode", "all"},
+		ResourceScope: string(apiextensions.NamespaceScoped),
+		Versions: []apiextensions.CustomResourceDefinitionVersion{
+			{
+				Name:    SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Labels: crdutils.Labels{
+			LabelsMap: map[string]string{"app": "searchlight"},
+		},
+		SpecDefinitionName:      "github.com/appscode/searchlight/apis/monitoring/v1alpha1.WorkloadAlert",
+		EnableValidation:        true,
+		GetOpenAPIDefinitions:   GetOpenAPIDefinitions,
+		EnableStatusSubresource: EnableStatusSubresource,
+		AdditionalPrinterColumns: []apiextensions.CustomResourceColumnDefinition{
+			{
+				Name:     "Kind",
+				Type:     "string",
+				JSONPath: ".spec.targetRef.kind",
+			},
+			{
+				Name:     "CheckCommand",
+				Type:     "string",
+				JSONPath: ".spec.check",
+			},
+			{
+				Name:     "Paused",
+				Type:     "boolean",
+				JSONPath: ".spec.paused",
+			},
+			{
+				Name:     "Synced",
+				Type:     "string",
+				JSONPath: `.status.conditions[?(@.type=="Synced")].status`,
+			},
+			{
+				Name:     "Age",
+				Type:     "date",
+				JSONPath: ".metadata.creationTimestamp",
+			},
+		},
+	})
+}
+
+func (a GlobalAlert) CustomResourceDefinition() *apiextensions.CustomResourceDefinition {
+	return crdutils.NewCustomResourceDefinition(crdutils.Config{
+		Group:         SchemeGroupVersion.Group,
+		Plural:        ResourcePluralGlobalAlert,
+		Singular:      ResourceSingularGlobalAlert,
+		Kind:          ResourceKindGlobalAlert,
+		ShortNames:    []string{"gla"},
+		Categories:    []string{"monitoring", "appscode", "all"},
+		ResourceScope: string(apiextensions.ClusterScoped),
+		Versions: []apiextensions.CustomResourceDefinitionVersion{
+			{
+				Name:    SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Labels: crdutils.Labels{
+			LabelsMap: map[string]string{"app": "searchlight"},
+		},
+		SpecDefinitionName:      "github.com/appscode/searchlight/apis/monitoring/v1alpha1.GlobalAlert",
+		EnableValidation:        true,
+		GetOpenAPIDefinitions:   GetOpenAPIDefinitions,
+		EnableStatusSubresource: EnableStatusSubresource,
+		AdditionalPrinterColumns: []apiextensions.CustomResourceColumnDefinition{
+			{
+				Name:     "CheckCommand",
+				Type:     "string",
+				JSONPath: ".spec.check",
+			},
+			{
+				Name:     "Paused",
+				Type:     "boolean",
+				JSONPath: ".spec.paused",
+			},
+			{
+				Name:     "Synced",
+				Type:     "string",
+				JSONPath: `.status.conditions[?(@.type=="Synced")].status`,
+			},
+			{
+				Name:     "Age",
+				Type:     "date",
+				JSONPath: ".metadata.creationTimestamp",
+			},
+		},
+	})
+}
+
+func (a Silence) CustomResourceDefinition() *apiextensions.CustomResourceDefinition {
+	return crdutils.NewCustomResourceDefinition(crdutils.Config{
+		Group:         SchemeGroupVersion.Group,
+		Plural:        ResourcePluralSilence,
+		Singular:      ResourceSingularSilence,
+		Kind:          ResourceKindSilence,
+		ShortNames:    []string{"sil"},
+		Categories:    []string{"monitoring", "appscode", "all"},
+		ResourceScope: string(apiextensions.NamespaceScoped),
+		Versions: []apiextensions.CustomResourceDefinitionVersion{
+			{
+				Name:    SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Labels: crdutils.Labels{
+			LabelsMap: map[string]string{"app": "searchlight"},
+		},
+		SpecDefinitionName:      "github.com/appscode/searchlight/apis/monitoring/v1alpha1.Silence",
+		EnableValidation:        true,
+		GetOpenAPIDefinitions:   GetOpenAPIDefinitions,
+		EnableStatusSubresource: EnableStatusSubresource,
+		AdditionalPrinterColumns: []apiextensions.CustomResourceColumnDefinition{
+			{
+				Name:     "Active",
+				Type:     "boolean",
+				JSONPath: ".status.active",
+			},
+			{
+				Name:     "Start",
+				Type:     "date",
+				JSONPath: ".status.windowStart",
+			},
+			{
+				Name:     "End",
+				Type:     "date",
+				JSONPath: ".status.windowEnd",
+			},
+			{
+				Name:     "Age",
+				Type:     "date",
+				JSONPath: ".metadata.creationTimestamp",
+			},
+		},
+	})
+}
+
+func (a TimePeriod) CustomResourceDefinition() *apiextensions.CustomResourceDefinition {
+	return crdutils.NewCustomResourceDefinition(crdutils.Config{
+		Group:         SchemeGroupVersion.Group,
+		Plural:        ResourcePluralTimePeriod,
+		Singular:      ResourceSingularTimePeriod,
+		Kind:          ResourceKindTimePeriod,
+		ShortNames:    []string{"tp"},
+		Categories:    []string{"monitoring", "appscode", "all"},
+		ResourceScope: string(apiextensions.ClusterScoped),
+		Versions: []apiextensions.CustomResourceDefinitionVersion{
+			{
+				Name:    SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Labels: crdutils.Labels{
+			LabelsMap: map[string]string{"app": "searchlight"},
+		},
+		SpecDefinitionName:      "github.com/appscode/searchlight/apis/monitoring/v1alpha1.TimePeriod",
+		EnableValidation:        true,
+		GetOpenAPIDefinitions:   GetOpenAPIDefinitions,
+		EnableStatusSubresource: EnableStatusSubresource,
+		AdditionalPrinterColumns: []apiextensions.CustomResourceColumnDefinition{
+			{
+				Name:     "TimeZone",
+				Type:     "string",
+				JSONPath: ".spec.timeZone",
+			},
+			{
+				Name:     "Age",
+				Type:     "date",
+				JSONPath: ".metadata.creationTimestamp",
+			},
+		},
+	})
+}
+
+func (a AlertTemplate) CustomResourceDefinition() *apiextensions.CustomResourceDefinition {
+	return crdutils.NewCustomResourceDefinition(crdutils.Config{
+		Group:         SchemeGroupVersion.Group,
+		Plural:        ResourcePluralAlertTemplate,
+		Singular:      ResourceSingularAlertTemplate,
+		Kind:          ResourceKindAlertTemplate,
+		ShortNames:    []string{"at"},
+		Categories:    []string{"monitoring", "appscode", "all"},
+		ResourceScope: string(apiextensions.NamespaceScoped),
+		Versions: []apiextensions.CustomResourceDefinitionVersion{
+			{
+				Name:    SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Labels: crdutils.Labels{
+			LabelsMap: map[string]string{"app": "searchlight"},
+		},
+		SpecDefinitionName:      "github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertTemplate",
+		EnableValidation:        true,
+		GetOpenAPIDefinitions:   GetOpenAPIDefinitions,
+		EnableStatusSubresource: EnableStatusSubresource,
+		AdditionalPrinterColumns: []apiextensions.CustomResourceColumnDefinition{
+			{
+				Name:     "CheckInterval",
+				Type:     "string",
+				JSONPath: ".spec.checkInterval",
+			},
+			{
+				Name:     "Age",
+				Type:     "date",
+				JSONPath: ".metadata.creationTimestamp",
+			},
+		},
+	})
+}
+
+func (a NotifierConfig) CustomResourceDefinition() *apiextensions.CustomResourceDefinition {
+	return crdutils.NewCustomResourceDefinition(crdutils.Config{
+		Group:         SchemeGroupVersion.Group,
+		Plural:        ResourcePluralNotifierConfig,
+		Singular:      ResourceSingularNotifierConfig,
+		Kind:          ResourceKindNotifierConfig,
+		ShortNames:    []string{"nc"},
+		Categories:    []string{"monitoring", "appscode", "all"},
+		ResourceScope: string(apiextensions.ClusterScoped),
+		Versions: []apiextensions.CustomResourceDefinitionVersion{
+			{
+				Name:    SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Labels: crdutils.Labels{
+			LabelsMap: map[string]string{"app": "searchlight"},
+		},
+		SpecDefinitionName:      "github.com/appscode/searchlight/apis/monitoring/v1alpha1.NotifierConfig",
+		EnableValidation:        true,
+		GetOpenAPIDefinitions:   GetOpenAPIDefinitions,
+		EnableStatusSubresource: EnableStatusSubresource,
+		AdditionalPrinterColumns: []apiextensions.CustomResourceColumnDefinition{
+			{
+				Name:     "Notifier",
+				Type:     "string",
+				JSONPath: ".spec.notifier",
+			},
 			{
 				Name:     "Age",
 				Type:     "date",
@@ -204,6 +482,11 @@ func (a SearchlightPlugin) CustomResourceDefinition() *apiextensions.CustomResou
 				Type:     "string",
 				JSONPath: ".spec.command",
 			},
+			{
+				Name:     "Synced",
+				Type:     "string",
+				JSONPath: `.status.conditions[?(@.type=="IcingaSynced")].status`,
+			},
 			{
 				Name:     "Age",
 				Type:     "date",
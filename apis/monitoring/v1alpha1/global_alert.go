@@ -0,0 +1,230 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	ResourceKindGlobalAlert     = "GlobalAlert"
+	ResourcePluralGlobalAlert   = "globalalerts"
+	ResourceSingularGlobalAlert = "globalalert"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type GlobalAlert struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the GlobalAlert.
+	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status
+	Spec GlobalAlertSpec `json:"spec,omitempty"`
+
+	// Status is the most recently observed status of the GlobalAlert.
+	// +optional
+	Status AlertStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GlobalAlertList is a collection of GlobalAlert.
+type GlobalAlertList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of GlobalAlert.
+	Items []GlobalAlert `json:"items"`
+}
+
+// GlobalAlertSpec describes the GlobalAlert the user wishes to create.
+// A GlobalAlert applies a pod check command to matching pods of all matching namespaces.
+type GlobalAlertSpec struct {
+	// NamespaceSelector selects the namespaces whose pods are checked.
+	// An empty selector matches all namespaces.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Selector selects the pods checked in each matching namespace
+	Selector *metav1.LabelSelector `json:"selector"`
+
+	// Icinga CheckCommand name
+	Check string `json:"check,omitempty"`
+
+	// How frequently Icinga Service will be checked
+	CheckInterval metav1.Duration `json:"checkInterval,omitempty"`
+
+	// How frequently notifications will be send
+	AlertInterval metav1.Duration `json:"alertInterval,omitempty"`
+
+	// Number of times Icinga Service is checked before a non-OK state is considered hard
+	// and notifications are sent
+	// +optional
+	MaxCheckAttempts int32 `json:"maxCheckAttempts,omitempty"`
+
+	// How frequently Icinga Service will be re-checked while in a soft non-OK state
+	// +optional
+	RetryInterval metav1.Duration `json:"retryInterval,omitempty"`
+
+	// Timeout of CheckCommand
+	// +optional
+	CheckTimeout metav1.Duration `json:"checkTimeout,omitempty"`
+
+	// Indicates that flap detection is enabled for Icinga Service
+	// +optional
+	EnableFlapping bool `json:"enableFlapping,omitempty"`
+
+	// Percentage of state changes below which Icinga Service stops flapping
+	// +optional
+	FlappingThresholdLow int32 `json:"flappingThresholdLow,omitempty"`
+
+	// Percentage of state changes above which Icinga Service starts flapping
+	// +optional
+	FlappingThresholdHigh int32 `json:"flappingThresholdHigh,omitempty"`
+
+	// Namespace of the Secret containing notifier credentials
+	NotifierSecretNamespace string `json:"notifierSecretNamespace,omitempty"`
+
+	// Secret containing notifier credentials
+	NotifierSecretName string `json:"notifierSecretName,omitempty"`
+
+	// NotifierParams contains information to send notifications for Incident
+	// State, UserUid, Method
+	Receivers []Receiver `json:"receivers,omitempty"`
+
+	// Vars contains Icinga Service variables to be used in CheckCommand. GlobalAlerts don't support
+	// varsFrom, since they aren't bound to the namespace of any secret.
+	Vars map[string]string `json:"vars,omitempty"`
+
+	// Indicates that Check is paused
+	// Icinga Services are removed
+	Paused bool `json:"paused,omitempty"`
+}
+
+var _ Alert = &GlobalAlert{}
+
+func (a GlobalAlert) GetName() string {
+	return a.Name
+}
+
+func (a GlobalAlert) GetNamespace() string {
+	return a.Namespace
+}
+
+func (a GlobalAlert) Command() string {
+	return string(a.Spec.Check)
+}
+
+func (a GlobalAlert) GetCheckInterval() time.Duration {
+	return a.Spec.CheckInterval.Duration
+}
+
+func (a GlobalAlert) GetAlertInterval() time.Duration {
+	return a.Spec.AlertInterval.Duration
+}
+
+func (a GlobalAlert) GetMaxCheckAttempts() int32 {
+	return a.Spec.MaxCheckAttempts
+}
+
+func (a GlobalAlert) GetRetryInterval() time.Duration {
+	return a.Spec.RetryInterval.Duration
+}
+
+func (a GlobalAlert) GetCheckTimeout() time.Duration {
+	return a.Spec.CheckTimeout.Duration
+}
+
+func (a GlobalAlert) GetFlapping() (enabled bool, low, high int32) {
+	return a.Spec.EnableFlapping, a.Spec.FlappingThresholdLow, a.Spec.FlappingThresholdHigh
+}
+
+func (a GlobalAlert) IsValid(kc kubernetes.Interface) error {
+	if a.Spec.Paused {
+		return nil
+	}
+
+	if a.Spec.Selector == nil {
+		return fmt.Errorf("pod selector is required")
+	}
+	if _, err := metav1.LabelSelectorAsSelector(a.Spec.Selector); err != nil {
+		return err
+	}
+	if _, err := a.NamespaceSelector(); err != nil {
+		return err
+	}
+
+	cmd, ok := PodCommands.Get(a.Spec.Check)
+	if !ok {
+		return fmt.Errorf("%s is not a valid pod check command", a.Spec.Check)
+	}
+
+	if err := validateVariables(cmd.Vars, a.Spec.Vars, nil); err != nil {
+		return err
+	}
+
+	if err := validateCheckSettings(a); err != nil {
+		return err
+	}
+
+	for _, rcv := range a.Spec.Receivers {
+		found := false
+		for _, state := range cmd.States {
+			if strings.EqualFold(state, rcv.State) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("state %s is unsupported for check command %s", rcv.State, a.Spec.Check)
+		}
+	}
+
+	if a.Spec.NotifierSecretName != "" && a.Spec.NotifierSecretNamespace == "" {
+		return fmt.Errorf("notifier secret namespace is required for cluster scoped alerts")
+	}
+	return checkNotifiers(kc, a)
+}
+
+// NamespaceSelector returns the selector of the namespaces whose pods are checked. A missing
+// NamespaceSelector matches all namespaces.
+func (a GlobalAlert) NamespaceSelector() (labels.Selector, error) {
+	if a.Spec.NamespaceSelector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(a.Spec.NamespaceSelector)
+}
+
+func (a GlobalAlert) GetNotifierSecretNamespace() string {
+	return a.Spec.NotifierSecretNamespace
+}
+
+func (a GlobalAlert) GetNotifierSecretName() string {
+	return a.Spec.NotifierSecretName
+}
+
+func (a GlobalAlert) GetReceivers() []Receiver {
+	return a.Spec.Receivers
+}
+
+func (a GlobalAlert) ObjectReference() *core.ObjectReference {
+	return &core.ObjectReference{
+		APIVersion:      SchemeGroupVersion.String(),
+		Kind:            ResourceKindGlobalAlert,
+		Name:            a.Name,
+		UID:             a.UID,
+		ResourceVersion: a.ResourceVersion,
+	}
+}
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"fmt"
 	"strings"
 	"sync"
 
@@ -15,6 +16,11 @@ const (
 	CheckPodExec   = "pod-exec"
 )
 
+const (
+	CheckWorkloadStatus   = "workload-status"
+	CheckWorkloadRestarts = "workload-restarts"
+)
+
 const (
 	CheckNodeVolume = "node-volume"
 	CheckNodeStatus = "node-status"
@@ -62,33 +68,67 @@ type IcingaCommand struct {
 }
 
 var (
-	PodCommands     = &Registry{reg: map[string]IcingaCommand{}}
-	NodeCommands    = &Registry{reg: map[string]IcingaCommand{}}
-	ClusterCommands = &Registry{reg: map[string]IcingaCommand{}}
+	PodCommands      = &Registry{reg: map[string]IcingaCommand{}}
+	NodeCommands     = &Registry{reg: map[string]IcingaCommand{}}
+	ClusterCommands  = &Registry{reg: map[string]IcingaCommand{}}
+	WorkloadCommands = &Registry{reg: map[string]IcingaCommand{}}
 )
 
+func validateCheckSettings(alert Alert) error {
+	if alert.GetMaxCheckAttempts() < 0 {
+		return fmt.Errorf("maxCheckAttempts must not be negative")
+	}
+	if alert.GetRetryInterval() < 0 {
+		return fmt.Errorf("retryInterval must not be negative")
+	}
+	if alert.GetCheckTimeout() < 0 {
+		return fmt.Errorf("checkTimeout must not be negative")
+	}
+	_, low, high := alert.GetFlapping()
+	if low < 0 || low > 100 || high < 0 || high > 100 {
+		return fmt.Errorf("flapping thresholds must be between 0 and 100 percent")
+	}
+	if low > 0 && high > 0 && low >= high {
+		return fmt.Errorf("flappingThresholdLow must be less than flappingThresholdHigh")
+	}
+	return nil
+}
+
 func checkNotifiers(kc kubernetes.Interface, alert Alert) error {
-	if alert.GetNotifierSecretName() == "" && len(alert.GetReceivers()) == 0 {
+	for _, r := range alert.GetReceivers() {
+		if r.EscalateAfter != nil && r.EscalateAfter.Duration <= 0 {
+			return fmt.Errorf("escalateAfter of receiver %s must be positive", r.Notifier)
+		}
+	}
+	if alert.GetNotifierSecretName() == "" && !usesNotifierSecret(alert.GetReceivers()) {
 		return nil
 	}
-	secret, err := kc.CoreV1().Secrets(alert.GetNamespace()).Get(alert.GetNotifierSecretName(), metav1.GetOptions{})
+	secret, err := kc.CoreV1().Secrets(alert.GetNotifierSecretNamespace()).Get(alert.GetNotifierSecretName(), metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
 	for _, r := range alert.GetReceivers() {
-		_, err = unified.LoadVia(r.Notifier, func(key string) (value string, found bool) {
-			var bytes []byte
-			bytes, found = secret.Data[key]
-			value = string(bytes)
-			return
-		})
-		if err != nil {
+		// credentials of NotifierConfigs are checked when they are created
+		if r.NotifierConfig != "" {
+			continue
+		}
+		if _, err = unified.LoadVia(r.Notifier, SecretLoader(secret)); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// usesNotifierSecret reports whether any receiver uses the notifier secret of its alert.
+func usesNotifierSecret(receivers []Receiver) bool {
+	for _, r := range receivers {
+		if r.NotifierConfig == "" {
+			return true
+		}
+	}
+	return false
+}
+
 func AlertType(t string) IncidentNotificationType {
 	switch strings.ToUpper(t) {
 	case "PROBLEM":
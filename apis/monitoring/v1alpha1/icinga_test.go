@@ -0,0 +1,77 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateCheckSettings(t *testing.T) {
+	cases := []struct {
+		name    string
+		spec    PodAlertSpec
+		wantErr bool
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name: "valid",
+			spec: PodAlertSpec{
+				MaxCheckAttempts:      3,
+				RetryInterval:         metav1.Duration{Duration: time.Minute},
+				CheckTimeout:          metav1.Duration{Duration: 10 * time.Second},
+				FlappingThresholdLow:  25,
+				FlappingThresholdHigh: 30,
+			},
+		},
+		{
+			name:    "negative max check attempts",
+			spec:    PodAlertSpec{MaxCheckAttempts: -1},
+			wantErr: true,
+		},
+		{
+			name:    "negative retry interval",
+			spec:    PodAlertSpec{RetryInterval: metav1.Duration{Duration: -time.Second}},
+			wantErr: true,
+		},
+		{
+			name:    "negative check timeout",
+			spec:    PodAlertSpec{CheckTimeout: metav1.Duration{Duration: -time.Second}},
+			wantErr: true,
+		},
+		{
+			name: "thresholds at bounds",
+			spec: PodAlertSpec{FlappingThresholdLow: 0, FlappingThresholdHigh: 100},
+		},
+		{
+			name:    "negative low threshold",
+			spec:    PodAlertSpec{FlappingThresholdLow: -1},
+			wantErr: true,
+		},
+		{
+			name:    "high threshold above 100",
+			spec:    PodAlertSpec{FlappingThresholdHigh: 101},
+			wantErr: true,
+		},
+		{
+			name:    "equal thresholds",
+			spec:    PodAlertSpec{FlappingThresholdLow: 30, FlappingThresholdHigh: 30},
+			wantErr: true,
+		},
+		{
+			name:    "low threshold above high",
+			spec:    PodAlertSpec{FlappingThresholdLow: 50, FlappingThresholdHigh: 30},
+			wantErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validateCheckSettings(&PodAlert{Spec: c.spec})
+			if (err != nil) != c.wantErr {
+				t.Errorf("expected error %v, got %v", c.wantErr, err)
+			}
+		})
+	}
+}
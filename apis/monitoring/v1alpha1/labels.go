@@ -4,5 +4,10 @@ const (
 	LabelKeyAlert            = "monitoring.appscode.com/alert"
 	LabelKeyAlertType        = "monitoring.appscode.com/alert-type"
 	LabelKeyObjectName       = "monitoring.appscode.com/object-name"
+	LabelKeyObjectKind       = "monitoring.appscode.com/object-kind"
 	LabelKeyProblemRecovered = "monitoring.appscode.com/recovered"
+
+	// LabelKeyLeader is set to "true" on the pod of the operator replica holding the leader Lease,
+	// so that the Service of the Icinga UI and API only routes to the leader.
+	LabelKeyLeader = "monitoring.appscode.com/leader"
 )
@@ -7,6 +7,7 @@ import (
 
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -17,7 +18,6 @@ const (
 )
 
 // +genclient
-// +genclient:skipVerbs=updateStatus
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -30,6 +30,10 @@ type NodeAlert struct {
 	// Spec is the desired state of the NodeAlert.
 	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status
 	Spec NodeAlertSpec `json:"spec,omitempty"`
+
+	// Status is the most recently observed status of the NodeAlert.
+	// +optional
+	Status AlertStatus `json:"status,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -47,19 +51,62 @@ type NodeAlertList struct {
 
 // NodeAlertSpec describes the NodeAlert the user wishes to create.
 type NodeAlertSpec struct {
+	// Deprecated: Use labelSelector. Searchlight operator migrates it to labelSelector.
 	Selector map[string]string `json:"selector,omitempty"`
 
+	// LabelSelector selects nodes by labels
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// Taints selects nodes by taints. All of them must match.
+	// +optional
+	Taints []NodeTaintSelector `json:"taints,omitempty"`
+
+	// Conditions selects nodes by status of conditions. All of them must match.
+	// +optional
+	Conditions []NodeConditionSelector `json:"conditions,omitempty"`
+
 	NodeName *string `json:"nodeName,omitempty"`
 
 	// Icinga CheckCommand name
 	Check string `json:"check,omitempty"`
 
+	// Name of an AlertTemplate in the same namespace whose values are used for fields
+	// not set in this spec
+	// +optional
+	TemplateRef string `json:"templateRef,omitempty"`
+
 	// How frequently Icinga Service will be checked
 	CheckInterval metav1.Duration `json:"checkInterval,omitempty"`
 
 	// How frequently notifications will be send
 	AlertInterval metav1.Duration `json:"alertInterval,omitempty"`
 
+	// Number of times Icinga Service is checked before a non-OK state is considered hard
+	// and notifications are sent
+	// +optional
+	MaxCheckAttempts int32 `json:"maxCheckAttempts,omitempty"`
+
+	// How frequently Icinga Service will be re-checked while in a soft non-OK state
+	// +optional
+	RetryInterval metav1.Duration `json:"retryInterval,omitempty"`
+
+	// Timeout of CheckCommand
+	// +optional
+	CheckTimeout metav1.Duration `json:"checkTimeout,omitempty"`
+
+	// Indicates that flap detection is enabled for Icinga Service
+	// +optional
+	EnableFlapping bool `json:"enableFlapping,omitempty"`
+
+	// Percentage of state changes below which Icinga Service stops flapping
+	// +optional
+	FlappingThresholdLow int32 `json:"flappingThresholdLow,omitempty"`
+
+	// Percentage of state changes above which Icinga Service starts flapping
+	// +optional
+	FlappingThresholdHigh int32 `json:"flappingThresholdHigh,omitempty"`
+
 	// Secret containing notifier credentials
 	NotifierSecretName string `json:"notifierSecretName,omitempty"`
 
@@ -70,11 +117,50 @@ type NodeAlertSpec struct {
 	// Vars contains Icinga Service variables to be used in CheckCommand
 	Vars map[string]string `json:"vars,omitempty"`
 
+	// VarsFrom contains Icinga Service variables whose values are read from secrets in the
+	// namespace of the alert at check time
+	// +optional
+	VarsFrom map[string]VarSource `json:"varsFrom,omitempty"`
+
 	// Indicates that Check is paused
 	// Icinga Services are removed
 	Paused bool `json:"paused,omitempty"`
 }
 
+type NodeTaintOperator string
+
+const (
+	NodeTaintOpExists       NodeTaintOperator = "Exists"
+	NodeTaintOpDoesNotExist NodeTaintOperator = "DoesNotExist"
+)
+
+// NodeTaintSelector matches nodes which have, or don't have, a taint.
+type NodeTaintSelector struct {
+	// Key of the taint
+	Key string `json:"key"`
+
+	// Value of the taint. Any value matches if empty.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Effect of the taint. Any effect matches if empty.
+	// +optional
+	Effect core.TaintEffect `json:"effect,omitempty"`
+
+	// Operator is either Exists or DoesNotExist. Defaults to Exists.
+	// +optional
+	Operator NodeTaintOperator `json:"operator,omitempty"`
+}
+
+// NodeConditionSelector matches nodes which have a condition with given status.
+type NodeConditionSelector struct {
+	// Type of the node condition, e.g. Ready
+	Type core.NodeConditionType `json:"type"`
+
+	// Status of the node condition, one of True, False or Unknown
+	Status core.ConditionStatus `json:"status"`
+}
+
 var _ Alert = &NodeAlert{}
 
 func (a NodeAlert) GetName() string {
@@ -97,21 +183,65 @@ func (a NodeAlert) GetAlertInterval() time.Duration {
 	return a.Spec.AlertInterval.Duration
 }
 
+func (a NodeAlert) GetMaxCheckAttempts() int32 {
+	return a.Spec.MaxCheckAttempts
+}
+
+func (a NodeAlert) GetRetryInterval() time.Duration {
+	return a.Spec.RetryInterval.Duration
+}
+
+func (a NodeAlert) GetCheckTimeout() time.Duration {
+	return a.Spec.CheckTimeout.Duration
+}
+
+func (a NodeAlert) GetFlapping() (enabled bool, low, high int32) {
+	return a.Spec.EnableFlapping, a.Spec.FlappingThresholdLow, a.Spec.FlappingThresholdHigh
+}
+
 func (a NodeAlert) IsValid(kc kubernetes.Interface) error {
 	if a.Spec.Paused {
 		return nil
 	}
 
-	if a.Spec.NodeName != nil && len(a.Spec.Selector) > 0 {
+	if a.Spec.NodeName != nil && (len(a.Spec.Selector) > 0 || a.Spec.LabelSelector != nil || len(a.Spec.Taints) > 0 || len(a.Spec.Conditions) > 0) {
 		return fmt.Errorf("can't specify both node name and selector")
 	}
+	if len(a.Spec.Selector) > 0 && a.Spec.LabelSelector != nil {
+		return fmt.Errorf("can't specify both selector and labelSelector")
+	}
+	if a.Spec.LabelSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(a.Spec.LabelSelector); err != nil {
+			return err
+		}
+	}
+	for _, t := range a.Spec.Taints {
+		if t.Key == "" {
+			return fmt.Errorf("taint key is required")
+		}
+		if t.Operator != "" && t.Operator != NodeTaintOpExists && t.Operator != NodeTaintOpDoesNotExist {
+			return fmt.Errorf("taint operator %s is unsupported", t.Operator)
+		}
+	}
+	for _, c := range a.Spec.Conditions {
+		if c.Type == "" {
+			return fmt.Errorf("condition type is required")
+		}
+		if c.Status != core.ConditionTrue && c.Status != core.ConditionFalse && c.Status != core.ConditionUnknown {
+			return fmt.Errorf("condition status %s is unsupported", c.Status)
+		}
+	}
 
 	cmd, ok := NodeCommands.Get(a.Spec.Check)
 	if !ok {
 		return fmt.Errorf("%s is not a valid node check command", a.Spec.Check)
 	}
 
-	if err := validateVariables(cmd.Vars, a.Spec.Vars); err != nil {
+	if err := validateVariables(cmd.Vars, a.Spec.Vars, a.Spec.VarsFrom); err != nil {
+		return err
+	}
+
+	if err := validateCheckSettings(a); err != nil {
 		return err
 	}
 
@@ -131,6 +261,63 @@ func (a NodeAlert) IsValid(kc kubernetes.Interface) error {
 	return checkNotifiers(kc, a)
 }
 
+// NodeSelector returns the label selector of the NodeAlert. The deprecated map selector
+// is used if labelSelector is not set. All nodes are selected if neither is set.
+func (a NodeAlert) NodeSelector() (labels.Selector, error) {
+	if a.Spec.LabelSelector != nil {
+		return metav1.LabelSelectorAsSelector(a.Spec.LabelSelector)
+	}
+	return labels.SelectorFromSet(a.Spec.Selector), nil
+}
+
+// MatchesNode reports whether the NodeAlert selects a node.
+func (a NodeAlert) MatchesNode(node *core.Node) (bool, error) {
+	if a.Spec.NodeName != nil {
+		return *a.Spec.NodeName == node.Name, nil
+	}
+
+	sel, err := a.NodeSelector()
+	if err != nil {
+		return false, err
+	}
+	if !sel.Matches(labels.Set(node.Labels)) {
+		return false, nil
+	}
+
+	for _, ts := range a.Spec.Taints {
+		found := false
+		for _, taint := range node.Spec.Taints {
+			if taint.Key == ts.Key &&
+				(ts.Value == "" || taint.Value == ts.Value) &&
+				(ts.Effect == "" || taint.Effect == ts.Effect) {
+				found = true
+				break
+			}
+		}
+		if found == (ts.Operator == NodeTaintOpDoesNotExist) {
+			return false, nil
+		}
+	}
+
+	for _, cs := range a.Spec.Conditions {
+		found := false
+		for _, cond := range node.Status.Conditions {
+			if cond.Type == cs.Type && cond.Status == cs.Status {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (a NodeAlert) GetNotifierSecretNamespace() string {
+	return a.Namespace
+}
+
 func (a NodeAlert) GetNotifierSecretName() string {
 	return a.Spec.NotifierSecretName
 }
@@ -0,0 +1,163 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNodeAlertMatchesNode(t *testing.T) {
+	node := &core.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-0", Labels: map[string]string{"role": "worker"}},
+		Spec: core.NodeSpec{
+			Taints: []core.Taint{
+				{Key: "dedicated", Value: "gpu", Effect: core.TaintEffectNoSchedule},
+			},
+		},
+		Status: core.NodeStatus{
+			Conditions: []core.NodeCondition{
+				{Type: core.NodeReady, Status: core.ConditionTrue},
+				{Type: core.NodeDiskPressure, Status: core.ConditionFalse},
+			},
+		},
+	}
+	untainted := node.DeepCopy()
+	untainted.Spec.Taints = nil
+	nodeName := func(name string) *string { return &name }
+
+	cases := []struct {
+		name     string
+		spec     NodeAlertSpec
+		node     *core.Node
+		expected bool
+	}{
+		{
+			name:     "no selector",
+			node:     node,
+			expected: true,
+		},
+		{
+			name:     "node name",
+			spec:     NodeAlertSpec{NodeName: nodeName("node-0")},
+			node:     node,
+			expected: true,
+		},
+		{
+			name: "other node name",
+			spec: NodeAlertSpec{NodeName: nodeName("node-1")},
+			node: node,
+		},
+		{
+			name:     "deprecated selector",
+			spec:     NodeAlertSpec{Selector: map[string]string{"role": "worker"}},
+			node:     node,
+			expected: true,
+		},
+		{
+			name: "unmatched label selector",
+			spec: NodeAlertSpec{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "master"}}},
+			node: node,
+		},
+		{
+			name:     "taint exists",
+			spec:     NodeAlertSpec{Taints: []NodeTaintSelector{{Key: "dedicated", Value: "gpu", Effect: core.TaintEffectNoSchedule, Operator: NodeTaintOpExists}}},
+			node:     node,
+			expected: true,
+		},
+		{
+			name:     "taint exists with empty operator",
+			spec:     NodeAlertSpec{Taints: []NodeTaintSelector{{Key: "dedicated"}}},
+			node:     node,
+			expected: true,
+		},
+		{
+			name: "taint with other value",
+			spec: NodeAlertSpec{Taints: []NodeTaintSelector{{Key: "dedicated", Value: "cpu"}}},
+			node: node,
+		},
+		{
+			name: "taint with other effect",
+			spec: NodeAlertSpec{Taints: []NodeTaintSelector{{Key: "dedicated", Effect: core.TaintEffectNoExecute}}},
+			node: node,
+		},
+		{
+			name:     "taint with empty value and effect",
+			spec:     NodeAlertSpec{Taints: []NodeTaintSelector{{Key: "dedicated", Value: "", Effect: ""}}},
+			node:     node,
+			expected: true,
+		},
+		{
+			name: "taint does not exist on tainted node",
+			spec: NodeAlertSpec{Taints: []NodeTaintSelector{{Key: "dedicated", Operator: NodeTaintOpDoesNotExist}}},
+			node: node,
+		},
+		{
+			name:     "taint with other value does not exist",
+			spec:     NodeAlertSpec{Taints: []NodeTaintSelector{{Key: "dedicated", Value: "cpu", Operator: NodeTaintOpDoesNotExist}}},
+			node:     node,
+			expected: true,
+		},
+		{
+			name: "taint exists on node without taints",
+			spec: NodeAlertSpec{Taints: []NodeTaintSelector{{Key: "dedicated"}}},
+			node: untainted,
+		},
+		{
+			name:     "taint does not exist on node without taints",
+			spec:     NodeAlertSpec{Taints: []NodeTaintSelector{{Key: "dedicated", Operator: NodeTaintOpDoesNotExist}}},
+			node:     untainted,
+			expected: true,
+		},
+		{
+			name: "all taints must match",
+			spec: NodeAlertSpec{Taints: []NodeTaintSelector{{Key: "dedicated"}, {Key: "spot"}}},
+			node: node,
+		},
+		{
+			name:     "condition",
+			spec:     NodeAlertSpec{Conditions: []NodeConditionSelector{{Type: core.NodeReady, Status: core.ConditionTrue}}},
+			node:     node,
+			expected: true,
+		},
+		{
+			name: "condition with other status",
+			spec: NodeAlertSpec{Conditions: []NodeConditionSelector{{Type: core.NodeReady, Status: core.ConditionFalse}}},
+			node: node,
+		},
+		{
+			name: "missing condition",
+			spec: NodeAlertSpec{Conditions: []NodeConditionSelector{{Type: core.NodeMemoryPressure, Status: core.ConditionFalse}}},
+			node: node,
+		},
+		{
+			name: "all conditions must match",
+			spec: NodeAlertSpec{Conditions: []NodeConditionSelector{
+				{Type: core.NodeReady, Status: core.ConditionTrue},
+				{Type: core.NodeDiskPressure, Status: core.ConditionTrue},
+			}},
+			node: node,
+		},
+		{
+			name: "labels, taints and conditions",
+			spec: NodeAlertSpec{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "worker"}},
+				Taints:        []NodeTaintSelector{{Key: "spot", Operator: NodeTaintOpDoesNotExist}},
+				Conditions:    []NodeConditionSelector{{Type: core.NodeDiskPressure, Status: core.ConditionFalse}},
+			},
+			node:     node,
+			expected: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			matches, err := NodeAlert{Spec: c.spec}.MatchesNode(c.node)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if matches != c.expected {
+				t.Errorf("expected match %v, got %v", c.expected, matches)
+			}
+		})
+	}
+}
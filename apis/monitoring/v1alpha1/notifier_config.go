@@ -0,0 +1,105 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"gomodules.xyz/envconfig"
+	"gomodules.xyz/notify/unified"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	ResourceKindNotifierConfig     = "NotifierConfig"
+	ResourcePluralNotifierConfig   = "notifierconfigs"
+	ResourceSingularNotifierConfig = "notifierconfig"
+
+	// VerbUseNotifierConfig is the RBAC verb required to refer to a NotifierConfig from a receiver.
+	VerbUseNotifierConfig = "use"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NotifierConfig bundles a notifier with the secret containing its credentials. Receivers of
+// alerts in any namespace can refer to it, if they are allowed to use it.
+type NotifierConfig struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the NotifierConfig.
+	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status
+	Spec NotifierConfigSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NotifierConfigList is a collection of NotifierConfig.
+type NotifierConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of NotifierConfig.
+	Items []NotifierConfig `json:"items"`
+}
+
+// NotifierConfigSpec describes the NotifierConfig the user wishes to create.
+type NotifierConfigSpec struct {
+	// Notifier name, e.g. Mailgun or Twilio
+	Notifier string `json:"notifier"`
+
+	// Secret containing notifier credentials
+	SecretRef NotifierSecretReference `json:"secretRef"`
+}
+
+// NotifierSecretReference refers to a secret in any namespace.
+type NotifierSecretReference struct {
+	// Namespace of the secret
+	Namespace string `json:"namespace"`
+
+	// Name of the secret
+	Name string `json:"name"`
+}
+
+// IsValid checks that the secret of the NotifierConfig contains the credentials of its notifier.
+func (c NotifierConfig) IsValid(kc kubernetes.Interface) error {
+	if c.Spec.Notifier == "" {
+		return fmt.Errorf("notifier is required")
+	}
+	if c.Spec.SecretRef.Namespace == "" || c.Spec.SecretRef.Name == "" {
+		return fmt.Errorf("namespace and name of secretRef are required")
+	}
+	secret, err := kc.CoreV1().Secrets(c.Spec.SecretRef.Namespace).Get(c.Spec.SecretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	_, err = unified.LoadVia(c.Spec.Notifier, SecretLoader(secret))
+	return err
+}
+
+func (c NotifierConfig) ObjectReference() *core.ObjectReference {
+	return &core.ObjectReference{
+		APIVersion:      SchemeGroupVersion.String(),
+		Kind:            ResourceKindNotifierConfig,
+		Name:            c.Name,
+		UID:             c.UID,
+		ResourceVersion: c.ResourceVersion,
+	}
+}
+
+// SecretLoader returns a function loading notifier credentials from a secret.
+func SecretLoader(secret *core.Secret) envconfig.LoaderFunc {
+	return func(key string) (value string, found bool) {
+		var bytes []byte
+		bytes, found = secret.Data[key]
+		value = string(bytes)
+		return
+	}
+}
@@ -1,3 +1,4 @@
+//go:build !ignore_autogenerated
 // +build !ignore_autogenerated
 
 /*
@@ -32,289 +33,298 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.ClusterAlert":          schema_searchlight_apis_monitoring_v1alpha1_ClusterAlert(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.ClusterAlertList":      schema_searchlight_apis_monitoring_v1alpha1_ClusterAlertList(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.ClusterAlertSpec":      schema_searchlight_apis_monitoring_v1alpha1_ClusterAlertSpec(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.IcingaCommand":         schema_searchlight_apis_monitoring_v1alpha1_IcingaCommand(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.Incident":              schema_searchlight_apis_monitoring_v1alpha1_Incident(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.IncidentList":          schema_searchlight_apis_monitoring_v1alpha1_IncidentList(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.IncidentNotification":  schema_searchlight_apis_monitoring_v1alpha1_IncidentNotification(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.IncidentStatus":        schema_searchlight_apis_monitoring_v1alpha1_IncidentStatus(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeAlert":             schema_searchlight_apis_monitoring_v1alpha1_NodeAlert(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeAlertList":         schema_searchlight_apis_monitoring_v1alpha1_NodeAlertList(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeAlertSpec":         schema_searchlight_apis_monitoring_v1alpha1_NodeAlertSpec(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginArguments":       schema_searchlight_apis_monitoring_v1alpha1_PluginArguments(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginVarField":        schema_searchlight_apis_monitoring_v1alpha1_PluginVarField(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginVars":            schema_searchlight_apis_monitoring_v1alpha1_PluginVars(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PodAlert":              schema_searchlight_apis_monitoring_v1alpha1_PodAlert(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PodAlertList":          schema_searchlight_apis_monitoring_v1alpha1_PodAlertList(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PodAlertSpec":          schema_searchlight_apis_monitoring_v1alpha1_PodAlertSpec(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.Receiver":              schema_searchlight_apis_monitoring_v1alpha1_Receiver(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.Registry":              schema_searchlight_apis_monitoring_v1alpha1_Registry(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPlugin":     schema_searchlight_apis_monitoring_v1alpha1_SearchlightPlugin(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPluginList": schema_searchlight_apis_monitoring_v1alpha1_SearchlightPluginList(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPluginSpec": schema_searchlight_apis_monitoring_v1alpha1_SearchlightPluginSpec(ref),
-		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.WebhookServiceSpec":    schema_searchlight_apis_monitoring_v1alpha1_WebhookServiceSpec(ref),
-		"k8s.io/apimachinery/pkg/api/resource.Quantity":                                  schema_apimachinery_pkg_api_resource_Quantity(ref),
-		"k8s.io/apimachinery/pkg/api/resource.int64Amount":                               schema_apimachinery_pkg_api_resource_int64Amount(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroup":                                  schema_pkg_apis_meta_v1_APIGroup(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroupList":                              schema_pkg_apis_meta_v1_APIGroupList(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResource":                               schema_pkg_apis_meta_v1_APIResource(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResourceList":                           schema_pkg_apis_meta_v1_APIResourceList(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIVersions":                               schema_pkg_apis_meta_v1_APIVersions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.CreateOptions":                             schema_pkg_apis_meta_v1_CreateOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.DeleteOptions":                             schema_pkg_apis_meta_v1_DeleteOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Duration":                                  schema_pkg_apis_meta_v1_Duration(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ExportOptions":                             schema_pkg_apis_meta_v1_ExportOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Fields":                                    schema_pkg_apis_meta_v1_Fields(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GetOptions":                                schema_pkg_apis_meta_v1_GetOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupKind":                                 schema_pkg_apis_meta_v1_GroupKind(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupResource":                             schema_pkg_apis_meta_v1_GroupResource(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersion":                              schema_pkg_apis_meta_v1_GroupVersion(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionForDiscovery":                  schema_pkg_apis_meta_v1_GroupVersionForDiscovery(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionKind":                          schema_pkg_apis_meta_v1_GroupVersionKind(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionResource":                      schema_pkg_apis_meta_v1_GroupVersionResource(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Initializer":                               schema_pkg_apis_meta_v1_Initializer(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Initializers":                              schema_pkg_apis_meta_v1_Initializers(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.InternalEvent":                             schema_pkg_apis_meta_v1_InternalEvent(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector":                             schema_pkg_apis_meta_v1_LabelSelector(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelectorRequirement":                  schema_pkg_apis_meta_v1_LabelSelectorRequirement(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.List":                                      schema_pkg_apis_meta_v1_List(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta":                                  schema_pkg_apis_meta_v1_ListMeta(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ListOptions":                               schema_pkg_apis_meta_v1_ListOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ManagedFieldsEntry":                        schema_pkg_apis_meta_v1_ManagedFieldsEntry(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime":                                 schema_pkg_apis_meta_v1_MicroTime(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta":                                schema_pkg_apis_meta_v1_ObjectMeta(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.OwnerReference":                            schema_pkg_apis_meta_v1_OwnerReference(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Patch":                                     schema_pkg_apis_meta_v1_Patch(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.PatchOptions":                              schema_pkg_apis_meta_v1_PatchOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Preconditions":                             schema_pkg_apis_meta_v1_Preconditions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.RootPaths":                                 schema_pkg_apis_meta_v1_RootPaths(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ServerAddressByClientCIDR":                 schema_pkg_apis_meta_v1_ServerAddressByClientCIDR(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Status":                                    schema_pkg_apis_meta_v1_Status(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusCause":                               schema_pkg_apis_meta_v1_StatusCause(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusDetails":                             schema_pkg_apis_meta_v1_StatusDetails(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Time":                                      schema_pkg_apis_meta_v1_Time(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Timestamp":                                 schema_pkg_apis_meta_v1_Timestamp(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TypeMeta":                                  schema_pkg_apis_meta_v1_TypeMeta(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.UpdateOptions":                             schema_pkg_apis_meta_v1_UpdateOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.WatchEvent":                                schema_pkg_apis_meta_v1_WatchEvent(ref),
-		"k8s.io/apimachinery/pkg/runtime.RawExtension":                                   schema_k8sio_apimachinery_pkg_runtime_RawExtension(ref),
-		"k8s.io/apimachinery/pkg/runtime.TypeMeta":                                       schema_k8sio_apimachinery_pkg_runtime_TypeMeta(ref),
-		"k8s.io/apimachinery/pkg/runtime.Unknown":                                        schema_k8sio_apimachinery_pkg_runtime_Unknown(ref),
-		"k8s.io/apimachinery/pkg/version.Info":                                           schema_k8sio_apimachinery_pkg_version_Info(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertCondition":             schema_searchlight_apis_monitoring_v1alpha1_AlertCondition(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertDependency":            schema_searchlight_apis_monitoring_v1alpha1_AlertDependency(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertStatus":                schema_searchlight_apis_monitoring_v1alpha1_AlertStatus(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertTarget":                schema_searchlight_apis_monitoring_v1alpha1_AlertTarget(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertTemplate":              schema_searchlight_apis_monitoring_v1alpha1_AlertTemplate(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertTemplateList":          schema_searchlight_apis_monitoring_v1alpha1_AlertTemplateList(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertTemplateSpec":          schema_searchlight_apis_monitoring_v1alpha1_AlertTemplateSpec(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.ClusterAlert":               schema_searchlight_apis_monitoring_v1alpha1_ClusterAlert(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.ClusterAlertList":           schema_searchlight_apis_monitoring_v1alpha1_ClusterAlertList(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.ClusterAlertSpec":           schema_searchlight_apis_monitoring_v1alpha1_ClusterAlertSpec(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.GlobalAlert":                schema_searchlight_apis_monitoring_v1alpha1_GlobalAlert(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.GlobalAlertList":            schema_searchlight_apis_monitoring_v1alpha1_GlobalAlertList(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.GlobalAlertSpec":            schema_searchlight_apis_monitoring_v1alpha1_GlobalAlertSpec(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.IcingaCommand":              schema_searchlight_apis_monitoring_v1alpha1_IcingaCommand(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.Incident":                   schema_searchlight_apis_monitoring_v1alpha1_Incident(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.IncidentList":               schema_searchlight_apis_monitoring_v1alpha1_IncidentList(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.IncidentNotification":       schema_searchlight_apis_monitoring_v1alpha1_IncidentNotification(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.IncidentStatus":             schema_searchlight_apis_monitoring_v1alpha1_IncidentStatus(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeAlert":                  schema_searchlight_apis_monitoring_v1alpha1_NodeAlert(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeAlertList":              schema_searchlight_apis_monitoring_v1alpha1_NodeAlertList(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeAlertSpec":              schema_searchlight_apis_monitoring_v1alpha1_NodeAlertSpec(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeConditionSelector":      schema_searchlight_apis_monitoring_v1alpha1_NodeConditionSelector(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeTaintSelector":          schema_searchlight_apis_monitoring_v1alpha1_NodeTaintSelector(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.NotifierConfig":             schema_searchlight_apis_monitoring_v1alpha1_NotifierConfig(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.NotifierConfigList":         schema_searchlight_apis_monitoring_v1alpha1_NotifierConfigList(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.NotifierConfigSpec":         schema_searchlight_apis_monitoring_v1alpha1_NotifierConfigSpec(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.NotifierSecretReference":    schema_searchlight_apis_monitoring_v1alpha1_NotifierSecretReference(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginAlerts":               schema_searchlight_apis_monitoring_v1alpha1_PluginAlerts(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginArguments":            schema_searchlight_apis_monitoring_v1alpha1_PluginArguments(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginVarField":             schema_searchlight_apis_monitoring_v1alpha1_PluginVarField(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginVars":                 schema_searchlight_apis_monitoring_v1alpha1_PluginVars(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PodAlert":                   schema_searchlight_apis_monitoring_v1alpha1_PodAlert(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PodAlertList":               schema_searchlight_apis_monitoring_v1alpha1_PodAlertList(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PodAlertSpec":               schema_searchlight_apis_monitoring_v1alpha1_PodAlertSpec(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.Receiver":                   schema_searchlight_apis_monitoring_v1alpha1_Receiver(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.Registry":                   schema_searchlight_apis_monitoring_v1alpha1_Registry(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.RolloutSuppression":         schema_searchlight_apis_monitoring_v1alpha1_RolloutSuppression(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPlugin":          schema_searchlight_apis_monitoring_v1alpha1_SearchlightPlugin(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPluginCondition": schema_searchlight_apis_monitoring_v1alpha1_SearchlightPluginCondition(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPluginList":      schema_searchlight_apis_monitoring_v1alpha1_SearchlightPluginList(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPluginSpec":      schema_searchlight_apis_monitoring_v1alpha1_SearchlightPluginSpec(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPluginStatus":    schema_searchlight_apis_monitoring_v1alpha1_SearchlightPluginStatus(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SecretKeySelector":          schema_searchlight_apis_monitoring_v1alpha1_SecretKeySelector(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.Silence":                    schema_searchlight_apis_monitoring_v1alpha1_Silence(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SilenceList":                schema_searchlight_apis_monitoring_v1alpha1_SilenceList(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SilenceMatcher":             schema_searchlight_apis_monitoring_v1alpha1_SilenceMatcher(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SilenceSchedule":            schema_searchlight_apis_monitoring_v1alpha1_SilenceSchedule(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SilenceSpec":                schema_searchlight_apis_monitoring_v1alpha1_SilenceSpec(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SilenceStatus":              schema_searchlight_apis_monitoring_v1alpha1_SilenceStatus(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SilenceTarget":              schema_searchlight_apis_monitoring_v1alpha1_SilenceTarget(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.TimePeriod":                 schema_searchlight_apis_monitoring_v1alpha1_TimePeriod(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.TimePeriodList":             schema_searchlight_apis_monitoring_v1alpha1_TimePeriodList(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.TimePeriodSpec":             schema_searchlight_apis_monitoring_v1alpha1_TimePeriodSpec(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.TimeRange":                  schema_searchlight_apis_monitoring_v1alpha1_TimeRange(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.VarSource":                  schema_searchlight_apis_monitoring_v1alpha1_VarSource(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.WebhookServiceSpec":         schema_searchlight_apis_monitoring_v1alpha1_WebhookServiceSpec(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.WorkloadAlert":              schema_searchlight_apis_monitoring_v1alpha1_WorkloadAlert(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.WorkloadAlertList":          schema_searchlight_apis_monitoring_v1alpha1_WorkloadAlertList(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.WorkloadAlertSpec":          schema_searchlight_apis_monitoring_v1alpha1_WorkloadAlertSpec(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.WorkloadTargetRef":          schema_searchlight_apis_monitoring_v1alpha1_WorkloadTargetRef(ref),
+		"github.com/appscode/searchlight/apis/monitoring/v1alpha1.templatedFields":            schema_searchlight_apis_monitoring_v1alpha1_templatedFields(ref),
+		"k8s.io/apimachinery/pkg/api/resource.Quantity":                                       schema_apimachinery_pkg_api_resource_Quantity(ref),
+		"k8s.io/apimachinery/pkg/api/resource.int64Amount":                                    schema_apimachinery_pkg_api_resource_int64Amount(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroup":                                       schema_pkg_apis_meta_v1_APIGroup(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroupList":                                   schema_pkg_apis_meta_v1_APIGroupList(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResource":                                    schema_pkg_apis_meta_v1_APIResource(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResourceList":                                schema_pkg_apis_meta_v1_APIResourceList(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIVersions":                                    schema_pkg_apis_meta_v1_APIVersions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.CreateOptions":                                  schema_pkg_apis_meta_v1_CreateOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.DeleteOptions":                                  schema_pkg_apis_meta_v1_DeleteOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Duration":                                       schema_pkg_apis_meta_v1_Duration(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ExportOptions":                                  schema_pkg_apis_meta_v1_ExportOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Fields":                                         schema_pkg_apis_meta_v1_Fields(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GetOptions":                                     schema_pkg_apis_meta_v1_GetOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupKind":                                      schema_pkg_apis_meta_v1_GroupKind(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupResource":                                  schema_pkg_apis_meta_v1_GroupResource(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersion":                                   schema_pkg_apis_meta_v1_GroupVersion(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionForDiscovery":                       schema_pkg_apis_meta_v1_GroupVersionForDiscovery(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionKind":                               schema_pkg_apis_meta_v1_GroupVersionKind(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionResource":                           schema_pkg_apis_meta_v1_GroupVersionResource(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Initializer":                                    schema_pkg_apis_meta_v1_Initializer(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Initializers":                                   schema_pkg_apis_meta_v1_Initializers(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.InternalEvent":                                  schema_pkg_apis_meta_v1_InternalEvent(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector":                                  schema_pkg_apis_meta_v1_LabelSelector(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelectorRequirement":                       schema_pkg_apis_meta_v1_LabelSelectorRequirement(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.List":                                           schema_pkg_apis_meta_v1_List(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta":                                       schema_pkg_apis_meta_v1_ListMeta(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ListOptions":                                    schema_pkg_apis_meta_v1_ListOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ManagedFieldsEntry":                             schema_pkg_apis_meta_v1_ManagedFieldsEntry(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime":                                      schema_pkg_apis_meta_v1_MicroTime(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta":                                     schema_pkg_apis_meta_v1_ObjectMeta(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.OwnerReference":                                 schema_pkg_apis_meta_v1_OwnerReference(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Patch":                                          schema_pkg_apis_meta_v1_Patch(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.PatchOptions":                                   schema_pkg_apis_meta_v1_PatchOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Preconditions":                                  schema_pkg_apis_meta_v1_Preconditions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.RootPaths":                                      schema_pkg_apis_meta_v1_RootPaths(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ServerAddressByClientCIDR":                      schema_pkg_apis_meta_v1_ServerAddressByClientCIDR(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Status":                                         schema_pkg_apis_meta_v1_Status(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusCause":                                    schema_pkg_apis_meta_v1_StatusCause(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusDetails":                                  schema_pkg_apis_meta_v1_StatusDetails(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Time":                                           schema_pkg_apis_meta_v1_Time(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Timestamp":                                      schema_pkg_apis_meta_v1_Timestamp(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TypeMeta":                                       schema_pkg_apis_meta_v1_TypeMeta(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.UpdateOptions":                                  schema_pkg_apis_meta_v1_UpdateOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.WatchEvent":                                     schema_pkg_apis_meta_v1_WatchEvent(ref),
+		"k8s.io/apimachinery/pkg/runtime.RawExtension":                                        schema_k8sio_apimachinery_pkg_runtime_RawExtension(ref),
+		"k8s.io/apimachinery/pkg/runtime.TypeMeta":                                            schema_k8sio_apimachinery_pkg_runtime_TypeMeta(ref),
+		"k8s.io/apimachinery/pkg/runtime.Unknown":                                             schema_k8sio_apimachinery_pkg_runtime_Unknown(ref),
+		"k8s.io/apimachinery/pkg/version.Info":                                                schema_k8sio_apimachinery_pkg_version_Info(ref),
 	}
 }
 
-func schema_searchlight_apis_monitoring_v1alpha1_ClusterAlert(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_searchlight_apis_monitoring_v1alpha1_AlertCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"kind": {
+					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Description: "Type of alert condition.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"apiVersion": {
+					"status": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Description: "Status of the condition, one of True, False, Unknown.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"metadata": {
+					"lastTransitionTime": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Standard object's metadata. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata",
-							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+							Description: "Last time the condition transitioned from one status to another.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"spec": {
+					"reason": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Spec is the desired state of the ClusterAlert. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status",
-							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.ClusterAlertSpec"),
+							Description: "The reason for the condition's last transition.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "A human readable message indicating details about the transition.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
+				Required: []string{"type", "status"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.ClusterAlertSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_searchlight_apis_monitoring_v1alpha1_ClusterAlertList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_searchlight_apis_monitoring_v1alpha1_AlertDependency(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ClusterAlertList is a collection of ClusterAlert.",
+				Description: "AlertDependency declares the alert which checks the node of a pod.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"kind": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"apiVersion": {
+					"nodeAlert": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Description: "Name of a NodeAlert in the namespace of the PodAlert, typically running the node-status check",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"metadata": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Standard object's metadata. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata",
-							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
-						},
-					},
-					"items": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Items is the list of ClusterAlert.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.ClusterAlert"),
-									},
-								},
-							},
-						},
-					},
 				},
-				Required: []string{"items"},
+				Required: []string{"nodeAlert"},
 			},
 		},
-		Dependencies: []string{
-			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.ClusterAlert", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
-func schema_searchlight_apis_monitoring_v1alpha1_ClusterAlertSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_searchlight_apis_monitoring_v1alpha1_AlertStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ClusterAlertSpec describes the ClusterAlert the user wishes to create.",
+				Description: "AlertStatus is the most recently observed status of an alert.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"check": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Icinga CheckCommand name",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"checkInterval": {
-						SchemaProps: spec.SchemaProps{
-							Description: "How frequently Icinga Service will be checked",
-							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
-						},
-					},
-					"alertInterval": {
-						SchemaProps: spec.SchemaProps{
-							Description: "How frequently notifications will be send",
-							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
-						},
-					},
-					"notifierSecretName": {
+					"observedGeneration": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Secret containing notifier credentials",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "ObservedGeneration is the most recent generation observed for this alert. It corresponds to the alert's generation, which is updated on mutation by the API Server.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"receivers": {
+					"conditions": {
 						SchemaProps: spec.SchemaProps{
-							Description: "NotifierParams contains information to send notifications for Incident State, UserUid, Method",
+							Description: "Conditions represent the latest available observations of the alert's state.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.Receiver"),
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertCondition"),
 									},
 								},
 							},
 						},
 					},
-					"vars": {
+					"targets": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Vars contains Icinga Service variables to be used in CheckCommand",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
+							Description: "Targets are the objects this alert is currently applied to.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Type:   []string{"string"},
-										Format: "",
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertTarget"),
 									},
 								},
 							},
 						},
 					},
-					"paused": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Indicates that Check is paused Icinga Services are removed",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.Receiver", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertCondition", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertTarget"},
 	}
 }
 
-func schema_searchlight_apis_monitoring_v1alpha1_IcingaCommand(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_searchlight_apis_monitoring_v1alpha1_AlertTarget(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"Name": {
+					"namespace": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Namespace of the target object. Empty for nodes.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"Vars": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginVars"),
+							Description: "Name of the target object. Empty for ClusterAlert.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"States": {
+					"icingaHost": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Type:   []string{"string"},
-										Format: "",
-									},
-								},
-							},
+							Description: "Name of the Icinga host created for the target",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Last known state of the Icinga service, such as OK, Warning, Critical or Unknown",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastCheckTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The time at which Icinga last checked the service for this target.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Error returned by the last attempt to apply this target in Icinga.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
-				Required: []string{"Name", "Vars", "States"},
+				Required: []string{"icingaHost"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginVars"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_searchlight_apis_monitoring_v1alpha1_Incident(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_searchlight_apis_monitoring_v1alpha1_AlertTemplate(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "AlertTemplate contains defaults for ClusterAlerts, NodeAlerts and PodAlerts which refer to it in spec.templateRef. Fields set in an alert take precedence over its template.",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
 						SchemaProps: spec.SchemaProps{
@@ -336,25 +346,25 @@ func schema_searchlight_apis_monitoring_v1alpha1_Incident(ref common.ReferenceCa
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
 						},
 					},
-					"status": {
+					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Derived information about the incident.",
-							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.IncidentStatus"),
+							Description: "Spec is the desired state of the AlertTemplate. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertTemplateSpec"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.IncidentStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertTemplateSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
-func schema_searchlight_apis_monitoring_v1alpha1_IncidentList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_searchlight_apis_monitoring_v1alpha1_AlertTemplateList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "IncidentList is a collection of Incident.",
+				Description: "AlertTemplateList is a collection of AlertTemplate.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -379,12 +389,12 @@ func schema_searchlight_apis_monitoring_v1alpha1_IncidentList(ref common.Referen
 					},
 					"items": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Items is the list of Incident.",
+							Description: "Items is the list of AlertTemplate.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.Incident"),
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertTemplate"),
 									},
 								},
 							},
@@ -395,108 +405,106 @@ func schema_searchlight_apis_monitoring_v1alpha1_IncidentList(ref common.Referen
 			},
 		},
 		Dependencies: []string{
-			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.Incident", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertTemplate", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
-func schema_searchlight_apis_monitoring_v1alpha1_IncidentNotification(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_searchlight_apis_monitoring_v1alpha1_AlertTemplateSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "AlertTemplateSpec describes the AlertTemplate the user wishes to create.",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"type": {
+					"checkInterval": {
 						SchemaProps: spec.SchemaProps{
-							Description: "incident notification type.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "How frequently Icinga Service will be checked",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
-					"checkOutput": {
+					"alertInterval": {
 						SchemaProps: spec.SchemaProps{
-							Description: "brief output of check command for the incident",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "How frequently notifications will be send",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
-					"author": {
+					"maxCheckAttempts": {
 						SchemaProps: spec.SchemaProps{
-							Description: "name of user making comment",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Number of times Icinga Service is checked before a non-OK state is considered hard and notifications are sent",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"comment": {
+					"retryInterval": {
 						SchemaProps: spec.SchemaProps{
-							Description: "comment made by user",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "How frequently Icinga Service will be re-checked while in a soft non-OK state",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
-					"firstTimestamp": {
+					"checkTimeout": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The time at which this notification was first recorded. (Time of server receipt is in TypeMeta.)",
-							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							Description: "Timeout of CheckCommand",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
-					"lastTimestamp": {
+					"notifierSecretName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The time at which the most recent occurrence of this notification was recorded.",
-							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							Description: "Secret containing notifier credentials",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"state": {
+					"receivers": {
 						SchemaProps: spec.SchemaProps{
-							Description: "state of incident, such as Critical, Warning, OK, Unknown",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Receivers of alerts which don't specify receivers themselves",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.Receiver"),
+									},
+								},
+							},
 						},
 					},
-				},
-				Required: []string{"type", "state"},
-			},
-		},
-		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
-	}
-}
-
-func schema_searchlight_apis_monitoring_v1alpha1_IncidentStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"lastNotificationType": {
+					"vars": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Type of last notification, such as problem, acknowledgement, recovery or custom",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Vars contains Icinga Service variables to be used in CheckCommand. Vars of an alert take precedence over the vars of its template with the same name.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
 						},
 					},
-					"notifications": {
+					"varsFrom": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Notifications for the incident, such as problem or acknowledgement.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
+							Description: "VarsFrom contains Icinga Service variables whose values are read from secrets in the namespace of the template at check time. Vars of an alert take precedence over them, too.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.IncidentNotification"),
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.VarSource"),
 									},
 								},
 							},
 						},
 					},
 				},
-				Required: []string{"lastNotificationType"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.IncidentNotification"},
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.Receiver", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.VarSource", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
-func schema_searchlight_apis_monitoring_v1alpha1_NodeAlert(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_searchlight_apis_monitoring_v1alpha1_ClusterAlert(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
@@ -524,23 +532,29 @@ func schema_searchlight_apis_monitoring_v1alpha1_NodeAlert(ref common.ReferenceC
 					},
 					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Spec is the desired state of the NodeAlert. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status",
-							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeAlertSpec"),
-						},
+							Description: "Spec is the desired state of the ClusterAlert. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.ClusterAlertSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status is the most recently observed status of the ClusterAlert.",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertStatus"),
+						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeAlertSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertStatus", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.ClusterAlertSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
-func schema_searchlight_apis_monitoring_v1alpha1_NodeAlertList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_searchlight_apis_monitoring_v1alpha1_ClusterAlertList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "NodeAlertList is a collection of NodeAlert.",
+				Description: "ClusterAlertList is a collection of ClusterAlert.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -565,12 +579,12 @@ func schema_searchlight_apis_monitoring_v1alpha1_NodeAlertList(ref common.Refere
 					},
 					"items": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Items is the list of NodeAlert.",
+							Description: "Items is the list of ClusterAlert.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeAlert"),
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.ClusterAlert"),
 									},
 								},
 							},
@@ -581,40 +595,27 @@ func schema_searchlight_apis_monitoring_v1alpha1_NodeAlertList(ref common.Refere
 			},
 		},
 		Dependencies: []string{
-			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeAlert", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.ClusterAlert", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
-func schema_searchlight_apis_monitoring_v1alpha1_NodeAlertSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_searchlight_apis_monitoring_v1alpha1_ClusterAlertSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "NodeAlertSpec describes the NodeAlert the user wishes to create.",
+				Description: "ClusterAlertSpec describes the ClusterAlert the user wishes to create.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"selector": {
-						SchemaProps: spec.SchemaProps{
-							Type: []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Type:   []string{"string"},
-										Format: "",
-									},
-								},
-							},
-						},
-					},
-					"nodeName": {
+					"check": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Icinga CheckCommand name",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"check": {
+					"templateRef": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Icinga CheckCommand name",
+							Description: "Name of an AlertTemplate in the same namespace whose values are used for fields not set in this spec",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -631,6 +632,46 @@ func schema_searchlight_apis_monitoring_v1alpha1_NodeAlertSpec(ref common.Refere
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
+					"maxCheckAttempts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of times Icinga Service is checked before a non-OK state is considered hard and notifications are sent",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"retryInterval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How frequently Icinga Service will be re-checked while in a soft non-OK state",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"checkTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeout of CheckCommand",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"enableFlapping": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Indicates that flap detection is enabled for Icinga Service",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"flappingThresholdLow": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Percentage of state changes below which Icinga Service stops flapping",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"flappingThresholdHigh": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Percentage of state changes above which Icinga Service starts flapping",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 					"notifierSecretName": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Secret containing notifier credentials",
@@ -666,121 +707,36 @@ func schema_searchlight_apis_monitoring_v1alpha1_NodeAlertSpec(ref common.Refere
 							},
 						},
 					},
-					"paused": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Indicates that Check is paused Icinga Services are removed",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.Receiver", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
-	}
-}
-
-func schema_searchlight_apis_monitoring_v1alpha1_PluginArguments(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"vars": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginVars"),
-						},
-					},
-					"host": {
-						SchemaProps: spec.SchemaProps{
-							Type: []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Type:   []string{"string"},
-										Format: "",
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginVars"},
-	}
-}
-
-func schema_searchlight_apis_monitoring_v1alpha1_PluginVarField(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"description": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"type": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-				},
-				Required: []string{"type"},
-			},
-		},
-	}
-}
-
-func schema_searchlight_apis_monitoring_v1alpha1_PluginVars(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"fields": {
+					"varsFrom": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"object"},
+							Description: "VarsFrom contains Icinga Service variables whose values are read from secrets in the namespace of the alert at check time",
+							Type:        []string{"object"},
 							AdditionalProperties: &spec.SchemaOrBool{
 								Allows: true,
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginVarField"),
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.VarSource"),
 									},
 								},
 							},
 						},
 					},
-					"required": {
+					"paused": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Type:   []string{"string"},
-										Format: "",
-									},
-								},
-							},
+							Description: "Indicates that Check is paused Icinga Services are removed",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
 				},
-				Required: []string{"fields"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginVarField"},
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.Receiver", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.VarSource", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
-func schema_searchlight_apis_monitoring_v1alpha1_PodAlert(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_searchlight_apis_monitoring_v1alpha1_GlobalAlert(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
@@ -808,23 +764,29 @@ func schema_searchlight_apis_monitoring_v1alpha1_PodAlert(ref common.ReferenceCa
 					},
 					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Spec is the desired state of the PodAlert. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status",
-							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.PodAlertSpec"),
+							Description: "Spec is the desired state of the GlobalAlert. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.GlobalAlertSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status is the most recently observed status of the GlobalAlert.",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertStatus"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PodAlertSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertStatus", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.GlobalAlertSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
-func schema_searchlight_apis_monitoring_v1alpha1_PodAlertList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_searchlight_apis_monitoring_v1alpha1_GlobalAlertList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PodAlertList is a collection of PodAlert.",
+				Description: "GlobalAlertList is a collection of GlobalAlert.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -849,12 +811,12 @@ func schema_searchlight_apis_monitoring_v1alpha1_PodAlertList(ref common.Referen
 					},
 					"items": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Items is the list of PodAlert.",
+							Description: "Items is the list of GlobalAlert.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.PodAlert"),
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.GlobalAlert"),
 									},
 								},
 							},
@@ -865,26 +827,27 @@ func schema_searchlight_apis_monitoring_v1alpha1_PodAlertList(ref common.Referen
 			},
 		},
 		Dependencies: []string{
-			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PodAlert", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.GlobalAlert", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
-func schema_searchlight_apis_monitoring_v1alpha1_PodAlertSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_searchlight_apis_monitoring_v1alpha1_GlobalAlertSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PodAlertSpec describes the PodAlert the user wishes to create.",
+				Description: "GlobalAlertSpec describes the GlobalAlert the user wishes to create. A GlobalAlert applies a pod check command to matching pods of all matching namespaces.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"selector": {
+					"namespaceSelector": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+							Description: "NamespaceSelector selects the namespaces whose pods are checked. An empty selector matches all namespaces.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
 						},
 					},
-					"podName": {
+					"selector": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Selector selects the pods checked in each matching namespace",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
 						},
 					},
 					"check": {
@@ -906,6 +869,53 @@ func schema_searchlight_apis_monitoring_v1alpha1_PodAlertSpec(ref common.Referen
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
+					"maxCheckAttempts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of times Icinga Service is checked before a non-OK state is considered hard and notifications are sent",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"retryInterval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How frequently Icinga Service will be re-checked while in a soft non-OK state",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"checkTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeout of CheckCommand",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"enableFlapping": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Indicates that flap detection is enabled for Icinga Service",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"flappingThresholdLow": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Percentage of state changes below which Icinga Service stops flapping",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"flappingThresholdHigh": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Percentage of state changes above which Icinga Service starts flapping",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"notifierSecretNamespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace of the Secret containing notifier credentials",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"notifierSecretName": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Secret containing notifier credentials",
@@ -928,7 +938,7 @@ func schema_searchlight_apis_monitoring_v1alpha1_PodAlertSpec(ref common.Referen
 					},
 					"vars": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Vars contains Icinga Service variables to be used in CheckCommand",
+							Description: "Vars contains Icinga Service variables to be used in CheckCommand. GlobalAlerts don't support varsFrom, since they aren't bound to the namespace of any secret.",
 							Type:        []string{"object"},
 							AdditionalProperties: &spec.SchemaOrBool{
 								Allows: true,
@@ -949,6 +959,7 @@ func schema_searchlight_apis_monitoring_v1alpha1_PodAlertSpec(ref common.Referen
 						},
 					},
 				},
+				Required: []string{"selector"},
 			},
 		},
 		Dependencies: []string{
@@ -956,23 +967,26 @@ func schema_searchlight_apis_monitoring_v1alpha1_PodAlertSpec(ref common.Referen
 	}
 }
 
-func schema_searchlight_apis_monitoring_v1alpha1_Receiver(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_searchlight_apis_monitoring_v1alpha1_IcingaCommand(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"state": {
+					"Name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "For which state notification will be sent",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"to": {
+					"Vars": {
 						SchemaProps: spec.SchemaProps{
-							Description: "To whom notification will be sent",
-							Type:        []string{"array"},
+							Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginVars"),
+						},
+					},
+					"States": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
@@ -983,53 +997,2130 @@ func schema_searchlight_apis_monitoring_v1alpha1_Receiver(ref common.ReferenceCa
 							},
 						},
 					},
-					"notifier": {
-						SchemaProps: spec.SchemaProps{
-							Description: "How this notification will be sent",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
 				},
+				Required: []string{"Name", "Vars", "States"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginVars"},
 	}
 }
 
-func schema_searchlight_apis_monitoring_v1alpha1_Registry(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_searchlight_apis_monitoring_v1alpha1_Incident(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"reg": {
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard object's metadata. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Derived information about the incident.",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.IncidentStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.IncidentStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_IncidentList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "IncidentList is a collection of Incident.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard object's metadata. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Items is the list of Incident.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.Incident"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.Incident", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_IncidentNotification(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "incident notification type.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"checkOutput": {
+						SchemaProps: spec.SchemaProps{
+							Description: "brief output of check command for the incident",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"author": {
+						SchemaProps: spec.SchemaProps{
+							Description: "name of user making comment",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"comment": {
+						SchemaProps: spec.SchemaProps{
+							Description: "comment made by user",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"firstTimestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The time at which this notification was first recorded. (Time of server receipt is in TypeMeta.)",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastTimestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The time at which the most recent occurrence of this notification was recorded.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Description: "state of incident, such as Critical, Warning, OK, Unknown",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"type", "state"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_IncidentStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"lastNotificationType": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of last notification, such as problem, acknowledgement, recovery or custom",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"notifications": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Notifications for the incident, such as problem or acknowledgement.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.IncidentNotification"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"lastNotificationType"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.IncidentNotification"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_NodeAlert(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard object's metadata. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Spec is the desired state of the NodeAlert. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeAlertSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status is the most recently observed status of the NodeAlert.",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertStatus", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeAlertSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_NodeAlertList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NodeAlertList is a collection of NodeAlert.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard object's metadata. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Items is the list of NodeAlert.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeAlert"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeAlert", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_NodeAlertSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NodeAlertSpec describes the NodeAlert the user wishes to create.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Deprecated: Use labelSelector. Searchlight operator migrates it to labelSelector.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"labelSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LabelSelector selects nodes by labels",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"taints": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Taints selects nodes by taints. All of them must match.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeTaintSelector"),
+									},
+								},
+							},
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions selects nodes by status of conditions. All of them must match.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeConditionSelector"),
+									},
+								},
+							},
+						},
+					},
+					"nodeName": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"check": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Icinga CheckCommand name",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"templateRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of an AlertTemplate in the same namespace whose values are used for fields not set in this spec",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"checkInterval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How frequently Icinga Service will be checked",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"alertInterval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How frequently notifications will be send",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"maxCheckAttempts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of times Icinga Service is checked before a non-OK state is considered hard and notifications are sent",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"retryInterval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How frequently Icinga Service will be re-checked while in a soft non-OK state",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"checkTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeout of CheckCommand",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"enableFlapping": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Indicates that flap detection is enabled for Icinga Service",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"flappingThresholdLow": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Percentage of state changes below which Icinga Service stops flapping",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"flappingThresholdHigh": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Percentage of state changes above which Icinga Service starts flapping",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"notifierSecretName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Secret containing notifier credentials",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"receivers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NotifierParams contains information to send notifications for Incident State, UserUid, Method",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.Receiver"),
+									},
+								},
+							},
+						},
+					},
+					"vars": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Vars contains Icinga Service variables to be used in CheckCommand",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"varsFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VarsFrom contains Icinga Service variables whose values are read from secrets in the namespace of the alert at check time",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.VarSource"),
+									},
+								},
+							},
+						},
+					},
+					"paused": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Indicates that Check is paused Icinga Services are removed",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeConditionSelector", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.NodeTaintSelector", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.Receiver", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.VarSource", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_NodeConditionSelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NodeConditionSelector matches nodes which have a condition with given status.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of the node condition, e.g. Ready",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status of the node condition, one of True, False or Unknown",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"type", "status"},
+			},
+		},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_NodeTaintSelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NodeTaintSelector matches nodes which have, or don't have, a taint.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Key of the taint",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Value of the taint. Any value matches if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"effect": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Effect of the taint. Any effect matches if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"operator": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Operator is either Exists or DoesNotExist. Defaults to Exists.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"key"},
+			},
+		},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_NotifierConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NotifierConfig bundles a notifier with the secret containing its credentials. Receivers of alerts in any namespace can refer to it, if they are allowed to use it.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard object's metadata. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Spec is the desired state of the NotifierConfig. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.NotifierConfigSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.NotifierConfigSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_NotifierConfigList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NotifierConfigList is a collection of NotifierConfig.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard object's metadata. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Items is the list of NotifierConfig.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.NotifierConfig"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.NotifierConfig", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_NotifierConfigSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NotifierConfigSpec describes the NotifierConfig the user wishes to create.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"notifier": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Notifier name, e.g. Mailgun or Twilio",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"secretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Secret containing notifier credentials",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.NotifierSecretReference"),
+						},
+					},
+				},
+				Required: []string{"notifier", "secretRef"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.NotifierSecretReference"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_NotifierSecretReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NotifierSecretReference refers to a secret in any namespace.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace of the secret",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the secret",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"namespace", "name"},
+			},
+		},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_PluginAlerts(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PluginAlerts lists alerts by kind. Namespaced alerts are listed as namespace/name.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"clusterAlerts": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"nodeAlerts": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"podAlerts": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"workloadAlerts": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"globalAlerts": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_PluginArguments(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"vars": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginVars"),
+						},
+					},
+					"host": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginVars"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_PluginVarField(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"description": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of the items of list and map vars. Defaults to string.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"enum": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Allowed values of the var, or of its items",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"minimum": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Minimum value of integer and number vars, or of their items",
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+					"maximum": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Maximum value of integer and number vars, or of their items",
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+					"pattern": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Regular expression the var, or each of its items, must match",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"default": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Default value of the var used by the CheckCommand, if an alert does not set it",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"type"},
+			},
+		},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_PluginVars(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"fields": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginVarField"),
+									},
+								},
+							},
+						},
+					},
+					"required": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"fields"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginVarField"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_PodAlert(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard object's metadata. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Spec is the desired state of the PodAlert. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.PodAlertSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status is the most recently observed status of the PodAlert.",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertStatus", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.PodAlertSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_PodAlertList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PodAlertList is a collection of PodAlert.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard object's metadata. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Items is the list of PodAlert.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.PodAlert"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PodAlert", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_PodAlertSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PodAlertSpec describes the PodAlert the user wishes to create.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"podName": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"check": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Icinga CheckCommand name",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"templateRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of an AlertTemplate in the same namespace whose values are used for fields not set in this spec",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"checkInterval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How frequently Icinga Service will be checked",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"alertInterval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How frequently notifications will be send",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"maxCheckAttempts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of times Icinga Service is checked before a non-OK state is considered hard and notifications are sent",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"retryInterval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How frequently Icinga Service will be re-checked while in a soft non-OK state",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"checkTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeout of CheckCommand",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"enableFlapping": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Indicates that flap detection is enabled for Icinga Service",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"flappingThresholdLow": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Percentage of state changes below which Icinga Service stops flapping",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"flappingThresholdHigh": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Percentage of state changes above which Icinga Service starts flapping",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"notifierSecretName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Secret containing notifier credentials",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"receivers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NotifierParams contains information to send notifications for Incident State, UserUid, Method",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.Receiver"),
+									},
+								},
+							},
+						},
+					},
+					"vars": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Vars contains Icinga Service variables to be used in CheckCommand",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"varsFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VarsFrom contains Icinga Service variables whose values are read from secrets in the namespace of the alert at check time",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.VarSource"),
+									},
+								},
+							},
+						},
+					},
+					"dependsOn": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DependsOn suppresses checks and notifications while the check of a NodeAlert is failing on the node where the pod is scheduled",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertDependency"),
+						},
+					},
+					"suppressDuringRollout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SuppressDuringRollout schedules downtimes for Icinga Services of pods whose Deployment or StatefulSet is rolling out, until the rollout completes or its deadline passes",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.RolloutSuppression"),
+						},
+					},
+					"paused": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Indicates that Check is paused Icinga Services are removed",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertDependency", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.Receiver", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.RolloutSuppression", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.VarSource", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_Receiver(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Description: "For which state notification will be sent",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"to": {
+						SchemaProps: spec.SchemaProps{
+							Description: "To whom notification will be sent",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"notifier": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How this notification will be sent",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"notifierConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of a NotifierConfig whose notifier and credentials are used instead of the notifier secret of the alert. Notifier defaults to the one of the NotifierConfig.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"escalateAfter": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EscalateAfter turns this receiver into an escalation step. Problem notifications are sent to it only if the incident is not acknowledged within this duration after its first problem notification.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"period": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of a TimePeriod outside of which no notification is sent to this receiver",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_Registry(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"reg": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.IcingaCommand"),
+									},
+								},
+							},
+						},
+					},
+					"mu": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("sync.RWMutex"),
+						},
+					},
+				},
+				Required: []string{"reg", "mu"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.IcingaCommand", "sync.RWMutex"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_RolloutSuppression(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RolloutSuppression schedules Icinga downtimes for the services of a PodAlert on the pods of a Deployment or StatefulSet while it rolls out.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"deadline": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Longest time services are kept in downtime, counted from the start of the rollout. Defaults to 10m.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_SearchlightPlugin(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Spec is the desired state of the SearchlightPlugin. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPluginSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status is the most recently observed status of the SearchlightPlugin.",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPluginStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPluginSpec", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPluginStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_SearchlightPluginCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of SearchlightPlugin condition.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status of the condition, one of True, False, Unknown.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastTransitionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Last time the condition transitioned from one status to another.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The reason for the condition's last transition.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "A human readable message indicating details about the transition.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"type", "status"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_SearchlightPluginList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SearchlightPluginList is a collection of SearchlightPlugin.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard object's metadata. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Items is the list of SearchlightPlugin.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPlugin"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPlugin", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_SearchlightPluginSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SearchlightPluginSpec describes the SearchlightPlugin the user wishes to create.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"command": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Check Command",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"webhook": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Webhook provides a reference to the service for this SearchlightPlugin. It must communicate on port 80",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.WebhookServiceSpec"),
+						},
+					},
+					"alertKinds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AlertKinds refers to supports Alert kinds for this plugin",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"arguments": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Supported arguments for SearchlightPlugin",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginArguments"),
+						},
+					},
+					"states": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Supported Icinga Service State",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"alertKinds", "states"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginArguments", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.WebhookServiceSpec"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_SearchlightPluginStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SearchlightPluginStatus is the most recently observed status of a SearchlightPlugin.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the most recent generation observed for this SearchlightPlugin. It corresponds to the SearchlightPlugin's generation, which is updated on mutation by the API Server.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions represent the latest available observations of the SearchlightPlugin's state.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPluginCondition"),
+									},
+								},
+							},
+						},
+					},
+					"checkCommand": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CheckCommand is the Icinga CheckCommand rendered for the SearchlightPlugin",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"alerts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Alerts are the alerts using the SearchlightPlugin as check command",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginAlerts"),
+						},
+					},
+					"pausedAlerts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PausedAlerts are the alerts which were paused because the SearchlightPlugin was deleted. They stay paused until resumed by the user.",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginAlerts"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginAlerts", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPluginCondition"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_SecretKeySelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SecretKeySelector selects a key of a secret in the namespace of the alert.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the secret",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Key of the secret to select",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "key"},
+			},
+		},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_Silence(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Silence mutes notifications of matching alerts during maintenance windows. Icinga downtimes are scheduled for the matching Icinga services, so checks keep running and their history is retained.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard object's metadata. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Spec is the desired state of the Silence. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.SilenceSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status is the most recently observed status of the Silence.",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.SilenceStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SilenceSpec", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.SilenceStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_SilenceList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SilenceList is a collection of Silence.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard object's metadata. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Items is the list of Silence.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.Silence"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.Silence", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_SilenceMatcher(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SilenceMatcher selects alerts. All of its fields must match.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"alertKind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind of the alerts, one of ClusterAlert, NodeAlert, PodAlert, WorkloadAlert or GlobalAlert. All kinds if empty. GlobalAlerts are only silenced on pods in the namespace of the Silence.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"alertNames": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Names of the alerts. Any name if empty.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"alertSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selector matches labels of the alerts",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"target": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Target restricts the Silence to the Icinga services of a single target object of the alerts",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.SilenceTarget"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SilenceTarget", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_SilenceSchedule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SilenceSchedule describes a window which starts at the same time on given days of every week.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"days": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
+							Description: "Days of week on which the window starts, e.g. Saturday. Every day if empty.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time of day at which the window starts, in 24 hour HH:MM format",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration of the window",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"timeZone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeZone of StartTime as IANA time zone name, e.g. Europe/Berlin. Defaults to UTC.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"startTime", "duration"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_SilenceSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SilenceSpec describes the Silence the user wishes to create. Either a one-off window with StartsAt and EndsAt, or a recurring Schedule must be specified.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"startsAt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Start time of a one-off window",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"endsAt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "End time of a one-off window",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Schedule of a weekly recurring window",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.SilenceSchedule"),
+						},
+					},
+					"matcher": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Matcher selects the alerts in the namespace of the Silence which are silenced",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.SilenceMatcher"),
+						},
+					},
+					"comment": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Comment is added to the Icinga downtimes",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"matcher"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SilenceMatcher", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.SilenceSchedule", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_SilenceStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SilenceStatus is the most recently observed status of a Silence.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the most recent generation observed for this Silence. It corresponds to the Silence's generation, which is updated on mutation by the API Server.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"active": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Active is true while the current time is within the window",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"windowStart": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Start time of the current or next window",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"windowEnd": {
+						SchemaProps: spec.SchemaProps{
+							Description: "End time of the current or next window",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"downtimeIDs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Names of the Icinga downtimes scheduled for the current or next window",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "A human readable message indicating why downtimes could not be scheduled",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"active"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_SilenceTarget(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SilenceTarget selects a target object of an alert, i.e. a pod, node or workload.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the target object",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_TimePeriod(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TimePeriod is a named set of weekly time ranges. Receivers of alerts referring to a TimePeriod are only notified within its ranges.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard object's metadata. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Spec is the desired state of the TimePeriod. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.TimePeriodSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.TimePeriodSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_TimePeriodList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TimePeriodList is a collection of TimePeriod.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard object's metadata. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Items is the list of TimePeriod.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.TimePeriod"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.TimePeriod", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_TimePeriodSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TimePeriodSpec describes the TimePeriod the user wishes to create.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"timeZone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeZone of the ranges as IANA time zone name, e.g. Europe/Berlin. Defaults to UTC.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ranges": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Ranges of the TimePeriod",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.TimeRange"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"ranges"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.TimeRange"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_TimeRange(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TimeRange describes a range of time on given days of every week.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"days": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Days of week of the range, e.g. Monday. Every day if empty.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.IcingaCommand"),
+										Type:   []string{"string"},
+										Format: "",
 									},
 								},
 							},
 						},
 					},
-					"mu": {
+					"startTime": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("sync.RWMutex"),
+							Description: "Start of the range in 24 hour HH:MM format",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"endTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "End of the range in 24 hour HH:MM format. Use 24:00 for the end of day.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"startTime", "endTime"},
+			},
+		},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_VarSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VarSource represents a source for the value of a var.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"secretKeyRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selects a key of a secret in the namespace of the alert",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.SecretKeySelector"),
 						},
 					},
 				},
-				Required: []string{"reg", "mu"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.IcingaCommand", "sync.RWMutex"},
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SecretKeySelector"},
 	}
 }
 
-func schema_searchlight_apis_monitoring_v1alpha1_SearchlightPlugin(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_searchlight_apis_monitoring_v1alpha1_WebhookServiceSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace is the namespace of the service",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the service",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_WorkloadAlert(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
@@ -1051,28 +3142,35 @@ func schema_searchlight_apis_monitoring_v1alpha1_SearchlightPlugin(ref common.Re
 					},
 					"metadata": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+							Description: "Standard object's metadata. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
 						},
 					},
 					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Spec is the desired state of the SearchlightPlugin. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status",
-							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPluginSpec"),
+							Description: "Spec is the desired state of the WorkloadAlert. More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.WorkloadAlertSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status is the most recently observed status of the WorkloadAlert.",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertStatus"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPluginSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.AlertStatus", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.WorkloadAlertSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
-func schema_searchlight_apis_monitoring_v1alpha1_SearchlightPluginList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_searchlight_apis_monitoring_v1alpha1_WorkloadAlertList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "SearchlightPluginList is a collection of SearchlightPlugin.",
+				Description: "WorkloadAlertList is a collection of WorkloadAlert.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -1097,12 +3195,12 @@ func schema_searchlight_apis_monitoring_v1alpha1_SearchlightPluginList(ref commo
 					},
 					"items": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Items is the list of SearchlightPlugin.",
+							Description: "Items is the list of WorkloadAlert.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPlugin"),
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.WorkloadAlert"),
 									},
 								},
 							},
@@ -1113,97 +3211,267 @@ func schema_searchlight_apis_monitoring_v1alpha1_SearchlightPluginList(ref commo
 			},
 		},
 		Dependencies: []string{
-			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.SearchlightPlugin", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.WorkloadAlert", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
-func schema_searchlight_apis_monitoring_v1alpha1_SearchlightPluginSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_searchlight_apis_monitoring_v1alpha1_WorkloadAlertSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "SearchlightPluginSpec describes the SearchlightPlugin the user wishes to create.",
+				Description: "WorkloadAlertSpec describes the WorkloadAlert the user wishes to create.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"command": {
+					"targetRef": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Check Command",
+							Description: "TargetRef selects the workloads checked by this alert",
+							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.WorkloadTargetRef"),
+						},
+					},
+					"check": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Icinga CheckCommand name",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"webhook": {
+					"checkInterval": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Webhook provides a reference to the service for this SearchlightPlugin. It must communicate on port 80",
-							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.WebhookServiceSpec"),
+							Description: "How frequently Icinga Service will be checked",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
-					"alertKinds": {
+					"alertInterval": {
 						SchemaProps: spec.SchemaProps{
-							Description: "AlertKinds refers to supports Alert kinds for this plugin",
+							Description: "How frequently notifications will be send",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"maxCheckAttempts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of times Icinga Service is checked before a non-OK state is considered hard and notifications are sent",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"retryInterval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How frequently Icinga Service will be re-checked while in a soft non-OK state",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"checkTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeout of CheckCommand",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"enableFlapping": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Indicates that flap detection is enabled for Icinga Service",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"flappingThresholdLow": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Percentage of state changes below which Icinga Service stops flapping",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"flappingThresholdHigh": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Percentage of state changes above which Icinga Service starts flapping",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"notifierSecretName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Secret containing notifier credentials",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"receivers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NotifierParams contains information to send notifications for Incident State, UserUid, Method",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Type:   []string{"string"},
-										Format: "",
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.Receiver"),
 									},
 								},
 							},
 						},
 					},
-					"arguments": {
+					"vars": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Supported arguments for SearchlightPlugin",
-							Ref:         ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginArguments"),
+							Description: "Vars contains Icinga Service variables to be used in CheckCommand",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
 						},
 					},
-					"states": {
+					"varsFrom": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Supported Icinga Service State",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
+							Description: "VarsFrom contains Icinga Service variables whose values are read from secrets in the namespace of the alert at check time",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Type:   []string{"string"},
-										Format: "",
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.VarSource"),
 									},
 								},
 							},
 						},
 					},
+					"paused": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Indicates that Check is paused Icinga Services are removed",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
-				Required: []string{"alertKinds", "states"},
+				Required: []string{"targetRef"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.PluginArguments", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.WebhookServiceSpec"},
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.Receiver", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.VarSource", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.WorkloadTargetRef", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
-func schema_searchlight_apis_monitoring_v1alpha1_WebhookServiceSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_searchlight_apis_monitoring_v1alpha1_WorkloadTargetRef(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "WorkloadTargetRef selects workloads of a kind in the namespace of the WorkloadAlert, either by name or by label selector.",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"namespace": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Namespace is the namespace of the service",
+							Description: "Kind of the workload, one of Deployment, StatefulSet or DaemonSet",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Name is the name of the service",
+							Description: "Name of the workload",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selector matches labels of the workloads",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
 				},
-				Required: []string{"name"},
+				Required: []string{"kind"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
+func schema_searchlight_apis_monitoring_v1alpha1_templatedFields(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "templatedFields are the fields of an alert spec which can be inherited from an AlertTemplate.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"checkInterval": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"alertInterval": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"maxCheckAttempts": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"retryInterval": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"checkTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"notifierSecretName": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"receivers": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.Receiver"),
+									},
+								},
+							},
+						},
+					},
+					"vars": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"varsFrom": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/appscode/searchlight/apis/monitoring/v1alpha1.VarSource"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"checkInterval", "alertInterval", "maxCheckAttempts", "retryInterval", "checkTimeout", "notifierSecretName", "receivers", "vars", "varsFrom"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/appscode/searchlight/apis/monitoring/v1alpha1.Receiver", "github.com/appscode/searchlight/apis/monitoring/v1alpha1.VarSource", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -2,10 +2,15 @@ package v1alpha1
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
 
 	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"kmodules.xyz/client-go/meta"
 )
 
 const (
@@ -16,7 +21,6 @@ const (
 
 // +genclient
 // +genclient:nonNamespaced
-// +genclient:skipVerbs=updateStatus
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -27,6 +31,10 @@ type SearchlightPlugin struct {
 	// Spec is the desired state of the SearchlightPlugin.
 	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status
 	Spec SearchlightPluginSpec `json:"spec,omitempty"`
+
+	// Status is the most recently observed status of the SearchlightPlugin.
+	// +optional
+	Status SearchlightPluginStatus `json:"status,omitempty"`
 }
 
 // SearchlightPluginSpec describes the SearchlightPlugin the user wishes to create.
@@ -61,11 +69,33 @@ const (
 	VarTypeBoolean  VarType = "boolean"
 	VarTypeString   VarType = "string"
 	VarTypeDuration VarType = "duration"
+	// VarTypeList is a comma separated list of values of type Items, e.g. a,b,c
+	VarTypeList VarType = "list"
+	// VarTypeMap is a comma separated list of key=value pairs with values of type Items, e.g. a=1,b=2
+	VarTypeMap VarType = "map"
 )
 
 type PluginVarField struct {
 	Description string  `json:"description,omitempty"`
 	Type        VarType `json:"type"`
+	// Type of the items of list and map vars. Defaults to string.
+	// +optional
+	Items VarType `json:"items,omitempty"`
+	// Allowed values of the var, or of its items
+	// +optional
+	Enum []string `json:"enum,omitempty"`
+	// Minimum value of integer and number vars, or of their items
+	// +optional
+	Minimum *float64 `json:"minimum,omitempty"`
+	// Maximum value of integer and number vars, or of their items
+	// +optional
+	Maximum *float64 `json:"maximum,omitempty"`
+	// Regular expression the var, or each of its items, must match
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+	// Default value of the var used by the CheckCommand, if an alert does not set it
+	// +optional
+	Default string `json:"default,omitempty"`
 }
 
 type PluginVars struct {
@@ -78,6 +108,109 @@ type PluginArguments struct {
 	Host map[string]string `json:"host,omitempty"`
 }
 
+// SearchlightPluginStatus is the most recently observed status of a SearchlightPlugin.
+type SearchlightPluginStatus struct {
+	// ObservedGeneration is the most recent generation observed for this SearchlightPlugin. It corresponds to the
+	// SearchlightPlugin's generation, which is updated on mutation by the API Server.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the SearchlightPlugin's state.
+	// +optional
+	Conditions []SearchlightPluginCondition `json:"conditions,omitempty"`
+
+	// CheckCommand is the Icinga CheckCommand rendered for the SearchlightPlugin
+	// +optional
+	CheckCommand string `json:"checkCommand,omitempty"`
+
+	// Alerts are the alerts using the SearchlightPlugin as check command
+	// +optional
+	Alerts *PluginAlerts `json:"alerts,omitempty"`
+
+	// PausedAlerts are the alerts which were paused because the SearchlightPlugin was deleted. They stay
+	// paused until resumed by the user.
+	// +optional
+	PausedAlerts *PluginAlerts `json:"pausedAlerts,omitempty"`
+}
+
+// PluginAlerts lists alerts by kind. Namespaced alerts are listed as namespace/name.
+type PluginAlerts struct {
+	// +optional
+	ClusterAlerts []string `json:"clusterAlerts,omitempty"`
+	// +optional
+	NodeAlerts []string `json:"nodeAlerts,omitempty"`
+	// +optional
+	PodAlerts []string `json:"podAlerts,omitempty"`
+	// +optional
+	WorkloadAlerts []string `json:"workloadAlerts,omitempty"`
+	// +optional
+	GlobalAlerts []string `json:"globalAlerts,omitempty"`
+}
+
+// Len returns the number of alerts.
+func (a PluginAlerts) Len() int {
+	return len(a.ClusterAlerts) + len(a.NodeAlerts) + len(a.PodAlerts) + len(a.WorkloadAlerts) + len(a.GlobalAlerts)
+}
+
+type SearchlightPluginConditionType string
+
+// These are valid conditions of a SearchlightPlugin.
+const (
+	// PluginConditionRegistered means the SearchlightPlugin passed validation and alerts of its alert kinds
+	// can use it as check command.
+	PluginConditionRegistered SearchlightPluginConditionType = "Registered"
+	// PluginConditionIcingaSynced means the CheckCommand of the SearchlightPlugin passed Icinga config
+	// validation and is active in Icinga.
+	PluginConditionIcingaSynced SearchlightPluginConditionType = "IcingaSynced"
+)
+
+type SearchlightPluginCondition struct {
+	// Type of SearchlightPlugin condition.
+	Type SearchlightPluginConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status core.ConditionStatus `json:"status"`
+	// Last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// The reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// A human readable message indicating details about the transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// GetCondition returns the condition of the given type, or nil if it is not set.
+func (s SearchlightPluginStatus) GetCondition(t SearchlightPluginConditionType) *SearchlightPluginCondition {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == t {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition adds or replaces the condition of the same type. LastTransitionTime
+// is only moved forward when the status of the condition changes.
+func (s *SearchlightPluginStatus) SetCondition(c SearchlightPluginCondition) {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type != c.Type {
+			continue
+		}
+		if s.Conditions[i].Status == c.Status {
+			c.LastTransitionTime = s.Conditions[i].LastTransitionTime
+		} else if c.LastTransitionTime.IsZero() {
+			c.LastTransitionTime = metav1.Now()
+		}
+		s.Conditions[i] = c
+		return
+	}
+	if c.LastTransitionTime.IsZero() {
+		c.LastTransitionTime = metav1.Now()
+	}
+	s.Conditions = append(s.Conditions, c)
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // SearchlightPluginList is a collection of SearchlightPlugin.
@@ -91,47 +224,249 @@ type SearchlightPluginList struct {
 	Items []SearchlightPlugin `json:"items"`
 }
 
-var (
-	validateVarValue = map[VarType]meta.ParserFunc{}
-)
-
-func registerVarValueParser(key VarType, fn meta.ParserFunc) {
-	validateVarValue[key] = fn
+// IsVarTemplate reports whether the value of a var is a Go template, which is evaluated
+// against the target pod or node of an alert.
+func IsVarTemplate(value string) bool {
+	return strings.Contains(value, "{{")
 }
 
-func init() {
-	registerVarValueParser(VarTypeInteger, meta.GetInt)
-	registerVarValueParser(VarTypeNumber, meta.GetFloat)
-	registerVarValueParser(VarTypeBoolean, meta.GetBool)
-	registerVarValueParser(VarTypeString, meta.GetString)
-	registerVarValueParser(VarTypeDuration, meta.GetDuration)
-}
-
-func validateVariables(pluginVars *PluginVars, vars map[string]string) error {
+func validateVariables(pluginVars *PluginVars, vars map[string]string, varsFrom map[string]VarSource) error {
+	if err := validateVarsFrom(vars, varsFrom); err != nil {
+		return err
+	}
 	if pluginVars == nil {
+		if len(varsFrom) > 0 {
+			return fmt.Errorf("varsFrom are unsupported")
+		}
 		return nil
 	}
+	for k := range varsFrom {
+		if _, found := pluginVars.Fields[k]; !found {
+			return fmt.Errorf("var '%s' is unsupported", k)
+		}
+	}
 	// Check if any invalid variable is provided
-	var err error
 	for k := range vars {
 		p, found := pluginVars.Fields[k]
 		if !found {
 			return fmt.Errorf("var '%s' is unsupported", k)
 		}
 
-		fn, found := validateVarValue[p.Type]
-		if !found {
-			return errors.Errorf(`type "%v" is not registered`, p.Type)
+		if IsVarTemplate(vars[k]) {
+			if _, err := template.New(k).Parse(vars[k]); err != nil {
+				return errors.Wrapf(err, `validation failure: variable "%s" is not a valid template`, k)
+			}
+			continue
 		}
-		if _, err = fn(vars, k); err != nil {
-			return errors.Wrapf(err, `validation failure: variable "%s" must be of type %v`, k, p.Type)
+
+		if _, err := p.Parse(vars[k]); err != nil {
+			return errors.Wrapf(err, `validation failure: variable "%s"`, k)
 		}
 	}
 	for _, k := range pluginVars.Required {
-		if _, ok := vars[k]; !ok {
-			return fmt.Errorf("plugin variable '%s' is required", k)
+		if _, ok := vars[k]; ok {
+			continue
 		}
+		if _, ok := varsFrom[k]; ok {
+			continue
+		}
+		if p, ok := pluginVars.Fields[k]; ok && p.Default != "" {
+			continue
+		}
+		return fmt.Errorf("plugin variable '%s' is required", k)
 	}
 
 	return nil
 }
+
+// IsValid validates the schema of the vars of a SearchlightPlugin.
+func (p SearchlightPlugin) IsValid() error {
+	if p.Spec.Arguments.Vars == nil {
+		return nil
+	}
+	return p.Spec.Arguments.Vars.IsValid()
+}
+
+// IsValid validates the schema of the vars.
+func (pv PluginVars) IsValid() error {
+	for k, f := range pv.Fields {
+		if err := f.isValid(); err != nil {
+			return errors.Wrapf(err, `invalid schema of variable "%s"`, k)
+		}
+	}
+	for _, k := range pv.Required {
+		if _, ok := pv.Fields[k]; !ok {
+			return fmt.Errorf("required variable '%s' is not a field", k)
+		}
+	}
+	return nil
+}
+
+func (f PluginVarField) isValid() error {
+	item := f.itemType()
+	switch f.Type {
+	case VarTypeInteger, VarTypeNumber, VarTypeBoolean, VarTypeString, VarTypeDuration:
+		if f.Items != "" {
+			return fmt.Errorf("items are only supported for list and map types")
+		}
+	case VarTypeList, VarTypeMap:
+		if item == VarTypeList || item == VarTypeMap {
+			return fmt.Errorf("items can't be of type %s", item)
+		}
+		if _, err := scalarParser(item); err != nil {
+			return err
+		}
+	default:
+		return errors.Errorf(`type "%v" is not registered`, f.Type)
+	}
+	if f.Minimum != nil || f.Maximum != nil {
+		if item != VarTypeInteger && item != VarTypeNumber {
+			return fmt.Errorf("minimum and maximum are only supported for integer and number types")
+		}
+		if f.Minimum != nil && f.Maximum != nil && *f.Minimum > *f.Maximum {
+			return fmt.Errorf("minimum %v is greater than maximum %v", *f.Minimum, *f.Maximum)
+		}
+	}
+	if f.Pattern != "" {
+		if _, err := regexp.Compile(f.Pattern); err != nil {
+			return errors.Wrapf(err, "invalid pattern")
+		}
+	}
+	for _, v := range f.Enum {
+		if err := f.checkItem(v); err != nil {
+			return errors.Wrapf(err, "invalid enum value %s", v)
+		}
+	}
+	if f.Default != "" {
+		if _, err := f.Parse(f.Default); err != nil {
+			return errors.Wrapf(err, "invalid default")
+		}
+	}
+	return nil
+}
+
+// itemType returns the type of a scalar var, or the type of the items of a list or map var.
+func (f PluginVarField) itemType() VarType {
+	switch f.Type {
+	case VarTypeList, VarTypeMap:
+		if f.Items == "" {
+			return VarTypeString
+		}
+		return f.Items
+	}
+	return f.Type
+}
+
+// Parse validates the value of a var and returns it as int64, float64, bool, string or time.Duration.
+// Lists are returned as []interface{} and maps as map[string]interface{}.
+func (f PluginVarField) Parse(value string) (interface{}, error) {
+	switch f.Type {
+	case VarTypeList:
+		items := SplitListVar(value)
+		out := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			v, err := f.parseItem(item)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	case VarTypeMap:
+		out := map[string]interface{}{}
+		for _, item := range SplitListVar(value) {
+			kv := strings.SplitN(item, "=", 2)
+			if len(kv) != 2 || kv[0] == "" {
+				return nil, fmt.Errorf("%s is not a key=value pair", item)
+			}
+			v, err := f.parseItem(kv[1])
+			if err != nil {
+				return nil, err
+			}
+			out[kv[0]] = v
+		}
+		return out, nil
+	}
+	return f.parseItem(value)
+}
+
+func (f PluginVarField) parseItem(value string) (interface{}, error) {
+	if err := f.checkItem(value); err != nil {
+		return nil, err
+	}
+	if len(f.Enum) > 0 {
+		found := false
+		for _, e := range f.Enum {
+			if e == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s is not one of %s", value, strings.Join(f.Enum, ", "))
+		}
+	}
+	if f.Pattern != "" {
+		if ok, err := regexp.MatchString(f.Pattern, value); err != nil || !ok {
+			return nil, fmt.Errorf("%s does not match pattern %s", value, f.Pattern)
+		}
+	}
+	parse, _ := scalarParser(f.itemType())
+	return parse(value)
+}
+
+// checkItem checks the type and range of a scalar value.
+func (f PluginVarField) checkItem(value string) error {
+	parse, err := scalarParser(f.itemType())
+	if err != nil {
+		return err
+	}
+	v, err := parse(value)
+	if err != nil {
+		return fmt.Errorf("%s is not of type %v", value, f.itemType())
+	}
+	var n float64
+	switch x := v.(type) {
+	case int64:
+		n = float64(x)
+	case float64:
+		n = x
+	default:
+		return nil
+	}
+	if f.Minimum != nil && n < *f.Minimum {
+		return fmt.Errorf("%s is less than minimum %v", value, *f.Minimum)
+	}
+	if f.Maximum != nil && n > *f.Maximum {
+		return fmt.Errorf("%s is greater than maximum %v", value, *f.Maximum)
+	}
+	return nil
+}
+
+func scalarParser(t VarType) (func(string) (interface{}, error), error) {
+	switch t {
+	case VarTypeInteger:
+		return func(s string) (interface{}, error) { return strconv.ParseInt(s, 10, 64) }, nil
+	case VarTypeNumber:
+		return func(s string) (interface{}, error) { return strconv.ParseFloat(s, 64) }, nil
+	case VarTypeBoolean:
+		return func(s string) (interface{}, error) { return strconv.ParseBool(s) }, nil
+	case VarTypeString:
+		return func(s string) (interface{}, error) { return s, nil }, nil
+	case VarTypeDuration:
+		return func(s string) (interface{}, error) { return time.ParseDuration(s) }, nil
+	}
+	return nil, errors.Errorf(`type "%v" is not registered`, t)
+}
+
+// SplitListVar splits the value of a list or map var into its items.
+func SplitListVar(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	items := strings.Split(value, ",")
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+	return items
+}
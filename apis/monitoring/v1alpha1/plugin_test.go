@@ -0,0 +1,161 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPluginVarFieldParse(t *testing.T) {
+	bound := func(v float64) *float64 { return &v }
+
+	cases := []struct {
+		name     string
+		field    PluginVarField
+		value    string
+		expected interface{}
+		wantErr  bool
+	}{
+		{name: "integer", field: PluginVarField{Type: VarTypeInteger}, value: "42", expected: int64(42)},
+		{name: "invalid integer", field: PluginVarField{Type: VarTypeInteger}, value: "4.2", wantErr: true},
+		{name: "number", field: PluginVarField{Type: VarTypeNumber}, value: "4.2", expected: 4.2},
+		{name: "invalid number", field: PluginVarField{Type: VarTypeNumber}, value: "four", wantErr: true},
+		{name: "boolean", field: PluginVarField{Type: VarTypeBoolean}, value: "true", expected: true},
+		{name: "invalid boolean", field: PluginVarField{Type: VarTypeBoolean}, value: "yes", wantErr: true},
+		{name: "string", field: PluginVarField{Type: VarTypeString}, value: "a,b", expected: "a,b"},
+		{name: "duration", field: PluginVarField{Type: VarTypeDuration}, value: "1m30s", expected: 90 * time.Second},
+		{name: "invalid duration", field: PluginVarField{Type: VarTypeDuration}, value: "90", wantErr: true},
+		{name: "unregistered type", field: PluginVarField{Type: "object"}, value: "x", wantErr: true},
+
+		{name: "at minimum", field: PluginVarField{Type: VarTypeInteger, Minimum: bound(1)}, value: "1", expected: int64(1)},
+		{name: "below minimum", field: PluginVarField{Type: VarTypeInteger, Minimum: bound(1)}, value: "0", wantErr: true},
+		{name: "at maximum", field: PluginVarField{Type: VarTypeNumber, Maximum: bound(0.5)}, value: "0.5", expected: 0.5},
+		{name: "above maximum", field: PluginVarField{Type: VarTypeNumber, Maximum: bound(0.5)}, value: "0.51", wantErr: true},
+		{name: "within range", field: PluginVarField{Type: VarTypeInteger, Minimum: bound(-10), Maximum: bound(10)}, value: "-10", expected: int64(-10)},
+		{name: "outside range", field: PluginVarField{Type: VarTypeInteger, Minimum: bound(-10), Maximum: bound(10)}, value: "11", wantErr: true},
+
+		{name: "enum", field: PluginVarField{Type: VarTypeString, Enum: []string{"tcp", "udp"}}, value: "udp", expected: "udp"},
+		{name: "not in enum", field: PluginVarField{Type: VarTypeString, Enum: []string{"tcp", "udp"}}, value: "TCP", wantErr: true},
+		{name: "integer enum", field: PluginVarField{Type: VarTypeInteger, Enum: []string{"80", "443"}}, value: "443", expected: int64(443)},
+		{name: "integer not in enum", field: PluginVarField{Type: VarTypeInteger, Enum: []string{"80", "443"}}, value: "8080", wantErr: true},
+
+		{name: "pattern", field: PluginVarField{Type: VarTypeString, Pattern: "^/[a-z/]*$"}, value: "/var/lib", expected: "/var/lib"},
+		{name: "not matching pattern", field: PluginVarField{Type: VarTypeString, Pattern: "^/[a-z/]*$"}, value: "var/lib", wantErr: true},
+		{name: "invalid pattern", field: PluginVarField{Type: VarTypeString, Pattern: "("}, value: "x", wantErr: true},
+
+		{name: "list", field: PluginVarField{Type: VarTypeList}, value: "a, b,c", expected: []interface{}{"a", "b", "c"}},
+		{name: "empty list", field: PluginVarField{Type: VarTypeList}, value: " ", expected: []interface{}{}},
+		{name: "integer list", field: PluginVarField{Type: VarTypeList, Items: VarTypeInteger}, value: "1,2", expected: []interface{}{int64(1), int64(2)}},
+		{name: "invalid list item", field: PluginVarField{Type: VarTypeList, Items: VarTypeInteger}, value: "1,b", wantErr: true},
+		{name: "list item below minimum", field: PluginVarField{Type: VarTypeList, Items: VarTypeInteger, Minimum: bound(1)}, value: "1,0", wantErr: true},
+		{name: "list item not in enum", field: PluginVarField{Type: VarTypeList, Enum: []string{"a", "b"}}, value: "a,c", wantErr: true},
+		{name: "list item not matching pattern", field: PluginVarField{Type: VarTypeList, Pattern: "^[a-z]+$"}, value: "a,B", wantErr: true},
+
+		{name: "map", field: PluginVarField{Type: VarTypeMap}, value: "a=1, b=x=y", expected: map[string]interface{}{"a": "1", "b": "x=y"}},
+		{name: "number map", field: PluginVarField{Type: VarTypeMap, Items: VarTypeNumber}, value: "a=1,b=2.5", expected: map[string]interface{}{"a": 1.0, "b": 2.5}},
+		{name: "map item without value", field: PluginVarField{Type: VarTypeMap}, value: "a", wantErr: true},
+		{name: "map item without key", field: PluginVarField{Type: VarTypeMap}, value: "=1", wantErr: true},
+		{name: "map item above maximum", field: PluginVarField{Type: VarTypeMap, Items: VarTypeInteger, Maximum: bound(100)}, value: "a=101", wantErr: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v, err := c.field.Parse(c.value)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("expected error %v, got %v", c.wantErr, err)
+			}
+			if err == nil && !reflect.DeepEqual(v, c.expected) {
+				t.Errorf("expected %#v, got %#v", c.expected, v)
+			}
+		})
+	}
+}
+
+func TestPluginVarsIsValid(t *testing.T) {
+	bound := func(v float64) *float64 { return &v }
+
+	cases := []struct {
+		name    string
+		field   PluginVarField
+		wantErr bool
+	}{
+		{name: "integer", field: PluginVarField{Type: VarTypeInteger}},
+		{name: "unregistered type", field: PluginVarField{Type: "object"}, wantErr: true},
+		{name: "items of scalar", field: PluginVarField{Type: VarTypeString, Items: VarTypeString}, wantErr: true},
+		{name: "list of integers", field: PluginVarField{Type: VarTypeList, Items: VarTypeInteger}},
+		{name: "list of lists", field: PluginVarField{Type: VarTypeList, Items: VarTypeList}, wantErr: true},
+		{name: "map of maps", field: PluginVarField{Type: VarTypeMap, Items: VarTypeMap}, wantErr: true},
+		{name: "unregistered items", field: PluginVarField{Type: VarTypeMap, Items: "object"}, wantErr: true},
+
+		{name: "range", field: PluginVarField{Type: VarTypeNumber, Minimum: bound(0), Maximum: bound(1)}},
+		{name: "equal bounds", field: PluginVarField{Type: VarTypeInteger, Minimum: bound(1), Maximum: bound(1)}},
+		{name: "minimum greater than maximum", field: PluginVarField{Type: VarTypeInteger, Minimum: bound(2), Maximum: bound(1)}, wantErr: true},
+		{name: "minimum of string", field: PluginVarField{Type: VarTypeString, Minimum: bound(1)}, wantErr: true},
+		{name: "maximum of duration", field: PluginVarField{Type: VarTypeDuration, Maximum: bound(1)}, wantErr: true},
+		{name: "minimum of integer list", field: PluginVarField{Type: VarTypeList, Items: VarTypeInteger, Minimum: bound(1)}},
+		{name: "minimum of string list", field: PluginVarField{Type: VarTypeList, Minimum: bound(1)}, wantErr: true},
+
+		{name: "pattern", field: PluginVarField{Type: VarTypeString, Pattern: "^[a-z]+$"}},
+		{name: "invalid pattern", field: PluginVarField{Type: VarTypeString, Pattern: "("}, wantErr: true},
+
+		{name: "enum", field: PluginVarField{Type: VarTypeInteger, Enum: []string{"80", "443"}}},
+		{name: "enum value of other type", field: PluginVarField{Type: VarTypeInteger, Enum: []string{"80", "http"}}, wantErr: true},
+		{name: "enum value out of range", field: PluginVarField{Type: VarTypeInteger, Enum: []string{"80", "443"}, Maximum: bound(100)}, wantErr: true},
+
+		{name: "default", field: PluginVarField{Type: VarTypeString, Enum: []string{"tcp", "udp"}, Default: "tcp"}},
+		{name: "default not in enum", field: PluginVarField{Type: VarTypeString, Enum: []string{"tcp", "udp"}, Default: "icmp"}, wantErr: true},
+		{name: "default below minimum", field: PluginVarField{Type: VarTypeInteger, Minimum: bound(1), Default: "0"}, wantErr: true},
+		{name: "default not matching pattern", field: PluginVarField{Type: VarTypeString, Pattern: "^[a-z]+$", Default: "A"}, wantErr: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := PluginVars{Fields: map[string]PluginVarField{"v": c.field}}.IsValid()
+			if (err != nil) != c.wantErr {
+				t.Errorf("expected error %v, got %v", c.wantErr, err)
+			}
+		})
+	}
+
+	if err := (PluginVars{Fields: map[string]PluginVarField{}, Required: []string{"v"}}).IsValid(); err == nil {
+		t.Error("expected error for required variable which is not a field")
+	}
+}
+
+func TestValidateVariables(t *testing.T) {
+	bound := func(v float64) *float64 { return &v }
+	pluginVars := &PluginVars{
+		Fields: map[string]PluginVarField{
+			"port":     {Type: VarTypeInteger, Minimum: bound(1), Maximum: bound(65535)},
+			"protocol": {Type: VarTypeString, Enum: []string{"tcp", "udp"}, Default: "tcp"},
+			"host":     {Type: VarTypeString},
+		},
+		Required: []string{"port", "protocol"},
+	}
+
+	cases := []struct {
+		name     string
+		vars     map[string]string
+		varsFrom map[string]VarSource
+		wantErr  bool
+	}{
+		{name: "valid", vars: map[string]string{"port": "443", "protocol": "udp"}},
+		{name: "required var with default", vars: map[string]string{"port": "443"}},
+		{name: "missing required var", vars: map[string]string{"protocol": "udp"}, wantErr: true},
+		{name: "unsupported var", vars: map[string]string{"port": "443", "timeout": "1s"}, wantErr: true},
+		{name: "out of range", vars: map[string]string{"port": "0"}, wantErr: true},
+		{name: "not in enum", vars: map[string]string{"port": "443", "protocol": "icmp"}, wantErr: true},
+		{name: "template", vars: map[string]string{"port": "{{ .Spec.Port }}"}},
+		{name: "invalid template", vars: map[string]string{"port": "{{ .Spec.Port "}, wantErr: true},
+		{
+			name:     "required var from secret",
+			varsFrom: map[string]VarSource{"port": {SecretKeyRef: &SecretKeySelector{Name: "db", Key: "port"}}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validateVariables(pluginVars, c.vars, c.varsFrom)
+			if (err != nil) != c.wantErr {
+				t.Errorf("expected error %v, got %v", c.wantErr, err)
+			}
+		})
+	}
+}
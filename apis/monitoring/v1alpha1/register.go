@@ -38,6 +38,18 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&NodeAlertList{},
 		&PodAlert{},
 		&PodAlertList{},
+		&WorkloadAlert{},
+		&WorkloadAlertList{},
+		&GlobalAlert{},
+		&GlobalAlertList{},
+		&Silence{},
+		&SilenceList{},
+		&TimePeriod{},
+		&TimePeriodList{},
+		&AlertTemplate{},
+		&AlertTemplateList{},
+		&NotifierConfig{},
+		&NotifierConfigList{},
 		&Incident{},
 		&IncidentList{},
 		&SearchlightPlugin{},
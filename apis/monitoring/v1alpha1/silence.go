@@ -0,0 +1,253 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindSilence     = "Silence"
+	ResourcePluralSilence   = "silences"
+	ResourceSingularSilence = "silence"
+)
+
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Silence mutes notifications of matching alerts during maintenance windows. Icinga
+// downtimes are scheduled for the matching Icinga services, so checks keep running and
+// their history is retained.
+type Silence struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the Silence.
+	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status
+	Spec SilenceSpec `json:"spec,omitempty"`
+
+	// Status is the most recently observed status of the Silence.
+	// +optional
+	Status SilenceStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SilenceList is a collection of Silence.
+type SilenceList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of Silence.
+	Items []Silence `json:"items"`
+}
+
+// SilenceSpec describes the Silence the user wishes to create. Either a one-off window
+// with StartsAt and EndsAt, or a recurring Schedule must be specified.
+type SilenceSpec struct {
+	// Start time of a one-off window
+	StartsAt *metav1.Time `json:"startsAt,omitempty"`
+
+	// End time of a one-off window
+	EndsAt *metav1.Time `json:"endsAt,omitempty"`
+
+	// Schedule of a weekly recurring window
+	Schedule *SilenceSchedule `json:"schedule,omitempty"`
+
+	// Matcher selects the alerts in the namespace of the Silence which are silenced
+	Matcher SilenceMatcher `json:"matcher"`
+
+	// Comment is added to the Icinga downtimes
+	Comment string `json:"comment,omitempty"`
+}
+
+// SilenceSchedule describes a window which starts at the same time on given days of every week.
+type SilenceSchedule struct {
+	// Days of week on which the window starts, e.g. Saturday. Every day if empty.
+	Days []string `json:"days,omitempty"`
+
+	// Time of day at which the window starts, in 24 hour HH:MM format
+	StartTime string `json:"startTime"`
+
+	// Duration of the window
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone of StartTime as IANA time zone name, e.g. Europe/Berlin. Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// SilenceMatcher selects alerts. All of its fields must match.
+type SilenceMatcher struct {
+	// Kind of the alerts, one of ClusterAlert, NodeAlert, PodAlert, WorkloadAlert or GlobalAlert. All kinds if empty.
+	// GlobalAlerts are only silenced on pods in the namespace of the Silence.
+	AlertKind string `json:"alertKind,omitempty"`
+
+	// Names of the alerts. Any name if empty.
+	AlertNames []string `json:"alertNames,omitempty"`
+
+	// Selector matches labels of the alerts
+	AlertSelector *metav1.LabelSelector `json:"alertSelector,omitempty"`
+
+	// Target restricts the Silence to the Icinga services of a single target object of the alerts
+	Target *SilenceTarget `json:"target,omitempty"`
+}
+
+// SilenceTarget selects a target object of an alert, i.e. a pod, node or workload.
+type SilenceTarget struct {
+	// Name of the target object
+	Name string `json:"name"`
+}
+
+// SilenceStatus is the most recently observed status of a Silence.
+type SilenceStatus struct {
+	// ObservedGeneration is the most recent generation observed for this Silence. It corresponds to the
+	// Silence's generation, which is updated on mutation by the API Server.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Active is true while the current time is within the window
+	Active bool `json:"active"`
+
+	// Start time of the current or next window
+	// +optional
+	WindowStart *metav1.Time `json:"windowStart,omitempty"`
+
+	// End time of the current or next window
+	// +optional
+	WindowEnd *metav1.Time `json:"windowEnd,omitempty"`
+
+	// Names of the Icinga downtimes scheduled for the current or next window
+	// +optional
+	DowntimeIDs []string `json:"downtimeIDs,omitempty"`
+
+	// A human readable message indicating why downtimes could not be scheduled
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+func (s Silence) IsValid() error {
+	oneOff := s.Spec.StartsAt != nil || s.Spec.EndsAt != nil
+	if oneOff && s.Spec.Schedule != nil {
+		return fmt.Errorf("can't specify both startsAt/endsAt and schedule")
+	}
+	if !oneOff && s.Spec.Schedule == nil {
+		return fmt.Errorf("specify either startsAt/endsAt or schedule")
+	}
+	if oneOff {
+		if s.Spec.StartsAt == nil || s.Spec.EndsAt == nil {
+			return fmt.Errorf("both startsAt and endsAt are required")
+		}
+		if !s.Spec.EndsAt.After(s.Spec.StartsAt.Time) {
+			return fmt.Errorf("endsAt must be after startsAt")
+		}
+	} else {
+		if _, _, _, err := s.Spec.Schedule.parse(); err != nil {
+			return err
+		}
+		if s.Spec.Schedule.Duration.Duration <= 0 {
+			return fmt.Errorf("schedule duration must be positive")
+		}
+		if s.Spec.Schedule.Duration.Duration > 7*24*time.Hour {
+			return fmt.Errorf("schedule duration must not exceed a week")
+		}
+	}
+
+	switch s.Spec.Matcher.AlertKind {
+	case "", ResourceKindClusterAlert, ResourceKindNodeAlert, ResourceKindPodAlert, ResourceKindWorkloadAlert, ResourceKindGlobalAlert:
+	default:
+		return fmt.Errorf("alert kind '%s' is unsupported", s.Spec.Matcher.AlertKind)
+	}
+	if s.Spec.Matcher.AlertSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(s.Spec.Matcher.AlertSelector); err != nil {
+			return err
+		}
+	}
+	if s.Spec.Matcher.Target != nil && s.Spec.Matcher.Target.Name == "" {
+		return fmt.Errorf("target name is required")
+	}
+	return nil
+}
+
+func (s Silence) ObjectReference() *core.ObjectReference {
+	return &core.ObjectReference{
+		APIVersion:      SchemeGroupVersion.String(),
+		Kind:            ResourceKindSilence,
+		Namespace:       s.Namespace,
+		Name:            s.Name,
+		UID:             s.UID,
+		ResourceVersion: s.ResourceVersion,
+	}
+}
+
+// Window returns the window which is active at the given time or, if there is none, the next one.
+// For a one-off window which has already ended, that window is returned.
+func (s Silence) Window(now time.Time) (start time.Time, end time.Time, err error) {
+	if s.Spec.Schedule == nil {
+		if s.Spec.StartsAt == nil || s.Spec.EndsAt == nil {
+			return start, end, fmt.Errorf("both startsAt and endsAt are required")
+		}
+		return s.Spec.StartsAt.Time, s.Spec.EndsAt.Time, nil
+	}
+
+	sch := s.Spec.Schedule
+	loc, hour, minute, err := sch.parse()
+	if err != nil {
+		return start, end, err
+	}
+	days := map[time.Weekday]bool{}
+	for _, d := range sch.Days {
+		wd, _ := parseWeekday(d)
+		days[wd] = true
+	}
+
+	local := now.In(loc)
+	// Windows which started in the last week may still be active.
+	for i := -7; i <= 7; i++ {
+		ws := time.Date(local.Year(), local.Month(), local.Day()+i, hour, minute, 0, 0, loc)
+		if len(days) > 0 && !days[ws.Weekday()] {
+			continue
+		}
+		we := ws.Add(sch.Duration.Duration)
+		if we.After(now) {
+			return ws, we, nil
+		}
+	}
+	return start, end, fmt.Errorf("no window found for schedule")
+}
+
+// parse returns the location of the schedule and the hour and minute of its start time.
+func (sch SilenceSchedule) parse() (loc *time.Location, hour int, minute int, err error) {
+	loc = time.UTC
+	if sch.TimeZone != "" {
+		if loc, err = time.LoadLocation(sch.TimeZone); err != nil {
+			return nil, 0, 0, fmt.Errorf("invalid time zone %s", sch.TimeZone)
+		}
+	}
+	t, err := time.Parse("15:04", sch.StartTime)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("start time %s is not in HH:MM format", sch.StartTime)
+	}
+	for _, d := range sch.Days {
+		if _, err := parseWeekday(d); err != nil {
+			return nil, 0, 0, err
+		}
+	}
+	return loc, t.Hour(), t.Minute(), nil
+}
+
+func parseWeekday(s string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), s) || strings.EqualFold(d.String()[:3], s) {
+			return d, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("invalid day of week %s", s)
+}
@@ -0,0 +1,260 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSilenceIsValid(t *testing.T) {
+	start := metav1.NewTime(time.Date(2018, 6, 2, 0, 0, 0, 0, time.UTC))
+	end := metav1.NewTime(start.Add(2 * time.Hour))
+	schedule := func(sch SilenceSchedule) *SilenceSchedule {
+		if sch.StartTime == "" {
+			sch.StartTime = "22:00"
+		}
+		if sch.Duration.Duration == 0 {
+			sch.Duration = metav1.Duration{Duration: 4 * time.Hour}
+		}
+		return &sch
+	}
+
+	cases := []struct {
+		name    string
+		spec    SilenceSpec
+		wantErr bool
+	}{
+		{
+			name: "one-off",
+			spec: SilenceSpec{StartsAt: &start, EndsAt: &end},
+		},
+		{
+			name: "schedule",
+			spec: SilenceSpec{Schedule: schedule(SilenceSchedule{Days: []string{"Saturday", "sun"}, TimeZone: "Europe/Berlin"})},
+		},
+		{
+			name:    "one-off and schedule",
+			spec:    SilenceSpec{StartsAt: &start, EndsAt: &end, Schedule: schedule(SilenceSchedule{})},
+			wantErr: true,
+		},
+		{
+			name:    "no window",
+			wantErr: true,
+		},
+		{
+			name:    "missing end",
+			spec:    SilenceSpec{StartsAt: &start},
+			wantErr: true,
+		},
+		{
+			name:    "missing start",
+			spec:    SilenceSpec{EndsAt: &end},
+			wantErr: true,
+		},
+		{
+			name:    "end before start",
+			spec:    SilenceSpec{StartsAt: &end, EndsAt: &start},
+			wantErr: true,
+		},
+		{
+			name:    "empty window",
+			spec:    SilenceSpec{StartsAt: &start, EndsAt: &start},
+			wantErr: true,
+		},
+		{
+			name:    "invalid time zone",
+			spec:    SilenceSpec{Schedule: schedule(SilenceSchedule{TimeZone: "Mars/Olympus"})},
+			wantErr: true,
+		},
+		{
+			name:    "invalid start time",
+			spec:    SilenceSpec{Schedule: schedule(SilenceSchedule{StartTime: "24:00"})},
+			wantErr: true,
+		},
+		{
+			name:    "invalid day",
+			spec:    SilenceSpec{Schedule: schedule(SilenceSchedule{Days: []string{"Caturday"}})},
+			wantErr: true,
+		},
+		{
+			name:    "negative duration",
+			spec:    SilenceSpec{Schedule: schedule(SilenceSchedule{Duration: metav1.Duration{Duration: -time.Hour}})},
+			wantErr: true,
+		},
+		{
+			name: "week long duration",
+			spec: SilenceSpec{Schedule: schedule(SilenceSchedule{Duration: metav1.Duration{Duration: 7 * 24 * time.Hour}})},
+		},
+		{
+			name:    "duration above a week",
+			spec:    SilenceSpec{Schedule: schedule(SilenceSchedule{Duration: metav1.Duration{Duration: 7*24*time.Hour + time.Minute}})},
+			wantErr: true,
+		},
+		{
+			name: "global alerts",
+			spec: SilenceSpec{StartsAt: &start, EndsAt: &end, Matcher: SilenceMatcher{AlertKind: ResourceKindGlobalAlert}},
+		},
+		{
+			name:    "unknown alert kind",
+			spec:    SilenceSpec{StartsAt: &start, EndsAt: &end, Matcher: SilenceMatcher{AlertKind: "Pod"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid alert selector",
+			spec:    SilenceSpec{StartsAt: &start, EndsAt: &end, Matcher: SilenceMatcher{AlertSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"-": "x"}}}},
+			wantErr: true,
+		},
+		{
+			name:    "empty target name",
+			spec:    SilenceSpec{StartsAt: &start, EndsAt: &end, Matcher: SilenceMatcher{Target: &SilenceTarget{}}},
+			wantErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := Silence{Spec: c.spec}.IsValid()
+			if (err != nil) != c.wantErr {
+				t.Errorf("expected error %v, got %v", c.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSilenceWindow(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	utc := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2018, month, day, hour, minute, 0, 0, time.UTC)
+	}
+	startsAt, endsAt := metav1.NewTime(utc(6, 2, 0, 0)), metav1.NewTime(utc(6, 2, 2, 0))
+
+	cases := []struct {
+		name  string
+		spec  SilenceSpec
+		now   time.Time
+		start time.Time
+		end   time.Time
+	}{
+		{
+			name:  "upcoming one-off",
+			spec:  SilenceSpec{StartsAt: &startsAt, EndsAt: &endsAt},
+			now:   utc(6, 1, 0, 0),
+			start: startsAt.Time,
+			end:   endsAt.Time,
+		},
+		{
+			name:  "expired one-off",
+			spec:  SilenceSpec{StartsAt: &startsAt, EndsAt: &endsAt},
+			now:   utc(6, 3, 0, 0),
+			start: startsAt.Time,
+			end:   endsAt.Time,
+		},
+		{
+			// 2018-06-01 is a Friday
+			name:  "daily before start",
+			spec:  SilenceSpec{Schedule: &SilenceSchedule{StartTime: "22:00", Duration: metav1.Duration{Duration: 4 * time.Hour}}},
+			now:   utc(6, 1, 12, 0),
+			start: utc(6, 1, 22, 0),
+			end:   utc(6, 2, 2, 0),
+		},
+		{
+			name:  "daily at start",
+			spec:  SilenceSpec{Schedule: &SilenceSchedule{StartTime: "22:00", Duration: metav1.Duration{Duration: 4 * time.Hour}}},
+			now:   utc(6, 1, 22, 0),
+			start: utc(6, 1, 22, 0),
+			end:   utc(6, 2, 2, 0),
+		},
+		{
+			name:  "daily active across midnight",
+			spec:  SilenceSpec{Schedule: &SilenceSchedule{StartTime: "22:00", Duration: metav1.Duration{Duration: 4 * time.Hour}}},
+			now:   utc(6, 2, 1, 59),
+			start: utc(6, 1, 22, 0),
+			end:   utc(6, 2, 2, 0),
+		},
+		{
+			name:  "daily at end",
+			spec:  SilenceSpec{Schedule: &SilenceSchedule{StartTime: "22:00", Duration: metav1.Duration{Duration: 4 * time.Hour}}},
+			now:   utc(6, 2, 2, 0),
+			start: utc(6, 2, 22, 0),
+			end:   utc(6, 3, 2, 0),
+		},
+		{
+			name:  "next day of week",
+			spec:  SilenceSpec{Schedule: &SilenceSchedule{Days: []string{"Saturday"}, StartTime: "22:00", Duration: metav1.Duration{Duration: 4 * time.Hour}}},
+			now:   utc(6, 1, 23, 0),
+			start: utc(6, 2, 22, 0),
+			end:   utc(6, 3, 2, 0),
+		},
+		{
+			name:  "active into next day of week",
+			spec:  SilenceSpec{Schedule: &SilenceSchedule{Days: []string{"sat"}, StartTime: "22:00", Duration: metav1.Duration{Duration: 4 * time.Hour}}},
+			now:   utc(6, 3, 1, 0),
+			start: utc(6, 2, 22, 0),
+			end:   utc(6, 3, 2, 0),
+		},
+		{
+			name:  "week long window started last week",
+			spec:  SilenceSpec{Schedule: &SilenceSchedule{Days: []string{"Friday"}, StartTime: "10:00", Duration: metav1.Duration{Duration: 7 * 24 * time.Hour}}},
+			now:   utc(6, 1, 9, 59),
+			start: utc(5, 25, 10, 0),
+			end:   utc(6, 1, 10, 0),
+		},
+		{
+			name:  "time zone",
+			spec:  SilenceSpec{Schedule: &SilenceSchedule{StartTime: "09:00", TimeZone: "America/New_York", Duration: metav1.Duration{Duration: time.Hour}}},
+			now:   utc(6, 1, 13, 30),
+			start: time.Date(2018, 6, 1, 9, 0, 0, 0, newYork),
+			end:   time.Date(2018, 6, 1, 10, 0, 0, 0, newYork),
+		},
+		{
+			// the day of week is the one of the time zone, 2018-06-02 is a Saturday in New York
+			name:  "day of week in time zone",
+			spec:  SilenceSpec{Schedule: &SilenceSchedule{Days: []string{"Saturday"}, StartTime: "23:00", TimeZone: "America/New_York", Duration: metav1.Duration{Duration: time.Hour}}},
+			now:   utc(6, 2, 12, 0),
+			start: utc(6, 3, 3, 0),
+			end:   utc(6, 3, 4, 0),
+		},
+		{
+			// clocks are set back at 03:00 CEST on 2018-10-28, so the day has 25 hours
+			name:  "daylight saving time ends",
+			spec:  SilenceSpec{Schedule: &SilenceSchedule{StartTime: "12:00", TimeZone: "Europe/Berlin", Duration: metav1.Duration{Duration: time.Hour}}},
+			now:   utc(10, 27, 11, 0),
+			start: time.Date(2018, 10, 28, 12, 0, 0, 0, berlin),
+			end:   time.Date(2018, 10, 28, 13, 0, 0, 0, berlin),
+		},
+		{
+			// clocks are set forward at 02:00 CET on 2018-03-25, so 02:30 is skipped to 03:30 CEST
+			name:  "daylight saving time starts",
+			spec:  SilenceSpec{Schedule: &SilenceSchedule{StartTime: "02:30", TimeZone: "Europe/Berlin", Duration: metav1.Duration{Duration: time.Hour}}},
+			now:   utc(3, 25, 0, 0),
+			start: utc(3, 25, 1, 30),
+			end:   utc(3, 25, 2, 30),
+		},
+		{
+			// the window is one hour long in absolute time, although local time moves by two hours
+			name:  "window across daylight saving time start",
+			spec:  SilenceSpec{Schedule: &SilenceSchedule{StartTime: "01:30", TimeZone: "Europe/Berlin", Duration: metav1.Duration{Duration: time.Hour}}},
+			now:   utc(3, 25, 0, 0),
+			start: time.Date(2018, 3, 25, 1, 30, 0, 0, berlin),
+			end:   time.Date(2018, 3, 25, 3, 30, 0, 0, berlin),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			start, end, err := Silence{Spec: c.spec}.Window(c.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !start.Equal(c.start) || !end.Equal(c.end) {
+				t.Errorf("expected window %v - %v, got %v - %v", c.start.UTC(), c.end.UTC(), start.UTC(), end.UTC())
+			}
+		})
+	}
+}
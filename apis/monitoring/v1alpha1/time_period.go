@@ -0,0 +1,218 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindTimePeriod     = "TimePeriod"
+	ResourcePluralTimePeriod   = "timeperiods"
+	ResourceSingularTimePeriod = "timeperiod"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TimePeriod is a named set of weekly time ranges. Receivers of alerts referring to a
+// TimePeriod are only notified within its ranges.
+type TimePeriod struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the TimePeriod.
+	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status
+	Spec TimePeriodSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TimePeriodList is a collection of TimePeriod.
+type TimePeriodList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of TimePeriod.
+	Items []TimePeriod `json:"items"`
+}
+
+// TimePeriodSpec describes the TimePeriod the user wishes to create.
+type TimePeriodSpec struct {
+	// TimeZone of the ranges as IANA time zone name, e.g. Europe/Berlin. Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Ranges of the TimePeriod
+	Ranges []TimeRange `json:"ranges"`
+}
+
+// TimeRange describes a range of time on given days of every week.
+type TimeRange struct {
+	// Days of week of the range, e.g. Monday. Every day if empty.
+	Days []string `json:"days,omitempty"`
+
+	// Start of the range in 24 hour HH:MM format
+	StartTime string `json:"startTime"`
+
+	// End of the range in 24 hour HH:MM format. Use 24:00 for the end of day.
+	EndTime string `json:"endTime"`
+}
+
+func (p TimePeriod) IsValid() error {
+	if len(p.Spec.Ranges) == 0 {
+		return fmt.Errorf("at least one range is required")
+	}
+	if _, err := p.location(); err != nil {
+		return err
+	}
+	for _, r := range p.Spec.Ranges {
+		if _, _, _, err := r.parse(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p TimePeriod) ObjectReference() *core.ObjectReference {
+	return &core.ObjectReference{
+		APIVersion:      SchemeGroupVersion.String(),
+		Kind:            ResourceKindTimePeriod,
+		Name:            p.Name,
+		UID:             p.UID,
+		ResourceVersion: p.ResourceVersion,
+	}
+}
+
+// IsInside reports whether the given time is within one of the ranges.
+func (p TimePeriod) IsInside(t time.Time) (bool, error) {
+	loc, err := p.location()
+	if err != nil {
+		return false, err
+	}
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	for _, r := range p.Spec.Ranges {
+		days, start, end, err := r.parse()
+		if err != nil {
+			return false, err
+		}
+		if len(days) > 0 && !days[local.Weekday()] {
+			continue
+		}
+		if minute >= start && minute < end {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// IcingaRanges returns the ranges of the TimePeriod as ranges of an Icinga TimePeriod, keyed by
+// lower case day of week. Icinga has no time zone support for ranges, so they are converted
+// to UTC using the offset of the time zone in the week following the given time.
+func (p TimePeriod) IcingaRanges(now time.Time) (map[string]string, error) {
+	loc, err := p.location()
+	if err != nil {
+		return nil, err
+	}
+
+	type interval struct{ start, end int }
+	utcRanges := map[time.Weekday][]interval{}
+	add := func(from, to time.Time) {
+		// split at midnight in UTC
+		for from.Before(to) {
+			midnight := time.Date(from.Year(), from.Month(), from.Day()+1, 0, 0, 0, 0, time.UTC)
+			end := to
+			if midnight.Before(to) {
+				end = midnight
+			}
+			endMinute := end.Hour()*60 + end.Minute()
+			if end.Equal(midnight) {
+				endMinute = 24 * 60
+			}
+			utcRanges[from.Weekday()] = append(utcRanges[from.Weekday()], interval{from.Hour()*60 + from.Minute(), endMinute})
+			from = end
+		}
+	}
+
+	local := now.In(loc)
+	for i := 0; i < 7; i++ {
+		day := time.Date(local.Year(), local.Month(), local.Day()+i, 0, 0, 0, 0, loc)
+		for _, r := range p.Spec.Ranges {
+			days, start, end, err := r.parse()
+			if err != nil {
+				return nil, err
+			}
+			if len(days) > 0 && !days[day.Weekday()] {
+				continue
+			}
+			from := time.Date(day.Year(), day.Month(), day.Day(), start/60, start%60, 0, 0, loc)
+			to := time.Date(day.Year(), day.Month(), day.Day(), end/60, end%60, 0, 0, loc)
+			add(from.UTC(), to.UTC())
+		}
+	}
+
+	ranges := make(map[string]string, len(utcRanges))
+	for day, intervals := range utcRanges {
+		sort.Slice(intervals, func(i, j int) bool { return intervals[i].start < intervals[j].start })
+		parts := make([]string, 0, len(intervals))
+		for _, iv := range intervals {
+			parts = append(parts, fmt.Sprintf("%02d:%02d-%02d:%02d", iv.start/60, iv.start%60, iv.end/60, iv.end%60))
+		}
+		ranges[strings.ToLower(day.String())] = strings.Join(parts, ",")
+	}
+	return ranges, nil
+}
+
+func (p TimePeriod) location() (*time.Location, error) {
+	if p.Spec.TimeZone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(p.Spec.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %s", p.Spec.TimeZone)
+	}
+	return loc, nil
+}
+
+// parse returns the days of the range and its start and end as minutes of day.
+func (r TimeRange) parse() (days map[time.Weekday]bool, start int, end int, err error) {
+	days = map[time.Weekday]bool{}
+	for _, d := range r.Days {
+		wd, err := parseWeekday(d)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		days[wd] = true
+	}
+	if start, err = parseMinuteOfDay(r.StartTime); err != nil {
+		return nil, 0, 0, err
+	}
+	if end, err = parseMinuteOfDay(r.EndTime); err != nil {
+		return nil, 0, 0, err
+	}
+	if end <= start {
+		return nil, 0, 0, fmt.Errorf("end time %s must be after start time %s", r.EndTime, r.StartTime)
+	}
+	return days, start, end, nil
+}
+
+func parseMinuteOfDay(s string) (int, error) {
+	if s == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("time %s is not in HH:MM format", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
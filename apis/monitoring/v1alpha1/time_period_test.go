@@ -0,0 +1,225 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimePeriodIsInside(t *testing.T) {
+	workHours := TimeRange{Days: []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"}, StartTime: "09:00", EndTime: "17:00"}
+	lateNight := TimeRange{StartTime: "22:00", EndTime: "24:00"}
+	utc := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2018, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	cases := []struct {
+		name     string
+		spec     TimePeriodSpec
+		time     time.Time
+		expected bool
+	}{
+		{
+			// 2018-06-01 is a Friday
+			name:     "within range",
+			spec:     TimePeriodSpec{Ranges: []TimeRange{workHours}},
+			time:     utc(6, 1, 12, 0),
+			expected: true,
+		},
+		{
+			name:     "at start",
+			spec:     TimePeriodSpec{Ranges: []TimeRange{workHours}},
+			time:     utc(6, 1, 9, 0),
+			expected: true,
+		},
+		{
+			name: "before start",
+			spec: TimePeriodSpec{Ranges: []TimeRange{workHours}},
+			time: utc(6, 1, 8, 59),
+		},
+		{
+			name: "at end",
+			spec: TimePeriodSpec{Ranges: []TimeRange{workHours}},
+			time: utc(6, 1, 17, 0),
+		},
+		{
+			name:     "before end",
+			spec:     TimePeriodSpec{Ranges: []TimeRange{workHours}},
+			time:     utc(6, 1, 16, 59),
+			expected: true,
+		},
+		{
+			name: "other day of week",
+			spec: TimePeriodSpec{Ranges: []TimeRange{workHours}},
+			time: utc(6, 2, 12, 0),
+		},
+		{
+			name:     "every day",
+			spec:     TimePeriodSpec{Ranges: []TimeRange{lateNight}},
+			time:     utc(6, 2, 22, 0),
+			expected: true,
+		},
+		{
+			name:     "end of day",
+			spec:     TimePeriodSpec{Ranges: []TimeRange{lateNight}},
+			time:     utc(6, 2, 23, 59),
+			expected: true,
+		},
+		{
+			name: "after end of day",
+			spec: TimePeriodSpec{Ranges: []TimeRange{lateNight}},
+			time: utc(6, 3, 0, 0),
+		},
+		{
+			name:     "any of the ranges",
+			spec:     TimePeriodSpec{Ranges: []TimeRange{workHours, lateNight}},
+			time:     utc(6, 2, 23, 0),
+			expected: true,
+		},
+		{
+			name:     "abbreviated day",
+			spec:     TimePeriodSpec{Ranges: []TimeRange{{Days: []string{"sat"}, StartTime: "00:00", EndTime: "24:00"}}},
+			time:     utc(6, 2, 0, 0),
+			expected: true,
+		},
+		{
+			// 17:30 in UTC is 10:30 in Los Angeles
+			name:     "time zone",
+			spec:     TimePeriodSpec{TimeZone: "America/Los_Angeles", Ranges: []TimeRange{{StartTime: "10:00", EndTime: "11:00"}}},
+			time:     utc(6, 1, 17, 30),
+			expected: true,
+		},
+		{
+			// 2018-06-02 03:00 in UTC is still Friday in Los Angeles
+			name:     "day of week in time zone",
+			spec:     TimePeriodSpec{TimeZone: "America/Los_Angeles", Ranges: []TimeRange{workHours, {Days: []string{"Friday"}, StartTime: "17:00", EndTime: "24:00"}}},
+			time:     utc(6, 2, 3, 0),
+			expected: true,
+		},
+		{
+			// Berlin is UTC+1 in winter and UTC+2 in summer
+			name:     "standard time",
+			spec:     TimePeriodSpec{TimeZone: "Europe/Berlin", Ranges: []TimeRange{{StartTime: "09:00", EndTime: "10:00"}}},
+			time:     utc(1, 15, 8, 30),
+			expected: true,
+		},
+		{
+			name: "daylight saving time",
+			spec: TimePeriodSpec{TimeZone: "Europe/Berlin", Ranges: []TimeRange{{StartTime: "09:00", EndTime: "10:00"}}},
+			time: utc(6, 15, 8, 30),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			inside, err := TimePeriod{Spec: c.spec}.IsInside(c.time)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if inside != c.expected {
+				t.Errorf("expected inside %v, got %v", c.expected, inside)
+			}
+		})
+	}
+}
+
+func TestTimePeriodIsValid(t *testing.T) {
+	cases := []struct {
+		name    string
+		spec    TimePeriodSpec
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			spec: TimePeriodSpec{TimeZone: "Asia/Dhaka", Ranges: []TimeRange{{Days: []string{"Sunday", "mon"}, StartTime: "00:00", EndTime: "24:00"}}},
+		},
+		{
+			name:    "no ranges",
+			spec:    TimePeriodSpec{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid time zone",
+			spec:    TimePeriodSpec{TimeZone: "Mars/Olympus", Ranges: []TimeRange{{StartTime: "09:00", EndTime: "17:00"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid day",
+			spec:    TimePeriodSpec{Ranges: []TimeRange{{Days: []string{"Caturday"}, StartTime: "09:00", EndTime: "17:00"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid start time",
+			spec:    TimePeriodSpec{Ranges: []TimeRange{{StartTime: "9am", EndTime: "17:00"}}},
+			wantErr: true,
+		},
+		{
+			name:    "start at end of day",
+			spec:    TimePeriodSpec{Ranges: []TimeRange{{StartTime: "24:00", EndTime: "24:00"}}},
+			wantErr: true,
+		},
+		{
+			name:    "end before start",
+			spec:    TimePeriodSpec{Ranges: []TimeRange{{StartTime: "22:00", EndTime: "06:00"}}},
+			wantErr: true,
+		},
+		{
+			name:    "empty range",
+			spec:    TimePeriodSpec{Ranges: []TimeRange{{StartTime: "09:00", EndTime: "09:00"}}},
+			wantErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := TimePeriod{Spec: c.spec}.IsValid()
+			if (err != nil) != c.wantErr {
+				t.Errorf("expected error %v, got %v", c.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTimePeriodIcingaRanges(t *testing.T) {
+	// 2018-06-01 is a Friday
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		spec     TimePeriodSpec
+		expected map[string]string
+	}{
+		{
+			name: "utc",
+			spec: TimePeriodSpec{Ranges: []TimeRange{{Days: []string{"Monday"}, StartTime: "09:00", EndTime: "17:00"}, {Days: []string{"Monday"}, StartTime: "06:00", EndTime: "07:30"}}},
+			expected: map[string]string{
+				"monday": "06:00-07:30,09:00-17:00",
+			},
+		},
+		{
+			// Berlin is UTC+2 in summer, so the range starts on the previous day in UTC
+			name: "split at midnight in utc",
+			spec: TimePeriodSpec{TimeZone: "Europe/Berlin", Ranges: []TimeRange{{Days: []string{"Monday"}, StartTime: "01:00", EndTime: "03:00"}}},
+			expected: map[string]string{
+				"sunday": "23:00-24:00",
+				"monday": "00:00-01:00",
+			},
+		},
+		{
+			name: "end of day",
+			spec: TimePeriodSpec{TimeZone: "Asia/Dhaka", Ranges: []TimeRange{{Days: []string{"Saturday"}, StartTime: "18:00", EndTime: "24:00"}}},
+			expected: map[string]string{
+				"saturday": "12:00-18:00",
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ranges, err := TimePeriod{Spec: c.spec}.IcingaRanges(now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(ranges, c.expected) {
+				t.Errorf("expected ranges %v, got %v", c.expected, ranges)
+			}
+		})
+	}
+}
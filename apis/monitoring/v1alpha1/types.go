@@ -1,5 +1,11 @@
 package v1alpha1
 
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 type Receiver struct {
 	// For which state notification will be sent
 	State string `json:"state,omitempty"`
@@ -9,4 +15,44 @@ type Receiver struct {
 
 	// How this notification will be sent
 	Notifier string `json:"notifier,omitempty"`
+
+	// Name of a NotifierConfig whose notifier and credentials are used instead of
+	// the notifier secret of the alert. Notifier defaults to the one of the NotifierConfig.
+	// +optional
+	NotifierConfig string `json:"notifierConfig,omitempty"`
+
+	// EscalateAfter turns this receiver into an escalation step. Problem notifications are sent to it
+	// only if the incident is not acknowledged within this duration after its first problem notification.
+	// +optional
+	EscalateAfter *metav1.Duration `json:"escalateAfter,omitempty"`
+
+	// Name of a TimePeriod outside of which no notification is sent to this receiver
+	// +optional
+	Period string `json:"period,omitempty"`
+}
+
+// AlertDependency declares the alert which checks the node of a pod.
+type AlertDependency struct {
+	// Name of a NodeAlert in the namespace of the PodAlert, typically running the node-status check
+	NodeAlert string `json:"nodeAlert"`
+}
+
+// DefaultRolloutDeadline is the deadline of RolloutSuppression if none is set.
+const DefaultRolloutDeadline = 10 * time.Minute
+
+// RolloutSuppression schedules Icinga downtimes for the services of a PodAlert on the pods of a
+// Deployment or StatefulSet while it rolls out.
+type RolloutSuppression struct {
+	// Longest time services are kept in downtime, counted from the start of the rollout.
+	// Defaults to 10m.
+	// +optional
+	Deadline metav1.Duration `json:"deadline,omitempty"`
+}
+
+// GetDeadline returns the deadline of the suppression, or DefaultRolloutDeadline if not set.
+func (r RolloutSuppression) GetDeadline() time.Duration {
+	if r.Deadline.Duration > 0 {
+		return r.Deadline.Duration
+	}
+	return DefaultRolloutDeadline
 }
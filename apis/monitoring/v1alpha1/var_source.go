@@ -0,0 +1,98 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// SecretVarPrefix is the prefix of the values of Icinga service vars referring to a key of a secret.
+// Such values are written by Searchlight operator for vars set via varsFrom and are resolved by
+// hyperalert at check time, so that secrets are never stored in Icinga.
+const SecretVarPrefix = "secretKeyRef://"
+
+// VarSource represents a source for the value of a var.
+type VarSource struct {
+	// Selects a key of a secret in the namespace of the alert
+	// +optional
+	SecretKeyRef *SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// SecretKeySelector selects a key of a secret in the namespace of the alert.
+type SecretKeySelector struct {
+	// Name of the secret
+	Name string `json:"name"`
+
+	// Key of the secret to select
+	Key string `json:"key"`
+}
+
+// SecretVarRef returns the value of an Icinga service var referring to a key of a secret.
+func SecretVarRef(namespace string, ref SecretKeySelector) string {
+	return SecretVarPrefix + namespace + "/" + ref.Name + "/" + ref.Key
+}
+
+// IsSecretVarRef returns true if the value of a var refers to a key of a secret.
+func IsSecretVarRef(value string) bool {
+	return strings.HasPrefix(value, SecretVarPrefix)
+}
+
+// ParseSecretVarRef returns the namespace, name and key of the secret a var refers to.
+func ParseSecretVarRef(value string) (namespace string, ref SecretKeySelector, err error) {
+	parts := strings.Split(strings.TrimPrefix(value, SecretVarPrefix), "/")
+	if !IsSecretVarRef(value) || len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", ref, fmt.Errorf("%s is not a valid secret reference", value)
+	}
+	return parts[0], SecretKeySelector{Name: parts[1], Key: parts[2]}, nil
+}
+
+// SecretVarRefs returns the values of the Icinga service vars set via varsFrom of an alert in the given namespace.
+func SecretVarRefs(namespace string, varsFrom map[string]VarSource) map[string]string {
+	out := make(map[string]string, len(varsFrom))
+	for k, src := range varsFrom {
+		if src.SecretKeyRef != nil {
+			out[k] = SecretVarRef(namespace, *src.SecretKeyRef)
+		}
+	}
+	return out
+}
+
+// validateVarsFrom validates varsFrom of an alert or AlertTemplate, and that vars don't refer to secrets themselves.
+func validateVarsFrom(vars map[string]string, varsFrom map[string]VarSource) error {
+	for k, src := range varsFrom {
+		if _, found := vars[k]; found {
+			return fmt.Errorf("var '%s' is set in both vars and varsFrom", k)
+		}
+		if src.SecretKeyRef == nil {
+			return fmt.Errorf("secretKeyRef of var '%s' is required", k)
+		}
+		if errs := validation.IsDNS1123Subdomain(src.SecretKeyRef.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid secret name of var '%s': %s", k, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsConfigMapKey(src.SecretKeyRef.Key); len(errs) > 0 {
+			return fmt.Errorf("invalid secret key of var '%s': %s", k, strings.Join(errs, ", "))
+		}
+	}
+	for k, v := range vars {
+		if IsSecretVarRef(v) {
+			return fmt.Errorf("value of var '%s' must not start with %s, use varsFrom instead", k, SecretVarPrefix)
+		}
+	}
+	return nil
+}
+
+// GetVarsFrom returns the varsFrom of an alert.
+func GetVarsFrom(alert Alert) map[string]VarSource {
+	switch a := alert.(type) {
+	case *ClusterAlert:
+		return a.Spec.VarsFrom
+	case *NodeAlert:
+		return a.Spec.VarsFrom
+	case *PodAlert:
+		return a.Spec.VarsFrom
+	case *WorkloadAlert:
+		return a.Spec.VarsFrom
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	ResourceKindWorkloadAlert     = "WorkloadAlert"
+	ResourcePluralWorkloadAlert   = "workloadalerts"
+	ResourceSingularWorkloadAlert = "workloadalert"
+)
+
+// Kinds of workloads supported by WorkloadAlert
+const (
+	WorkloadKindDeployment  = "Deployment"
+	WorkloadKindStatefulSet = "StatefulSet"
+	WorkloadKindDaemonSet   = "DaemonSet"
+)
+
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type WorkloadAlert struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the WorkloadAlert.
+	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#spec-and-status
+	Spec WorkloadAlertSpec `json:"spec,omitempty"`
+
+	// Status is the most recently observed status of the WorkloadAlert.
+	// +optional
+	Status AlertStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WorkloadAlertList is a collection of WorkloadAlert.
+type WorkloadAlertList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: http://releases.k8s.io/release-1.2/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of WorkloadAlert.
+	Items []WorkloadAlert `json:"items"`
+}
+
+// WorkloadAlertSpec describes the WorkloadAlert the user wishes to create.
+type WorkloadAlertSpec struct {
+	// TargetRef selects the workloads checked by this alert
+	TargetRef WorkloadTargetRef `json:"targetRef"`
+
+	// Icinga CheckCommand name
+	Check string `json:"check,omitempty"`
+
+	// How frequently Icinga Service will be checked
+	CheckInterval metav1.Duration `json:"checkInterval,omitempty"`
+
+	// How frequently notifications will be send
+	AlertInterval metav1.Duration `json:"alertInterval,omitempty"`
+
+	// Number of times Icinga Service is checked before a non-OK state is considered hard
+	// and notifications are sent
+	// +optional
+	MaxCheckAttempts int32 `json:"maxCheckAttempts,omitempty"`
+
+	// How frequently Icinga Service will be re-checked while in a soft non-OK state
+	// +optional
+	RetryInterval metav1.Duration `json:"retryInterval,omitempty"`
+
+	// Timeout of CheckCommand
+	// +optional
+	CheckTimeout metav1.Duration `json:"checkTimeout,omitempty"`
+
+	// Indicates that flap detection is enabled for Icinga Service
+	// +optional
+	EnableFlapping bool `json:"enableFlapping,omitempty"`
+
+	// Percentage of state changes below which Icinga Service stops flapping
+	// +optional
+	FlappingThresholdLow int32 `json:"flappingThresholdLow,omitempty"`
+
+	// Percentage of state changes above which Icinga Service starts flapping
+	// +optional
+	FlappingThresholdHigh int32 `json:"flappingThresholdHigh,omitempty"`
+
+	// Secret containing notifier credentials
+	NotifierSecretName string `json:"notifierSecretName,omitempty"`
+
+	// NotifierParams contains information to send notifications for Incident
+	// State, UserUid, Method
+	Receivers []Receiver `json:"receivers,omitempty"`
+
+	// Vars contains Icinga Service variables to be used in CheckCommand
+	Vars map[string]string `json:"vars,omitempty"`
+
+	// VarsFrom contains Icinga Service variables whose values are read from secrets in the
+	// namespace of the alert at check time
+	// +optional
+	VarsFrom map[string]VarSource `json:"varsFrom,omitempty"`
+
+	// Indicates that Check is paused
+	// Icinga Services are removed
+	Paused bool `json:"paused,omitempty"`
+}
+
+// WorkloadTargetRef selects workloads of a kind in the namespace of the WorkloadAlert,
+// either by name or by label selector.
+type WorkloadTargetRef struct {
+	// Kind of the workload, one of Deployment, StatefulSet or DaemonSet
+	Kind string `json:"kind"`
+
+	// Name of the workload
+	Name *string `json:"name,omitempty"`
+
+	// Selector matches labels of the workloads
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+var _ Alert = &WorkloadAlert{}
+
+func (a WorkloadAlert) GetName() string {
+	return a.Name
+}
+
+func (a WorkloadAlert) GetNamespace() string {
+	return a.Namespace
+}
+
+func (a WorkloadAlert) Command() string {
+	return string(a.Spec.Check)
+}
+
+func (a WorkloadAlert) GetCheckInterval() time.Duration {
+	return a.Spec.CheckInterval.Duration
+}
+
+func (a WorkloadAlert) GetAlertInterval() time.Duration {
+	return a.Spec.AlertInterval.Duration
+}
+
+func (a WorkloadAlert) GetMaxCheckAttempts() int32 {
+	return a.Spec.MaxCheckAttempts
+}
+
+func (a WorkloadAlert) GetRetryInterval() time.Duration {
+	return a.Spec.RetryInterval.Duration
+}
+
+func (a WorkloadAlert) GetCheckTimeout() time.Duration {
+	return a.Spec.CheckTimeout.Duration
+}
+
+func (a WorkloadAlert) GetFlapping() (enabled bool, low, high int32) {
+	return a.Spec.EnableFlapping, a.Spec.FlappingThresholdLow, a.Spec.FlappingThresholdHigh
+}
+
+func (a WorkloadAlert) IsValid(kc kubernetes.Interface) error {
+	if a.Spec.Paused {
+		return nil
+	}
+
+	switch a.Spec.TargetRef.Kind {
+	case WorkloadKindDeployment, WorkloadKindStatefulSet, WorkloadKindDaemonSet:
+	default:
+		return fmt.Errorf("workload kind '%s' is unsupported", a.Spec.TargetRef.Kind)
+	}
+	if a.Spec.TargetRef.Name != nil && a.Spec.TargetRef.Selector != nil {
+		return fmt.Errorf("can't specify both workload name and selector")
+	}
+	if a.Spec.TargetRef.Name == nil && a.Spec.TargetRef.Selector == nil {
+		return fmt.Errorf("specify either workload name or selector")
+	}
+	if a.Spec.TargetRef.Selector != nil {
+		_, err := metav1.LabelSelectorAsSelector(a.Spec.TargetRef.Selector)
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd, ok := WorkloadCommands.Get(a.Spec.Check)
+	if !ok {
+		return fmt.Errorf("%s is not a valid workload check command", a.Spec.Check)
+	}
+
+	if err := validateVariables(cmd.Vars, a.Spec.Vars, a.Spec.VarsFrom); err != nil {
+		return err
+	}
+
+	if err := validateCheckSettings(a); err != nil {
+		return err
+	}
+
+	for _, rcv := range a.Spec.Receivers {
+		found := false
+		for _, state := range cmd.States {
+			if strings.EqualFold(state, rcv.State) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("state %s is unsupported for check command %s", rcv.State, a.Spec.Check)
+		}
+	}
+
+	return checkNotifiers(kc, a)
+}
+
+func (a WorkloadAlert) GetNotifierSecretNamespace() string {
+	return a.Namespace
+}
+
+func (a WorkloadAlert) GetNotifierSecretName() string {
+	return a.Spec.NotifierSecretName
+}
+
+func (a WorkloadAlert) GetReceivers() []Receiver {
+	return a.Spec.Receivers
+}
+
+func (a WorkloadAlert) ObjectReference() *core.ObjectReference {
+	return &core.ObjectReference{
+		APIVersion:      SchemeGroupVersion.String(),
+		Kind:            ResourceKindWorkloadAlert,
+		Namespace:       a.Namespace,
+		Name:            a.Name,
+		UID:             a.UID,
+		ResourceVersion: a.ResourceVersion,
+	}
+}
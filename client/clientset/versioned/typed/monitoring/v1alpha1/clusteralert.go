@@ -39,6 +39,7 @@ type ClusterAlertsGetter interface {
 type ClusterAlertInterface interface {
 	Create(*v1alpha1.ClusterAlert) (*v1alpha1.ClusterAlert, error)
 	Update(*v1alpha1.ClusterAlert) (*v1alpha1.ClusterAlert, error)
+	UpdateStatus(*v1alpha1.ClusterAlert) (*v1alpha1.ClusterAlert, error)
 	Delete(name string, options *v1.DeleteOptions) error
 	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
 	Get(name string, options v1.GetOptions) (*v1alpha1.ClusterAlert, error)
@@ -132,6 +133,22 @@ func (c *clusterAlerts) Update(clusterAlert *v1alpha1.ClusterAlert) (result *v1a
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *clusterAlerts) UpdateStatus(clusterAlert *v1alpha1.ClusterAlert) (result *v1alpha1.ClusterAlert, err error) {
+	result = &v1alpha1.ClusterAlert{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("clusteralerts").
+		Name(clusterAlert.Name).
+		SubResource("status").
+		Body(clusterAlert).
+		Do().
+		Into(result)
+	return
+}
+
 // Delete takes name of the clusterAlert and deletes it. Returns an error if one occurs.
 func (c *clusterAlerts) Delete(name string, options *v1.DeleteOptions) error {
 	return c.client.Delete().
@@ -100,6 +100,18 @@ func (c *FakeClusterAlerts) Update(clusterAlert *v1alpha1.ClusterAlert) (result
 	return obj.(*v1alpha1.ClusterAlert), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeClusterAlerts) UpdateStatus(clusterAlert *v1alpha1.ClusterAlert) (*v1alpha1.ClusterAlert, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(clusteralertsResource, "status", c.ns, clusterAlert), &v1alpha1.ClusterAlert{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterAlert), err
+}
+
 // Delete takes name of the clusterAlert and deletes it. Returns an error if one occurs.
 func (c *FakeClusterAlerts) Delete(name string, options *v1.DeleteOptions) error {
 	_, err := c.Fake.
@@ -94,6 +94,17 @@ func (c *FakeSearchlightPlugins) Update(searchlightPlugin *v1alpha1.SearchlightP
 	return obj.(*v1alpha1.SearchlightPlugin), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeSearchlightPlugins) UpdateStatus(searchlightPlugin *v1alpha1.SearchlightPlugin) (*v1alpha1.SearchlightPlugin, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(searchlightpluginsResource, "status", searchlightPlugin), &v1alpha1.SearchlightPlugin{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.SearchlightPlugin), err
+}
+
 // Delete takes name of the searchlightPlugin and deletes it. Returns an error if one occurs.
 func (c *FakeSearchlightPlugins) Delete(name string, options *v1.DeleteOptions) error {
 	_, err := c.Fake.
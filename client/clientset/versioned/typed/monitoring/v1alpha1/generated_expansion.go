@@ -18,12 +18,24 @@ limitations under the License.
 
 package v1alpha1
 
+type AlertTemplateExpansion interface{}
+
 type ClusterAlertExpansion interface{}
 
+type GlobalAlertExpansion interface{}
+
 type IncidentExpansion interface{}
 
 type NodeAlertExpansion interface{}
 
+type NotifierConfigExpansion interface{}
+
 type PodAlertExpansion interface{}
 
 type SearchlightPluginExpansion interface{}
+
+type SilenceExpansion interface{}
+
+type TimePeriodExpansion interface{}
+
+type WorkloadAlertExpansion interface{}
@@ -27,11 +27,17 @@ import (
 
 type MonitoringV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	AlertTemplatesGetter
 	ClusterAlertsGetter
+	GlobalAlertsGetter
 	IncidentsGetter
 	NodeAlertsGetter
+	NotifierConfigsGetter
 	PodAlertsGetter
 	SearchlightPluginsGetter
+	SilencesGetter
+	TimePeriodsGetter
+	WorkloadAlertsGetter
 }
 
 // MonitoringV1alpha1Client is used to interact with features provided by the monitoring.appscode.com group.
@@ -39,10 +45,18 @@ type MonitoringV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *MonitoringV1alpha1Client) AlertTemplates(namespace string) AlertTemplateInterface {
+	return newAlertTemplates(c, namespace)
+}
+
 func (c *MonitoringV1alpha1Client) ClusterAlerts(namespace string) ClusterAlertInterface {
 	return newClusterAlerts(c, namespace)
 }
 
+func (c *MonitoringV1alpha1Client) GlobalAlerts() GlobalAlertInterface {
+	return newGlobalAlerts(c)
+}
+
 func (c *MonitoringV1alpha1Client) Incidents(namespace string) IncidentInterface {
 	return newIncidents(c, namespace)
 }
@@ -51,6 +65,10 @@ func (c *MonitoringV1alpha1Client) NodeAlerts(namespace string) NodeAlertInterfa
 	return newNodeAlerts(c, namespace)
 }
 
+func (c *MonitoringV1alpha1Client) NotifierConfigs() NotifierConfigInterface {
+	return newNotifierConfigs(c)
+}
+
 func (c *MonitoringV1alpha1Client) PodAlerts(namespace string) PodAlertInterface {
 	return newPodAlerts(c, namespace)
 }
@@ -59,6 +77,18 @@ func (c *MonitoringV1alpha1Client) SearchlightPlugins() SearchlightPluginInterfa
 	return newSearchlightPlugins(c)
 }
 
+func (c *MonitoringV1alpha1Client) Silences(namespace string) SilenceInterface {
+	return newSilences(c, namespace)
+}
+
+func (c *MonitoringV1alpha1Client) TimePeriods() TimePeriodInterface {
+	return newTimePeriods(c)
+}
+
+func (c *MonitoringV1alpha1Client) WorkloadAlerts(namespace string) WorkloadAlertInterface {
+	return newWorkloadAlerts(c, namespace)
+}
+
 // NewForConfig creates a new MonitoringV1alpha1Client for the given config.
 func NewForConfig(c *rest.Config) (*MonitoringV1alpha1Client, error) {
 	config := *c
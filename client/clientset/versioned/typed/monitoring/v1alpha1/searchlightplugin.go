@@ -39,6 +39,7 @@ type SearchlightPluginsGetter interface {
 type SearchlightPluginInterface interface {
 	Create(*v1alpha1.SearchlightPlugin) (*v1alpha1.SearchlightPlugin, error)
 	Update(*v1alpha1.SearchlightPlugin) (*v1alpha1.SearchlightPlugin, error)
+	UpdateStatus(*v1alpha1.SearchlightPlugin) (*v1alpha1.SearchlightPlugin, error)
 	Delete(name string, options *v1.DeleteOptions) error
 	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
 	Get(name string, options v1.GetOptions) (*v1alpha1.SearchlightPlugin, error)
@@ -125,6 +126,21 @@ func (c *searchlightPlugins) Update(searchlightPlugin *v1alpha1.SearchlightPlugi
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *searchlightPlugins) UpdateStatus(searchlightPlugin *v1alpha1.SearchlightPlugin) (result *v1alpha1.SearchlightPlugin, err error) {
+	result = &v1alpha1.SearchlightPlugin{}
+	err = c.client.Put().
+		Resource("searchlightplugins").
+		Name(searchlightPlugin.Name).
+		SubResource("status").
+		Body(searchlightPlugin).
+		Do().
+		Into(result)
+	return
+}
+
 // Delete takes name of the searchlightPlugin and deletes it. Returns an error if one occurs.
 func (c *searchlightPlugins) Delete(name string, options *v1.DeleteOptions) error {
 	return c.client.Delete().
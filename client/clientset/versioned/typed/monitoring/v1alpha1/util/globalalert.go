@@ -0,0 +1,132 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	cs "github.com/appscode/searchlight/client/clientset/versioned/typed/monitoring/v1alpha1"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kutil "kmodules.xyz/client-go"
+)
+
+func CreateOrPatchGlobalAlert(c cs.MonitoringV1alpha1Interface, meta metav1.ObjectMeta, transform func(alert *api.GlobalAlert) *api.GlobalAlert) (*api.GlobalAlert, kutil.VerbType, error) {
+	cur, err := c.GlobalAlerts().Get(meta.Name, metav1.GetOptions{})
+	if kerr.IsNotFound(err) {
+		glog.V(3).Infof("Creating GlobalAlert %s.", meta.Name)
+		out, err := c.GlobalAlerts().Create(transform(&api.GlobalAlert{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "GlobalAlert",
+				APIVersion: api.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: meta,
+		}))
+		return out, kutil.VerbCreated, err
+	} else if err != nil {
+		return nil, kutil.VerbUnchanged, err
+	}
+	return PatchGlobalAlert(c, cur, transform)
+}
+
+func PatchGlobalAlert(c cs.MonitoringV1alpha1Interface, cur *api.GlobalAlert, transform func(*api.GlobalAlert) *api.GlobalAlert) (*api.GlobalAlert, kutil.VerbType, error) {
+	return PatchGlobalAlertObject(c, cur, transform(cur.DeepCopy()))
+}
+
+func PatchGlobalAlertObject(c cs.MonitoringV1alpha1Interface, cur, mod *api.GlobalAlert) (*api.GlobalAlert, kutil.VerbType, error) {
+	curJson, err := json.Marshal(cur)
+	if err != nil {
+		return nil, kutil.VerbUnchanged, err
+	}
+
+	modJson, err := json.Marshal(mod)
+	if err != nil {
+		return nil, kutil.VerbUnchanged, err
+	}
+
+	patch, err := jsonmergepatch.CreateThreeWayJSONMergePatch(curJson, modJson, curJson)
+	if err != nil {
+		return nil, kutil.VerbUnchanged, err
+	}
+	if len(patch) == 0 || string(patch) == "{}" {
+		return cur, kutil.VerbUnchanged, nil
+	}
+	glog.V(3).Infof("Patching GlobalAlert %s with %s.", cur.Name, string(patch))
+	out, err := c.GlobalAlerts().Patch(cur.Name, types.MergePatchType, patch)
+	return out, kutil.VerbPatched, err
+}
+
+func TryUpdateGlobalAlert(c cs.MonitoringV1alpha1Interface, meta metav1.ObjectMeta, transform func(*api.GlobalAlert) *api.GlobalAlert) (result *api.GlobalAlert, err error) {
+	attempt := 0
+	err = wait.PollImmediate(kutil.RetryInterval, kutil.RetryTimeout, func() (bool, error) {
+		attempt++
+		cur, e2 := c.GlobalAlerts().Get(meta.Name, metav1.GetOptions{})
+		if kerr.IsNotFound(e2) {
+			return false, e2
+		} else if e2 == nil {
+			result, e2 = c.GlobalAlerts().Update(transform(cur.DeepCopy()))
+			return e2 == nil, nil
+		}
+		glog.Errorf("Attempt %d failed to update GlobalAlert %s due to %v.", attempt, cur.Name, e2)
+		return false, nil
+	})
+
+	if err != nil {
+		err = fmt.Errorf("failed to update GlobalAlert %s after %d attempts due to %v", meta.Name, attempt, err)
+	}
+	return
+}
+
+func UpdateGlobalAlertStatus(
+	c cs.MonitoringV1alpha1Interface,
+	in *api.GlobalAlert,
+	transform func(*api.AlertStatus) *api.AlertStatus,
+	useSubresource ...bool,
+) (result *api.GlobalAlert, err error) {
+	if len(useSubresource) > 1 {
+		return nil, errors.Errorf("invalid value passed for useSubresource: %v", useSubresource)
+	}
+	apply := func(x *api.GlobalAlert) *api.GlobalAlert {
+		out := x.DeepCopy()
+		out.Status = *transform(x.Status.DeepCopy())
+		return out
+	}
+
+	if len(useSubresource) == 1 && useSubresource[0] {
+		attempt := 0
+		cur := in.DeepCopy()
+		err = wait.PollImmediate(kutil.RetryInterval, kutil.RetryTimeout, func() (bool, error) {
+			attempt++
+			var e2 error
+			result, e2 = c.GlobalAlerts().UpdateStatus(apply(cur))
+			if kerr.IsConflict(e2) {
+				latest, e3 := c.GlobalAlerts().Get(in.Name, metav1.GetOptions{})
+				switch {
+				case e3 == nil:
+					cur = latest
+					return false, nil
+				case kutil.IsRequestRetryable(e3):
+					return false, nil
+				default:
+					return false, e3
+				}
+			} else if e2 != nil && !kutil.IsRequestRetryable(e2) {
+				return false, e2
+			}
+			return e2 == nil, nil
+		})
+
+		if err != nil {
+			err = fmt.Errorf("failed to update status of GlobalAlert %s after %d attempts due to %v", in.Name, attempt, err)
+		}
+		return
+	}
+
+	result, _, err = PatchGlobalAlertObject(c, in, apply(in))
+	return
+}
@@ -32,6 +32,30 @@ func AssignTypeKind(v interface{}) error {
 		u.APIVersion = api.SchemeGroupVersion.String()
 		u.Kind = meta.GetKind(v)
 		return nil
+	case *api.WorkloadAlert:
+		u.APIVersion = api.SchemeGroupVersion.String()
+		u.Kind = meta.GetKind(v)
+		return nil
+	case *api.GlobalAlert:
+		u.APIVersion = api.SchemeGroupVersion.String()
+		u.Kind = meta.GetKind(v)
+		return nil
+	case *api.Silence:
+		u.APIVersion = api.SchemeGroupVersion.String()
+		u.Kind = meta.GetKind(v)
+		return nil
+	case *api.TimePeriod:
+		u.APIVersion = api.SchemeGroupVersion.String()
+		u.Kind = meta.GetKind(v)
+		return nil
+	case *api.AlertTemplate:
+		u.APIVersion = api.SchemeGroupVersion.String()
+		u.Kind = meta.GetKind(v)
+		return nil
+	case *api.NotifierConfig:
+		u.APIVersion = api.SchemeGroupVersion.String()
+		u.Kind = meta.GetKind(v)
+		return nil
 	}
 	return errors.New("unknown api object type")
 }
@@ -7,6 +7,7 @@ import (
 	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
 	cs "github.com/appscode/searchlight/client/clientset/versioned/typed/monitoring/v1alpha1"
 	"github.com/golang/glog"
+	"github.com/pkg/errors"
 	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -80,3 +81,52 @@ func TryUpdateSearchlightPlugin(c cs.MonitoringV1alpha1Interface, meta metav1.Ob
 	}
 	return
 }
+
+func UpdateSearchlightPluginStatus(
+	c cs.MonitoringV1alpha1Interface,
+	in *api.SearchlightPlugin,
+	transform func(*api.SearchlightPluginStatus) *api.SearchlightPluginStatus,
+	useSubresource ...bool,
+) (result *api.SearchlightPlugin, err error) {
+	if len(useSubresource) > 1 {
+		return nil, errors.Errorf("invalid value passed for useSubresource: %v", useSubresource)
+	}
+	apply := func(x *api.SearchlightPlugin) *api.SearchlightPlugin {
+		out := x.DeepCopy()
+		out.Status = *transform(x.Status.DeepCopy())
+		return out
+	}
+
+	if len(useSubresource) == 1 && useSubresource[0] {
+		attempt := 0
+		cur := in.DeepCopy()
+		err = wait.PollImmediate(kutil.RetryInterval, kutil.RetryTimeout, func() (bool, error) {
+			attempt++
+			var e2 error
+			result, e2 = c.SearchlightPlugins().UpdateStatus(apply(cur))
+			if kerr.IsConflict(e2) {
+				latest, e3 := c.SearchlightPlugins().Get(in.Name, metav1.GetOptions{})
+				switch {
+				case e3 == nil:
+					cur = latest
+					return false, nil
+				case kutil.IsRequestRetryable(e3):
+					return false, nil
+				default:
+					return false, e3
+				}
+			} else if e2 != nil && !kutil.IsRequestRetryable(e2) {
+				return false, e2
+			}
+			return e2 == nil, nil
+		})
+
+		if err != nil {
+			err = fmt.Errorf("failed to update status of SearchlightPlugin %s after %d attempts due to %v", in.Name, attempt, err)
+		}
+		return
+	}
+
+	result, _, err = PatchSearchlightPluginObject(c, in, apply(in))
+	return
+}
@@ -0,0 +1,82 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	cs "github.com/appscode/searchlight/client/clientset/versioned/typed/monitoring/v1alpha1"
+	"github.com/golang/glog"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kutil "kmodules.xyz/client-go"
+)
+
+func CreateOrPatchTimePeriod(c cs.MonitoringV1alpha1Interface, meta metav1.ObjectMeta, transform func(alert *api.TimePeriod) *api.TimePeriod) (*api.TimePeriod, kutil.VerbType, error) {
+	cur, err := c.TimePeriods().Get(meta.Name, metav1.GetOptions{})
+	if kerr.IsNotFound(err) {
+		glog.V(3).Infof("Creating TimePeriod %s/%s.", meta.Namespace, meta.Name)
+		out, err := c.TimePeriods().Create(transform(&api.TimePeriod{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "TimePeriod",
+				APIVersion: api.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: meta,
+		}))
+		return out, kutil.VerbCreated, err
+	} else if err != nil {
+		return nil, kutil.VerbUnchanged, err
+	}
+	return PatchTimePeriod(c, cur, transform)
+}
+
+func PatchTimePeriod(c cs.MonitoringV1alpha1Interface, cur *api.TimePeriod, transform func(*api.TimePeriod) *api.TimePeriod) (*api.TimePeriod, kutil.VerbType, error) {
+	return PatchTimePeriodObject(c, cur, transform(cur.DeepCopy()))
+}
+
+func PatchTimePeriodObject(c cs.MonitoringV1alpha1Interface, cur, mod *api.TimePeriod) (*api.TimePeriod, kutil.VerbType, error) {
+	curJson, err := json.Marshal(cur)
+	if err != nil {
+		return nil, kutil.VerbUnchanged, err
+	}
+
+	modJson, err := json.Marshal(mod)
+	if err != nil {
+		return nil, kutil.VerbUnchanged, err
+	}
+
+	patch, err := jsonmergepatch.CreateThreeWayJSONMergePatch(curJson, modJson, curJson)
+	if err != nil {
+		return nil, kutil.VerbUnchanged, err
+	}
+	if len(patch) == 0 || string(patch) == "{}" {
+		return cur, kutil.VerbUnchanged, nil
+	}
+	glog.V(3).Infof("Patching TimePeriod %s/%s with %s.", cur.Namespace, cur.Name, string(patch))
+	out, err := c.TimePeriods().Patch(cur.Name, types.MergePatchType, patch)
+	return out, kutil.VerbPatched, err
+}
+
+func TryUpdateTimePeriod(c cs.MonitoringV1alpha1Interface, meta metav1.ObjectMeta, transform func(*api.TimePeriod) *api.TimePeriod) (result *api.TimePeriod, err error) {
+	attempt := 0
+	err = wait.PollImmediate(kutil.RetryInterval, kutil.RetryTimeout, func() (bool, error) {
+		attempt++
+		cur, e2 := c.TimePeriods().Get(meta.Name, metav1.GetOptions{})
+		if kerr.IsNotFound(e2) {
+			return false, e2
+		} else if e2 == nil {
+			result, e2 = c.TimePeriods().Update(transform(cur.DeepCopy()))
+			return e2 == nil, nil
+		}
+		glog.Errorf("Attempt %d failed to update TimePeriod %s/%s due to %v.", attempt, cur.Namespace, cur.Name, e2)
+		return false, nil
+	})
+
+	if err != nil {
+		err = fmt.Errorf("failed to update TimePeriod %s/%s after %d attempts due to %v", meta.Namespace, meta.Name, attempt, err)
+	}
+	return
+}
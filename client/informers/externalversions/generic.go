@@ -53,16 +53,28 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=monitoring.appscode.com, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("alerttemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Monitoring().V1alpha1().AlertTemplates().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("clusteralerts"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Monitoring().V1alpha1().ClusterAlerts().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("globalalerts"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Monitoring().V1alpha1().GlobalAlerts().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("incidents"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Monitoring().V1alpha1().Incidents().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("nodealerts"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Monitoring().V1alpha1().NodeAlerts().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("notifierconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Monitoring().V1alpha1().NotifierConfigs().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("podalerts"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Monitoring().V1alpha1().PodAlerts().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("searchlightplugins"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Monitoring().V1alpha1().SearchlightPlugins().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("silences"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Monitoring().V1alpha1().Silences().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("timeperiods"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Monitoring().V1alpha1().TimePeriods().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("workloadalerts"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Monitoring().V1alpha1().WorkloadAlerts().Informer()}, nil
 
 	}
 
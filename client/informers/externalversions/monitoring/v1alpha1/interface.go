@@ -24,16 +24,28 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// AlertTemplates returns a AlertTemplateInformer.
+	AlertTemplates() AlertTemplateInformer
 	// ClusterAlerts returns a ClusterAlertInformer.
 	ClusterAlerts() ClusterAlertInformer
+	// GlobalAlerts returns a GlobalAlertInformer.
+	GlobalAlerts() GlobalAlertInformer
 	// Incidents returns a IncidentInformer.
 	Incidents() IncidentInformer
 	// NodeAlerts returns a NodeAlertInformer.
 	NodeAlerts() NodeAlertInformer
+	// NotifierConfigs returns a NotifierConfigInformer.
+	NotifierConfigs() NotifierConfigInformer
 	// PodAlerts returns a PodAlertInformer.
 	PodAlerts() PodAlertInformer
 	// SearchlightPlugins returns a SearchlightPluginInformer.
 	SearchlightPlugins() SearchlightPluginInformer
+	// Silences returns a SilenceInformer.
+	Silences() SilenceInformer
+	// TimePeriods returns a TimePeriodInformer.
+	TimePeriods() TimePeriodInformer
+	// WorkloadAlerts returns a WorkloadAlertInformer.
+	WorkloadAlerts() WorkloadAlertInformer
 }
 
 type version struct {
@@ -47,11 +59,21 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// AlertTemplates returns a AlertTemplateInformer.
+func (v *version) AlertTemplates() AlertTemplateInformer {
+	return &alertTemplateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterAlerts returns a ClusterAlertInformer.
 func (v *version) ClusterAlerts() ClusterAlertInformer {
 	return &clusterAlertInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// GlobalAlerts returns a GlobalAlertInformer.
+func (v *version) GlobalAlerts() GlobalAlertInformer {
+	return &globalAlertInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Incidents returns a IncidentInformer.
 func (v *version) Incidents() IncidentInformer {
 	return &incidentInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -62,6 +84,11 @@ func (v *version) NodeAlerts() NodeAlertInformer {
 	return &nodeAlertInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// NotifierConfigs returns a NotifierConfigInformer.
+func (v *version) NotifierConfigs() NotifierConfigInformer {
+	return &notifierConfigInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // PodAlerts returns a PodAlertInformer.
 func (v *version) PodAlerts() PodAlertInformer {
 	return &podAlertInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -71,3 +98,18 @@ func (v *version) PodAlerts() PodAlertInformer {
 func (v *version) SearchlightPlugins() SearchlightPluginInformer {
 	return &searchlightPluginInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// Silences returns a SilenceInformer.
+func (v *version) Silences() SilenceInformer {
+	return &silenceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// TimePeriods returns a TimePeriodInformer.
+func (v *version) TimePeriods() TimePeriodInformer {
+	return &timePeriodInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// WorkloadAlerts returns a WorkloadAlertInformer.
+func (v *version) WorkloadAlerts() WorkloadAlertInformer {
+	return &workloadAlertInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
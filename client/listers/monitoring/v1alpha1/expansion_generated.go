@@ -18,6 +18,14 @@ limitations under the License.
 
 package v1alpha1
 
+// AlertTemplateListerExpansion allows custom methods to be added to
+// AlertTemplateLister.
+type AlertTemplateListerExpansion interface{}
+
+// AlertTemplateNamespaceListerExpansion allows custom methods to be added to
+// AlertTemplateNamespaceLister.
+type AlertTemplateNamespaceListerExpansion interface{}
+
 // ClusterAlertListerExpansion allows custom methods to be added to
 // ClusterAlertLister.
 type ClusterAlertListerExpansion interface{}
@@ -26,6 +34,10 @@ type ClusterAlertListerExpansion interface{}
 // ClusterAlertNamespaceLister.
 type ClusterAlertNamespaceListerExpansion interface{}
 
+// GlobalAlertListerExpansion allows custom methods to be added to
+// GlobalAlertLister.
+type GlobalAlertListerExpansion interface{}
+
 // IncidentListerExpansion allows custom methods to be added to
 // IncidentLister.
 type IncidentListerExpansion interface{}
@@ -42,6 +54,10 @@ type NodeAlertListerExpansion interface{}
 // NodeAlertNamespaceLister.
 type NodeAlertNamespaceListerExpansion interface{}
 
+// NotifierConfigListerExpansion allows custom methods to be added to
+// NotifierConfigLister.
+type NotifierConfigListerExpansion interface{}
+
 // PodAlertListerExpansion allows custom methods to be added to
 // PodAlertLister.
 type PodAlertListerExpansion interface{}
@@ -53,3 +69,23 @@ type PodAlertNamespaceListerExpansion interface{}
 // SearchlightPluginListerExpansion allows custom methods to be added to
 // SearchlightPluginLister.
 type SearchlightPluginListerExpansion interface{}
+
+// SilenceListerExpansion allows custom methods to be added to
+// SilenceLister.
+type SilenceListerExpansion interface{}
+
+// SilenceNamespaceListerExpansion allows custom methods to be added to
+// SilenceNamespaceLister.
+type SilenceNamespaceListerExpansion interface{}
+
+// TimePeriodListerExpansion allows custom methods to be added to
+// TimePeriodLister.
+type TimePeriodListerExpansion interface{}
+
+// WorkloadAlertListerExpansion allows custom methods to be added to
+// WorkloadAlertLister.
+type WorkloadAlertListerExpansion interface{}
+
+// WorkloadAlertNamespaceListerExpansion allows custom methods to be added to
+// WorkloadAlertNamespaceLister.
+type WorkloadAlertNamespaceListerExpansion interface{}
@@ -1,7 +1,7 @@
 #!/bin/bash
 set -eou pipefail
 
-crds=(clusteralerts nodealerts podalerts incidents searchlightplugins)
+crds=(clusteralerts nodealerts podalerts workloadalerts globalalerts silences timeperiods alerttemplates notifierconfigs incidents searchlightplugins)
 apiversions=(v1alpha1.admission v1alpha1.incidents)
 
 echo "checking kubeconfig context"
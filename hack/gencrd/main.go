@@ -36,6 +36,12 @@ func generateCRDDefinitions() {
 		slitev1alpha1.ClusterAlert{}.CustomResourceDefinition(),
 		slitev1alpha1.NodeAlert{}.CustomResourceDefinition(),
 		slitev1alpha1.PodAlert{}.CustomResourceDefinition(),
+		slitev1alpha1.WorkloadAlert{}.CustomResourceDefinition(),
+		slitev1alpha1.GlobalAlert{}.CustomResourceDefinition(),
+		slitev1alpha1.Silence{}.CustomResourceDefinition(),
+		slitev1alpha1.TimePeriod{}.CustomResourceDefinition(),
+		slitev1alpha1.AlertTemplate{}.CustomResourceDefinition(),
+		slitev1alpha1.NotifierConfig{}.CustomResourceDefinition(),
 		slitev1alpha1.Incident{}.CustomResourceDefinition(),
 		slitev1alpha1.SearchlightPlugin{}.CustomResourceDefinition(),
 	}
@@ -83,6 +89,12 @@ func generateSwaggerJson() {
 			{slitev1alpha1.SchemeGroupVersion, slitev1alpha1.ResourcePluralClusterAlert, slitev1alpha1.ResourceKindClusterAlert, true},
 			{slitev1alpha1.SchemeGroupVersion, slitev1alpha1.ResourcePluralNodeAlert, slitev1alpha1.ResourceKindNodeAlert, true},
 			{slitev1alpha1.SchemeGroupVersion, slitev1alpha1.ResourcePluralPodAlert, slitev1alpha1.ResourceKindPodAlert, true},
+			{slitev1alpha1.SchemeGroupVersion, slitev1alpha1.ResourcePluralWorkloadAlert, slitev1alpha1.ResourceKindWorkloadAlert, true},
+			{slitev1alpha1.SchemeGroupVersion, slitev1alpha1.ResourcePluralGlobalAlert, slitev1alpha1.ResourceKindGlobalAlert, false},
+			{slitev1alpha1.SchemeGroupVersion, slitev1alpha1.ResourcePluralSilence, slitev1alpha1.ResourceKindSilence, true},
+			{slitev1alpha1.SchemeGroupVersion, slitev1alpha1.ResourcePluralTimePeriod, slitev1alpha1.ResourceKindTimePeriod, false},
+			{slitev1alpha1.SchemeGroupVersion, slitev1alpha1.ResourcePluralAlertTemplate, slitev1alpha1.ResourceKindAlertTemplate, true},
+			{slitev1alpha1.SchemeGroupVersion, slitev1alpha1.ResourcePluralNotifierConfig, slitev1alpha1.ResourceKindNotifierConfig, false},
 			{slitev1alpha1.SchemeGroupVersion, slitev1alpha1.ResourcePluralIncident, slitev1alpha1.ResourceKindIncident, true},
 			{slitev1alpha1.SchemeGroupVersion, slitev1alpha1.ResourcePluralSearchlightPlugin, slitev1alpha1.ResourceKindSearchlightPlugin, true},
 		},
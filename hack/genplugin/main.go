@@ -29,6 +29,8 @@ func main() {
 		plugin.GetPodStatusPlugin(),
 		plugin.GetPodVolumePlugin(),
 		plugin.GetPodExecPlugin(),
+		plugin.GetWorkloadStatusPlugin(),
+		plugin.GetWorkloadRestartsPlugin(),
 	}
 
 	f, err := os.OpenFile(filepath.Join(pluginFolder, "plugins.yaml"), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
@@ -2,10 +2,14 @@ package plugin
 
 import (
 	"encoding/json"
+	"fmt"
 	"sync"
 
 	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	cs "github.com/appscode/searchlight/client/clientset/versioned"
+	"github.com/pkg/errors"
 	admission "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
@@ -15,6 +19,7 @@ import (
 
 type CRDValidator struct {
 	client      kubernetes.Interface
+	extClient   cs.Interface
 	lock        sync.RWMutex
 	initialized bool
 }
@@ -38,12 +43,15 @@ func (a *CRDValidator) Initialize(config *rest.Config, stopCh <-chan struct{}) e
 	if a.client, err = kubernetes.NewForConfig(config); err != nil {
 		return err
 	}
+	if a.extClient, err = cs.NewForConfig(config); err != nil {
+		return err
+	}
 	return err
 }
 
 func (a *CRDValidator) Admit(req *admission.AdmissionRequest) *admission.AdmissionResponse {
 	status := &admission.AdmissionResponse{}
-	supportedKinds := sets.NewString(api.ResourceKindClusterAlert, api.ResourceKindNodeAlert, api.ResourceKindPodAlert)
+	supportedKinds := sets.NewString(api.ResourceKindClusterAlert, api.ResourceKindNodeAlert, api.ResourceKindPodAlert, api.ResourceKindWorkloadAlert, api.ResourceKindGlobalAlert, api.ResourceKindSilence, api.ResourceKindTimePeriod, api.ResourceKindAlertTemplate, api.ResourceKindNotifierConfig, api.ResourceKindSearchlightPlugin)
 
 	if (req.Operation != admission.Create && req.Operation != admission.Update) ||
 		len(req.SubResource) != 0 ||
@@ -59,20 +67,113 @@ func (a *CRDValidator) Admit(req *admission.AdmissionRequest) *admission.Admissi
 		return hooks.StatusUninitialized()
 	}
 
-	var alert api.Alert
-	switch req.Kind.Kind {
-	case api.ResourceKindClusterAlert:
-		alert = &api.ClusterAlert{}
-	case api.ResourceKindNodeAlert:
-		alert = &api.NodeAlert{}
-	case api.ResourceKindPodAlert:
-		alert = &api.PodAlert{}
+	if req.Kind.Kind == api.ResourceKindSilence {
+		silence := &api.Silence{}
+		if err := json.Unmarshal(req.Object.Raw, silence); err != nil {
+			return hooks.StatusBadRequest(err)
+		}
+		if err := silence.IsValid(); err != nil {
+			return hooks.StatusForbidden(err)
+		}
+		status.Allowed = true
+		return status
+	}
+
+	if req.Kind.Kind == api.ResourceKindTimePeriod {
+		tp := &api.TimePeriod{}
+		if err := json.Unmarshal(req.Object.Raw, tp); err != nil {
+			return hooks.StatusBadRequest(err)
+		}
+		if err := tp.IsValid(); err != nil {
+			return hooks.StatusForbidden(err)
+		}
+		status.Allowed = true
+		return status
+	}
+
+	if req.Kind.Kind == api.ResourceKindAlertTemplate {
+		t := &api.AlertTemplate{}
+		if err := json.Unmarshal(req.Object.Raw, t); err != nil {
+			return hooks.StatusBadRequest(err)
+		}
+		if err := t.IsValid(); err != nil {
+			return hooks.StatusForbidden(err)
+		}
+		old := &api.AlertTemplate{}
+		if req.Operation == admission.Update {
+			if err := json.Unmarshal(req.OldObject.Raw, old); err != nil {
+				return hooks.StatusBadRequest(err)
+			}
+		}
+		if err := a.checkReceivers(req.UserInfo, req.Namespace, t.Spec.Receivers, old.Spec.Receivers); err != nil {
+			return hooks.StatusForbidden(err)
+		}
+		if err := a.checkVarsFrom(req.UserInfo, req.Namespace, t.Spec.VarsFrom, old.Spec.VarsFrom); err != nil {
+			return hooks.StatusForbidden(err)
+		}
+		status.Allowed = true
+		return status
+	}
+
+	if req.Kind.Kind == api.ResourceKindNotifierConfig {
+		cfg := &api.NotifierConfig{}
+		if err := json.Unmarshal(req.Object.Raw, cfg); err != nil {
+			return hooks.StatusBadRequest(err)
+		}
+		if err := cfg.IsValid(a.client); err != nil {
+			return hooks.StatusForbidden(err)
+		}
+		if err := a.checkSecretAccess(req.UserInfo, cfg); err != nil {
+			return hooks.StatusForbidden(err)
+		}
+		status.Allowed = true
+		return status
+	}
+
+	if req.Kind.Kind == api.ResourceKindSearchlightPlugin {
+		p := &api.SearchlightPlugin{}
+		if err := json.Unmarshal(req.Object.Raw, p); err != nil {
+			return hooks.StatusBadRequest(err)
+		}
+		if err := p.IsValid(); err != nil {
+			return hooks.StatusForbidden(err)
+		}
+		status.Allowed = true
+		return status
 	}
 
+	alert := newAlert(req.Kind.Kind)
 	err := json.Unmarshal(req.Object.Raw, alert)
 	if err != nil {
 		return hooks.StatusBadRequest(err)
 	}
+	if req.Kind.Kind == api.ResourceKindPodAlert && api.IsAnnotationAlert(alert.GetName()) {
+		return hooks.StatusForbidden(fmt.Errorf("name prefix %s is reserved for alerts declared in pod annotations", api.AnnotationAlertPrefix))
+	}
+	var oldReceivers []api.Receiver
+	var oldVarsFrom map[string]api.VarSource
+	if req.Operation == admission.Update {
+		old := newAlert(req.Kind.Kind)
+		if err := json.Unmarshal(req.OldObject.Raw, old); err != nil {
+			return hooks.StatusBadRequest(err)
+		}
+		oldReceivers = old.GetReceivers()
+		oldVarsFrom = api.GetVarsFrom(old)
+	}
+	// receivers and varsFrom inherited from an AlertTemplate were checked when the template was written
+	if err := a.checkReceivers(req.UserInfo, req.Namespace, alert.GetReceivers(), oldReceivers); err != nil {
+		return hooks.StatusForbidden(err)
+	}
+	if err := a.checkVarsFrom(req.UserInfo, req.Namespace, api.GetVarsFrom(alert), oldVarsFrom); err != nil {
+		return hooks.StatusForbidden(err)
+	}
+	if name := api.GetTemplateRef(alert); name != "" {
+		t, err := a.extClient.MonitoringV1alpha1().AlertTemplates(req.Namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return hooks.StatusForbidden(errors.Wrapf(err, "failed to get AlertTemplate %s", name))
+		}
+		alert = api.WithTemplate(alert, t)
+	}
 	err = alert.IsValid(a.client)
 	if err != nil {
 		return hooks.StatusForbidden(err)
@@ -81,3 +182,19 @@ func (a *CRDValidator) Admit(req *admission.AdmissionRequest) *admission.Admissi
 	status.Allowed = true
 	return status
 }
+
+func newAlert(kind string) api.Alert {
+	switch kind {
+	case api.ResourceKindClusterAlert:
+		return &api.ClusterAlert{}
+	case api.ResourceKindNodeAlert:
+		return &api.NodeAlert{}
+	case api.ResourceKindPodAlert:
+		return &api.PodAlert{}
+	case api.ResourceKindWorkloadAlert:
+		return &api.WorkloadAlert{}
+	case api.ResourceKindGlobalAlert:
+		return &api.GlobalAlert{}
+	}
+	return nil
+}
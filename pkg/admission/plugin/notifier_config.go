@@ -0,0 +1,80 @@
+package plugin
+
+import (
+	"fmt"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	authentication "k8s.io/api/authentication/v1"
+	authorization "k8s.io/api/authorization/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// checkReceivers checks that the requesting user may use the NotifierConfigs newly referred to by receivers
+// in the given namespace, and that their notifiers match. References already present in the old object are
+// not checked again, so that other users can still update it.
+func (a *CRDValidator) checkReceivers(user authentication.UserInfo, namespace string, receivers, oldReceivers []api.Receiver) error {
+	existing := sets.NewString()
+	for _, r := range oldReceivers {
+		existing.Insert(r.NotifierConfig)
+	}
+	for _, r := range receivers {
+		if r.NotifierConfig == "" || existing.Has(r.NotifierConfig) {
+			continue
+		}
+		cfg, err := a.extClient.MonitoringV1alpha1().NotifierConfigs().Get(r.NotifierConfig, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if r.Notifier != "" && r.Notifier != cfg.Spec.Notifier {
+			return fmt.Errorf("notifier %s of receiver does not match notifier %s of NotifierConfig %s", r.Notifier, cfg.Spec.Notifier, cfg.Name)
+		}
+		err = a.checkAccess(user, &authorization.ResourceAttributes{
+			Namespace: namespace,
+			Verb:      api.VerbUseNotifierConfig,
+			Group:     api.SchemeGroupVersion.Group,
+			Resource:  api.ResourcePluralNotifierConfig,
+			Name:      cfg.Name,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSecretAccess checks that the requesting user may read the secret of a NotifierConfig,
+// so that NotifierConfigs can't be used to expose credentials of other namespaces.
+func (a *CRDValidator) checkSecretAccess(user authentication.UserInfo, cfg *api.NotifierConfig) error {
+	return a.checkAccess(user, &authorization.ResourceAttributes{
+		Namespace: cfg.Spec.SecretRef.Namespace,
+		Verb:      "get",
+		Group:     core.GroupName,
+		Resource:  "secrets",
+		Name:      cfg.Spec.SecretRef.Name,
+	})
+}
+
+func (a *CRDValidator) checkAccess(user authentication.UserInfo, attrs *authorization.ResourceAttributes) error {
+	extra := make(map[string]authorization.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorization.ExtraValue(v)
+	}
+	review, err := a.client.AuthorizationV1().SubjectAccessReviews().Create(&authorization.SubjectAccessReview{
+		Spec: authorization.SubjectAccessReviewSpec{
+			User:               user.Username,
+			Groups:             user.Groups,
+			UID:                user.UID,
+			Extra:              extra,
+			ResourceAttributes: attrs,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if !review.Status.Allowed {
+		return fmt.Errorf("user %s is not allowed to %s %s %s in namespace %q", user.Username, attrs.Verb, attrs.Resource, attrs.Name, attrs.Namespace)
+	}
+	return nil
+}
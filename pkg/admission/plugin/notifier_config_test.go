@@ -0,0 +1,198 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	mon_fake "github.com/appscode/searchlight/client/clientset/versioned/fake"
+	admission "k8s.io/api/admission/v1beta1"
+	authentication "k8s.io/api/authentication/v1"
+	authorization "k8s.io/api/authorization/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+// newTestValidator returns a CRDValidator whose SubjectAccessReviews allow the given accesses,
+// formatted as "verb resource namespace/name". The reviews made are appended to reviews.
+func newTestValidator(allowed []string, reviews *[]string) *CRDValidator {
+	client := fake.NewSimpleClientset(
+		&core.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "slack", Namespace: "monitoring"},
+			Data:       map[string][]byte{"SLACK_AUTH_TOKEN": []byte("token")},
+		},
+		&core.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "notifier", Namespace: "demo"},
+			Data:       map[string][]byte{"SLACK_AUTH_TOKEN": []byte("token")},
+		},
+	)
+	client.PrependReactor("create", "subjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		review := action.(clienttesting.CreateAction).GetObject().(*authorization.SubjectAccessReview)
+		attrs := review.Spec.ResourceAttributes
+		access := fmt.Sprintf("%s %s %s/%s", attrs.Verb, attrs.Resource, attrs.Namespace, attrs.Name)
+		*reviews = append(*reviews, review.Spec.User+": "+access)
+		for _, a := range allowed {
+			if a == access {
+				review.Status.Allowed = true
+			}
+		}
+		return true, review, nil
+	})
+	extClient := mon_fake.NewSimpleClientset(&api.NotifierConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-slack"},
+		Spec: api.NotifierConfigSpec{
+			Notifier:  "Slack",
+			SecretRef: api.NotifierSecretReference{Namespace: "monitoring", Name: "slack"},
+		},
+	})
+	return &CRDValidator{client: client, extClient: extClient, initialized: true}
+}
+
+func admissionRequest(t *testing.T, kind string, obj, old runtime.Object) *admission.AdmissionRequest {
+	req := &admission.AdmissionRequest{
+		Kind:      metav1.GroupVersionKind{Group: api.SchemeGroupVersion.Group, Version: api.SchemeGroupVersion.Version, Kind: kind},
+		Namespace: "demo",
+		Operation: admission.Create,
+		UserInfo:  authentication.UserInfo{Username: "jane"},
+	}
+	var err error
+	if req.Object.Raw, err = json.Marshal(obj); err != nil {
+		t.Fatal(err)
+	}
+	if old != nil {
+		req.Operation = admission.Update
+		if req.OldObject.Raw, err = json.Marshal(old); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return req
+}
+
+func TestAdmitNotifierConfigReceivers(t *testing.T) {
+	api.PodCommands.Insert("pod-notify", api.IcingaCommand{Name: "pod-notify", States: []string{"OK", "Critical"}})
+	podName := "web-0"
+	newAlert := func(receivers ...api.Receiver) *api.PodAlert {
+		return &api.PodAlert{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "demo"},
+			Spec:       api.PodAlertSpec{PodName: &podName, Check: "pod-notify", NotifierSecretName: "notifier", Receivers: receivers},
+		}
+	}
+	useTeamSlack := "use notifierconfigs demo/team-slack"
+
+	cases := []struct {
+		name     string
+		kind     string
+		obj      runtime.Object
+		old      runtime.Object
+		allowed  []string
+		reviews  []string
+		expected bool
+	}{
+		{
+			name:     "receiver without NotifierConfig",
+			kind:     api.ResourceKindPodAlert,
+			obj:      newAlert(api.Receiver{State: "Critical", To: []string{"ops"}, Notifier: "Slack"}),
+			expected: true,
+		},
+		{
+			name:     "allowed to use NotifierConfig",
+			kind:     api.ResourceKindPodAlert,
+			obj:      newAlert(api.Receiver{State: "Critical", To: []string{"ops"}, NotifierConfig: "team-slack"}),
+			allowed:  []string{useTeamSlack},
+			reviews:  []string{"jane: " + useTeamSlack},
+			expected: true,
+		},
+		{
+			name:    "not allowed to use NotifierConfig",
+			kind:    api.ResourceKindPodAlert,
+			obj:     newAlert(api.Receiver{State: "Critical", To: []string{"ops"}, NotifierConfig: "team-slack"}),
+			reviews: []string{"jane: " + useTeamSlack},
+		},
+		{
+			name:    "notifier of receiver does not match NotifierConfig",
+			kind:    api.ResourceKindPodAlert,
+			obj:     newAlert(api.Receiver{State: "Critical", To: []string{"ops"}, Notifier: "Mailgun", NotifierConfig: "team-slack"}),
+			allowed: []string{useTeamSlack},
+		},
+		{
+			name:    "missing NotifierConfig",
+			kind:    api.ResourceKindPodAlert,
+			obj:     newAlert(api.Receiver{State: "Critical", To: []string{"ops"}, NotifierConfig: "other-slack"}),
+			allowed: []string{"use notifierconfigs demo/other-slack"},
+		},
+		{
+			// other users can still update alerts referring to a NotifierConfig they can't use
+			name:     "NotifierConfig referred to before update",
+			kind:     api.ResourceKindPodAlert,
+			obj:      newAlert(api.Receiver{State: "Critical", To: []string{"ops", "dev"}, NotifierConfig: "team-slack"}),
+			old:      newAlert(api.Receiver{State: "OK", To: []string{"ops"}, NotifierConfig: "team-slack"}),
+			expected: true,
+		},
+		{
+			name: "NotifierConfig added by update",
+			kind: api.ResourceKindPodAlert,
+			obj: newAlert(
+				api.Receiver{State: "Critical", To: []string{"ops"}, Notifier: "Slack"},
+				api.Receiver{State: "Critical", To: []string{"dev"}, NotifierConfig: "team-slack"},
+			),
+			old:     newAlert(api.Receiver{State: "Critical", To: []string{"ops"}, Notifier: "Slack"}),
+			reviews: []string{"jane: " + useTeamSlack},
+		},
+		{
+			name: "not allowed to use NotifierConfig in AlertTemplate",
+			kind: api.ResourceKindAlertTemplate,
+			obj: &api.AlertTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "demo"},
+				Spec:       api.AlertTemplateSpec{Receivers: []api.Receiver{{State: "Critical", To: []string{"ops"}, NotifierConfig: "team-slack"}}},
+			},
+			reviews: []string{"jane: " + useTeamSlack},
+		},
+		{
+			name: "allowed to read secret of NotifierConfig",
+			kind: api.ResourceKindNotifierConfig,
+			obj: &api.NotifierConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-slack"},
+				Spec:       api.NotifierConfigSpec{Notifier: "Slack", SecretRef: api.NotifierSecretReference{Namespace: "monitoring", Name: "slack"}},
+			},
+			allowed:  []string{"get secrets monitoring/slack"},
+			reviews:  []string{"jane: get secrets monitoring/slack"},
+			expected: true,
+		},
+		{
+			name: "not allowed to read secret of NotifierConfig",
+			kind: api.ResourceKindNotifierConfig,
+			obj: &api.NotifierConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-slack"},
+				Spec:       api.NotifierConfigSpec{Notifier: "Slack", SecretRef: api.NotifierSecretReference{Namespace: "monitoring", Name: "slack"}},
+			},
+			reviews: []string{"jane: get secrets monitoring/slack"},
+		},
+		{
+			name: "secret without credentials of notifier",
+			kind: api.ResourceKindNotifierConfig,
+			obj: &api.NotifierConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-mailgun"},
+				Spec:       api.NotifierConfigSpec{Notifier: "Mailgun", SecretRef: api.NotifierSecretReference{Namespace: "monitoring", Name: "slack"}},
+			},
+			allowed: []string{"get secrets monitoring/slack"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var reviews []string
+			a := newTestValidator(c.allowed, &reviews)
+			resp := a.Admit(admissionRequest(t, c.kind, c.obj, c.old))
+			if resp.Allowed != c.expected {
+				t.Errorf("expected allowed %v, got %+v", c.expected, resp.Result)
+			}
+			if strings.Join(reviews, ",") != strings.Join(c.reviews, ",") {
+				t.Errorf("expected access reviews %v, got %v", c.reviews, reviews)
+			}
+		})
+	}
+}
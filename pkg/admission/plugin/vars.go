@@ -0,0 +1,32 @@
+package plugin
+
+import (
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	authentication "k8s.io/api/authentication/v1"
+	authorization "k8s.io/api/authorization/v1"
+	core "k8s.io/api/core/v1"
+)
+
+// checkVarsFrom checks that the requesting user may read the secrets newly referred to by varsFrom in the
+// given namespace, so that alerts can't be used to forward secrets the user can't read to a check command.
+func (a *CRDValidator) checkVarsFrom(user authentication.UserInfo, namespace string, varsFrom, oldVarsFrom map[string]api.VarSource) error {
+	for k, src := range varsFrom {
+		if src.SecretKeyRef == nil {
+			continue
+		}
+		if old, found := oldVarsFrom[k]; found && old.SecretKeyRef != nil && *old.SecretKeyRef == *src.SecretKeyRef {
+			continue
+		}
+		err := a.checkAccess(user, &authorization.ResourceAttributes{
+			Namespace: namespace,
+			Verb:      "get",
+			Group:     core.GroupName,
+			Resource:  "secrets",
+			Name:      src.SecretKeyRef.Name,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
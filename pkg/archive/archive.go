@@ -0,0 +1,38 @@
+// Package archive stores documents in a local directory or in a bucket of an S3 compatible object
+// store, such as AWS S3 or MinIO.
+package archive
+
+import (
+	"net/url"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// Sink stores documents by key.
+type Sink interface {
+	// Put stores data under a key, a slash separated relative path, replacing an existing document.
+	Put(key string, data []byte) error
+}
+
+// New returns the Sink for a location, which is either a local directory, e.g. /var/lib/searchlight
+// or file:///var/lib/searchlight, or a bucket with an optional prefix, e.g.
+// s3://incidents/searchlight?endpoint=minio.storage.svc:9000&region=us-east-1&insecure=true.
+// Credentials of buckets are read from the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment
+// variables.
+func New(location string) (Sink, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid archive location %s", location)
+	}
+	switch u.Scheme {
+	case "", "file":
+		if u.Path == "" {
+			return nil, errors.Errorf("missing directory in archive location %s", location)
+		}
+		return &dirSink{dir: u.Path}, nil
+	case "s3":
+		return newS3Sink(u, os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"))
+	}
+	return nil, errors.Errorf("unsupported scheme %s in archive location %s", u.Scheme, location)
+}
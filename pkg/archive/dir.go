@@ -0,0 +1,25 @@
+package archive
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// dirSink stores documents as files in a local directory.
+type dirSink struct {
+	dir string
+}
+
+func (s *dirSink) Put(key string, data []byte) error {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	// written to a temporary file first, so that a document is never archived partially
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
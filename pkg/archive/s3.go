@@ -0,0 +1,196 @@
+package archive
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultS3Endpoint = "s3.amazonaws.com"
+	defaultS3Region   = "us-east-1"
+)
+
+// s3Sink uploads documents to a bucket of an S3 compatible object store, using path style URLs
+// and AWS Signature Version 4.
+type s3Sink struct {
+	scheme    string
+	endpoint  string
+	region    string
+	bucket    string
+	prefix    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+}
+
+func newS3Sink(u *url.URL, accessKey, secretKey string) (*s3Sink, error) {
+	if u.Host == "" {
+		return nil, errors.Errorf("missing bucket in archive location %s", u)
+	}
+	if accessKey == "" || secretKey == "" {
+		return nil, errors.New("missing AWS_ACCESS_KEY_ID or AWS_SECRET_ACCESS_KEY for archive bucket")
+	}
+	q := u.Query()
+	s := &s3Sink{
+		scheme:    "https",
+		endpoint:  q.Get("endpoint"),
+		region:    q.Get("region"),
+		bucket:    u.Host,
+		prefix:    strings.Trim(u.Path, "/"),
+		accessKey: accessKey,
+		secretKey: secretKey,
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}
+	if s.endpoint == "" {
+		s.endpoint = defaultS3Endpoint
+	}
+	if s.region == "" {
+		s.region = defaultS3Region
+	}
+	if v := q.Get("insecure"); v != "" {
+		insecure, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value %s of insecure in archive location", v)
+		}
+		if insecure {
+			s.scheme = "http"
+		}
+	}
+	return s, nil
+}
+
+func (s *s3Sink) Put(key string, data []byte) error {
+	objectPath := "/" + s.bucket + "/" + path.Join(s.prefix, key)
+	u := &url.URL{Scheme: s.scheme, Host: s.endpoint, Path: objectPath, RawPath: uriEncode(objectPath, false)}
+	req, err := http.NewRequest(http.MethodPut, u.String(), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	s.sign(req, data, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to upload %s", objectPath)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("failed to upload %s: %s: %s", objectPath, resp.Status, body)
+	}
+	return nil
+}
+
+// sign signs a request with AWS Signature Version 4.
+// ref: https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func (s *s3Sink) sign(req *http.Request, payload []byte, t time.Time) {
+	amzDate := t.Format("20060102T150405Z")
+	date := t.Format("20060102")
+	payloadHash := hexSHA256(payload)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	canonicalRequest, signedHeaders := canonicalRequest(req, payloadHash)
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	signature := hex.EncodeToString(hmacSHA256(signingKey(s.secretKey, date, s.region), stringToSign(amzDate, scope, canonicalRequest)))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature,
+	))
+}
+
+// canonicalRequest returns the canonical request of a request and the names of its signed headers,
+// which are all headers except Authorization.
+func canonicalRequest(req *http.Request, payloadHash string) (string, string) {
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		if strings.EqualFold(k, "Authorization") {
+			continue
+		}
+		values := make([]string, len(v))
+		for i := range v {
+			values[i] = strings.Join(strings.Fields(v[i]), " ")
+		}
+		headers[strings.ToLower(k)] = strings.Join(values, ",")
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+
+	query := req.URL.Query()
+	params := make([]string, 0, len(query))
+	for k, v := range query {
+		for _, val := range v {
+			params = append(params, uriEncode(k, true)+"="+uriEncode(val, true))
+		}
+	}
+	sort.Strings(params)
+
+	signedHeaders := strings.Join(names, ";")
+	return strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		strings.Join(params, "&"),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n"), signedHeaders
+}
+
+func stringToSign(amzDate, scope, canonicalRequest string) string {
+	return "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hexSHA256([]byte(canonicalRequest))
+}
+
+func signingKey(secretKey, date, region string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	return hmacSHA256(key, "aws4_request")
+}
+
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// uriEncode encodes a path or query parameter as required by AWS Signature Version 4, escaping all
+// bytes except unreserved characters, and slashes unless encodeSlash is set.
+func uriEncode(p string, encodeSlash bool) string {
+	var buf strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || (c == '/' && !encodeSlash) {
+			buf.WriteByte(c)
+		} else {
+			fmt.Fprintf(&buf, "%%%02X", c)
+		}
+	}
+	return buf.String()
+}
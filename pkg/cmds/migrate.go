@@ -0,0 +1,59 @@
+package cmds
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdMigrate(out io.Writer) *cobra.Command {
+	var (
+		kubeconfigPath string
+		contextName    string
+		dryRun         bool
+		output         = "yaml"
+	)
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate alerts, SearchlightPlugins and Incidents to the current schema",
+		Long: `Apply the migrations of alerts, SearchlightPlugins and Incidents which are not recorded as applied yet, in order.
+Applied migrations are recorded in the searchlight-migrations ConfigMap in the operator namespace. The operator applies
+pending migrations on start, so this is only needed to preview them with --dry-run, or to retry failed ones.`,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "yaml" && output != "json" {
+				return errors.Errorf("unknown output format %s", output)
+			}
+			cfg, err := newOperatorConfig(kubeconfigPath, contextName)
+			if err != nil {
+				return err
+			}
+
+			results, migrateErr := cfg.NewPlanner().Migrate(dryRun)
+			var data []byte
+			if output == "json" {
+				data, err = json.MarshalIndent(results, "", "  ")
+			} else {
+				data, err = yaml.Marshal(results)
+			}
+			if err != nil {
+				return err
+			}
+			if _, err = fmt.Fprintln(out, string(data)); err != nil {
+				return err
+			}
+			return migrateErr
+		},
+	}
+
+	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", kubeconfigPath, "Path to kubeconfig file with authorization information. If empty, the in-cluster config is used.")
+	cmd.Flags().StringVar(&contextName, "context", contextName, "Name of the kubeconfig context to use")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", dryRun, "If true, only reports the objects pending migrations would change")
+	cmd.Flags().StringVarP(&output, "output", "o", output, "Output format, one of yaml or json")
+
+	return cmd
+}
@@ -0,0 +1,147 @@
+package cmds
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/appscode/go/log"
+	cs "github.com/appscode/searchlight/client/clientset/versioned"
+	"github.com/appscode/searchlight/pkg/icinga"
+	"github.com/appscode/searchlight/pkg/operator"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	core "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"kmodules.xyz/client-go/tools/clientcmd"
+)
+
+type planOptions struct {
+	kubeconfigPath string
+	contextName    string
+	namespace      string
+	configRoot     string
+	filenames      []string
+	offline        bool
+	output         string
+}
+
+func NewCmdPlan(out io.Writer, stopCh <-chan struct{}) *cobra.Command {
+	o := &planOptions{
+		namespace:  core.NamespaceDefault,
+		configRoot: "/srv",
+		output:     "yaml",
+	}
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Show the Icinga objects and CheckCommands the operator would create, update or delete",
+		Long: `Show the Icinga hosts, services, notifications and CheckCommands the operator would create, update or delete,
+without changing Icinga or any Kubernetes object. Alerts, AlertTemplates and SearchlightPlugins in the given manifests
+are planned as if they were applied.`,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if o.output != "yaml" && o.output != "json" {
+				return errors.Errorf("unknown output format %s", o.output)
+			}
+			return o.run(out, stopCh)
+		},
+	}
+
+	cmd.Flags().StringVar(&o.kubeconfigPath, "kubeconfig", o.kubeconfigPath, "Path to kubeconfig file with authorization information. If empty, the in-cluster config is used.")
+	cmd.Flags().StringVar(&o.contextName, "context", o.contextName, "Name of the kubeconfig context to use")
+	cmd.Flags().StringVarP(&o.namespace, "namespace", "n", o.namespace, "Namespace of objects in manifests which don't set one")
+	cmd.Flags().StringVarP(&o.configRoot, "config-dir", "s", o.configRoot, "Path to directory containing icinga2 config.")
+	cmd.Flags().StringSliceVarP(&o.filenames, "filename", "f", o.filenames, "Manifests of alerts, AlertTemplates and SearchlightPlugins to plan as if they were applied")
+	cmd.Flags().BoolVar(&o.offline, "offline", o.offline, "If true, plans against an empty Icinga instead of connecting to the Icinga API")
+	cmd.Flags().StringVarP(&o.output, "output", "o", o.output, "Output format, one of yaml or json")
+
+	return cmd
+}
+
+func (o *planOptions) run(out io.Writer, stopCh <-chan struct{}) error {
+	cfg, err := newOperatorConfig(o.kubeconfigPath, o.contextName)
+	if err != nil {
+		return err
+	}
+	cfg.ConfigRoot = o.configRoot
+	if !o.offline {
+		if cfg.IcingaClient, err = o.icingaClient(); err != nil {
+			return err
+		}
+	}
+
+	op := cfg.NewPlanner()
+	if err := op.RunInformers(stopCh); err != nil {
+		return err
+	}
+	for _, filename := range o.filenames {
+		f, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+		err = op.AddManifests(f, o.namespace)
+		f.Close()
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", filename)
+		}
+	}
+
+	plan, err := op.Plan()
+	if err != nil {
+		return err
+	}
+	var data []byte
+	if o.output == "json" {
+		data, err = json.MarshalIndent(plan, "", "  ")
+	} else {
+		data, err = yaml.Marshal(plan)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
+
+// newOperatorConfig returns the config of an operator run from the command line, without an Icinga client.
+func newOperatorConfig(kubeconfigPath, contextName string) (*operator.OperatorConfig, error) {
+	clientConfig, err := clientcmd.BuildConfigFromContext(kubeconfigPath, contextName)
+	if err != nil {
+		return nil, err
+	}
+	cfg := operator.NewOperatorConfig(clientConfig)
+	cfg.ResyncPeriod = 10 * time.Minute
+	if cfg.KubeClient, err = kubernetes.NewForConfig(clientConfig); err != nil {
+		return nil, err
+	}
+	if cfg.ExtClient, err = cs.NewForConfig(clientConfig); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// icingaClient connects to the Icinga API using an existing Icinga config, which is never generated here.
+func (o *planOptions) icingaClient() (*icinga.Client, error) {
+	mgr := &icinga.Configurator{ConfigRoot: o.configRoot}
+	if _, err := os.Stat(mgr.ConfigFile()); err != nil {
+		return nil, errors.Wrapf(err, "failed to read Icinga config, use --offline to plan without Icinga")
+	}
+
+	// user input is only read while generating a missing config
+	data, err := mgr.LoadConfig(func(key string) (string, bool) {
+		return "", false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c := icinga.NewClient(*data)
+	if c.Check().Get(nil).Do().Status != 200 {
+		return nil, errors.New("failed to connect to the Icinga API, use --offline to plan without Icinga")
+	}
+	log.Infoln("connected to icinga api")
+	return c, nil
+}
@@ -38,6 +38,8 @@ func NewCmdSearchlight() *cobra.Command {
 	stopCh := genericapiserver.SetupSignalHandler()
 	rootCmd.AddCommand(NewCmdRun(os.Stdout, os.Stderr, stopCh))
 	rootCmd.AddCommand(NewCmdConfigure())
+	rootCmd.AddCommand(NewCmdPlan(os.Stdout, stopCh))
+	rootCmd.AddCommand(NewCmdMigrate(os.Stdout))
 	rootCmd.AddCommand(v.NewCmdVersion())
 
 	return rootCmd
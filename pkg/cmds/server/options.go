@@ -20,25 +20,39 @@ import (
 )
 
 type OperatorOptions struct {
-	ConfigRoot       string
-	ConfigSecretName string
-	ResyncPeriod     time.Duration
-	MaxNumRequeues   int
-	NumThreads       int
-	IncidentTTL      time.Duration
+	ConfigRoot           string
+	ConfigSecretName     string
+	ResyncPeriod         time.Duration
+	MaxNumRequeues       int
+	NumThreads           int
+	IncidentTTL          time.Duration
+	IncidentStaleTTL     time.Duration
+	IncidentMaxPerAlert  int
+	IncidentGCPeriod     time.Duration
+	IncidentArchive      string
+	DriftReconcilePeriod time.Duration
+	// Leader election
+	LeaderElect                 bool
+	LeaderElectionLeaseDuration time.Duration
+	LeaderElectionRenewDeadline time.Duration
+	LeaderElectionRetryPeriod   time.Duration
 	// V logging level, the value of the -v flag
 	verbosity string
 }
 
 func NewOperatorOptions() *OperatorOptions {
 	return &OperatorOptions{
-		ConfigRoot:       "/srv",
-		ConfigSecretName: "searchlight-operator",
-		ResyncPeriod:     5 * time.Minute,
-		MaxNumRequeues:   5,
-		NumThreads:       1,
-		IncidentTTL:      90 * 24 * time.Hour,
-		verbosity:        "3",
+		ConfigRoot:                  "/srv",
+		ConfigSecretName:            "searchlight-operator",
+		ResyncPeriod:                5 * time.Minute,
+		MaxNumRequeues:              5,
+		NumThreads:                  1,
+		IncidentTTL:                 90 * 24 * time.Hour,
+		IncidentGCPeriod:            time.Hour,
+		LeaderElectionLeaseDuration: 15 * time.Second,
+		LeaderElectionRenewDeadline: 10 * time.Second,
+		LeaderElectionRetryPeriod:   2 * time.Second,
+		verbosity:                   "3",
 	}
 }
 
@@ -46,7 +60,17 @@ func (s *OperatorOptions) AddGoFlags(fs *flag.FlagSet) {
 	fs.StringVar(&s.ConfigRoot, "config-dir", s.ConfigRoot, "Path to directory containing icinga2 config. This should be an emptyDir inside Kubernetes.")
 	fs.StringVar(&s.ConfigSecretName, "config-secret-name", s.ConfigSecretName, "Name of Kubernetes secret used to pass icinga credentials.")
 	fs.DurationVar(&s.ResyncPeriod, "resync-period", s.ResyncPeriod, "If non-zero, will re-list this often. Otherwise, re-list will be delayed aslong as possible (until the upstream source closes the watch or times out.")
-	fs.DurationVar(&s.IncidentTTL, "incident-ttl", s.IncidentTTL, "Garbage collects incidents older than this duration. Set to 0 to disable garbage collection.")
+	fs.DurationVar(&s.IncidentTTL, "incident-ttl", s.IncidentTTL, "Garbage collects recovered incidents without notifications for this duration. Set to 0 to keep recovered incidents. Namespaces may override it with the monitoring.appscode.com/incident-ttl annotation.")
+	fs.DurationVar(&s.IncidentStaleTTL, "incident-stale-ttl", s.IncidentStaleTTL, "Garbage collects unrecovered incidents without notifications for this duration. Set to 0 to keep unrecovered incidents. Namespaces may override it with the monitoring.appscode.com/incident-stale-ttl annotation.")
+	fs.IntVar(&s.IncidentMaxPerAlert, "incident-max-per-alert", s.IncidentMaxPerAlert, "Maximum number of incidents kept for each alert and target, older recovered incidents are garbage collected. Set to 0 for no limit. Namespaces may override it with the monitoring.appscode.com/incident-max-per-alert annotation.")
+	fs.DurationVar(&s.IncidentGCPeriod, "incident-gc-period", s.IncidentGCPeriod, "How frequently expired incidents are garbage collected. Set to 0 to disable garbage collection.")
+	fs.StringVar(&s.IncidentArchive, "incident-archive", s.IncidentArchive, "Directory or S3 compatible bucket, e.g. s3://bucket/prefix?endpoint=minio:9000&insecure=true, garbage collected incidents are archived to as JSON. Bucket credentials are read from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY. If empty, incidents are not archived.")
+
+	fs.DurationVar(&s.DriftReconcilePeriod, "drift-reconcile-period", s.DriftReconcilePeriod, "How frequently Icinga hosts, services and notifications are compared with alerts, to recreate missing and remove orphaned ones. Set to 0 to disable drift reconciliation.")
+	fs.BoolVar(&s.LeaderElect, "leader-elect", s.LeaderElect, "If true, replicas elect a leader using a Lease in the operator namespace. Only the leader reconciles alerts and writes Icinga config. Required to run more than one replica.")
+	fs.DurationVar(&s.LeaderElectionLeaseDuration, "leader-elect-lease-duration", s.LeaderElectionLeaseDuration, "Duration non-leader replicas wait after the last renewal of the leader Lease before taking over.")
+	fs.DurationVar(&s.LeaderElectionRenewDeadline, "leader-elect-renew-deadline", s.LeaderElectionRenewDeadline, "Duration the leader retries renewing the leader Lease before it exits.")
+	fs.DurationVar(&s.LeaderElectionRetryPeriod, "leader-elect-retry-period", s.LeaderElectionRetryPeriod, "Duration replicas wait between attempts to acquire or renew the leader Lease.")
 
 	fs.BoolVar(&api.EnableStatusSubresource, "enable-status-subresource", api.EnableStatusSubresource, "If true, uses sub resource for Voyager crds.")
 }
@@ -57,6 +81,21 @@ func (s *OperatorOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.AddGoFlagSet(pfs)
 }
 
+func (s *OperatorOptions) Validate() error {
+	if s.IncidentTTL < 0 || s.IncidentStaleTTL < 0 || s.IncidentMaxPerAlert < 0 {
+		return errors.New("incident-ttl, incident-stale-ttl and incident-max-per-alert must not be negative")
+	}
+	if s.LeaderElect {
+		if s.LeaderElectionRenewDeadline >= s.LeaderElectionLeaseDuration {
+			return errors.New("leader-elect-renew-deadline must be less than leader-elect-lease-duration")
+		}
+		if s.LeaderElectionRetryPeriod <= 0 || s.LeaderElectionRetryPeriod >= s.LeaderElectionRenewDeadline {
+			return errors.New("leader-elect-retry-period must be positive and less than leader-elect-renew-deadline")
+		}
+	}
+	return nil
+}
+
 func (s *OperatorOptions) ApplyTo(cfg *operator.OperatorConfig) error {
 	var err error
 
@@ -66,6 +105,15 @@ func (s *OperatorOptions) ApplyTo(cfg *operator.OperatorConfig) error {
 	cfg.MaxNumRequeues = s.MaxNumRequeues
 	cfg.NumThreads = s.NumThreads
 	cfg.IncidentTTL = s.IncidentTTL
+	cfg.IncidentStaleTTL = s.IncidentStaleTTL
+	cfg.IncidentMaxPerAlert = s.IncidentMaxPerAlert
+	cfg.IncidentGCPeriod = s.IncidentGCPeriod
+	cfg.IncidentArchive = s.IncidentArchive
+	cfg.DriftReconcilePeriod = s.DriftReconcilePeriod
+	cfg.LeaderElect = s.LeaderElect
+	cfg.LeaderElectionLeaseDuration = s.LeaderElectionLeaseDuration
+	cfg.LeaderElectionRenewDeadline = s.LeaderElectionRenewDeadline
+	cfg.LeaderElectionRetryPeriod = s.LeaderElectionRetryPeriod
 	cfg.Verbosity = s.verbosity
 
 	if cfg.KubeClient, err = kubernetes.NewForConfig(cfg.ClientConfig); err != nil {
@@ -55,7 +55,7 @@ func (o SearchlightOptions) AddFlags(fs *pflag.FlagSet) {
 }
 
 func (o SearchlightOptions) Validate(args []string) error {
-	return nil
+	return o.OperatorOptions.Validate()
 }
 
 func (o *SearchlightOptions) Complete(cmd *cobra.Command) error {
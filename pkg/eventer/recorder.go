@@ -27,6 +27,16 @@ const (
 	EventReasonSync           = "Sync"
 	EventReasonFailedToSync   = "FailedToSync"
 	EventReasonSuccessfulSync = "SuccessfulSync"
+	EventReasonDriftCorrected = "DriftCorrected"
+
+	// Migration event list
+	EventReasonFailedToMigrate = "FailedToMigrate"
+
+	// Icinga downtime event list
+	EventReasonRolloutSuppressed = "RolloutSuppressed"
+
+	// SearchlightPlugin event list
+	EventReasonAlertPaused = "AlertPaused"
 )
 
 func NewEventRecorder(client kubernetes.Interface, component string) record.EventRecorder {
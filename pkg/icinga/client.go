@@ -29,6 +29,8 @@ type APIRequest struct {
 	userName string
 	password string
 	verb     string
+	// type of the Icinga objects requested, used as label of metrics
+	object string
 
 	Err  error
 	req  *http.Request
@@ -69,6 +71,30 @@ func (c *Client) Notifications(hostName string) *APIRequest {
 	return c.newRequest("/objects/notifications/" + hostName)
 }
 
+func (c *Client) Dependencies(hostName string) *APIRequest {
+	return c.newRequest("/objects/dependencies/" + hostName)
+}
+
+func (c *Client) TimePeriods(name string) *APIRequest {
+	return c.newRequest("/objects/timeperiods/" + name)
+}
+
+func (c *Client) Downtimes(name string) *APIRequest {
+	return c.newRequest("/objects/downtimes/" + name)
+}
+
+func (c *Client) ConfigPackages(name string) *APIRequest {
+	return c.newRequest("/config/packages/" + name)
+}
+
+func (c *Client) ConfigStages(pkg, stage string) *APIRequest {
+	return c.newRequest("/config/stages/" + pkg + "/" + stage)
+}
+
+func (c *Client) ConfigFiles(pkg, stage, path string) *APIRequest {
+	return c.newRequest("/config/files/" + pkg + "/" + stage + "/" + path)
+}
+
 func (c *Client) Actions(action string) *APIRequest {
 	return c.newRequest("/actions/" + action)
 }
@@ -85,6 +111,7 @@ func addUri(uri string, name []string) string {
 }
 
 func (ic *APIRequest) Get(name []string, jsonBody ...string) *APIRequest {
+	ic.verb = "get"
 	if len(jsonBody) == 0 {
 		ic.req, ic.Err = ic.newRequest("GET", addUri(ic.uri, name), nil)
 	} else if len(jsonBody) == 1 {
@@ -96,20 +123,31 @@ func (ic *APIRequest) Get(name []string, jsonBody ...string) *APIRequest {
 }
 
 func (ic *APIRequest) Create(name []string, jsonBody string) *APIRequest {
+	ic.verb = "create"
 	ic.req, ic.Err = ic.newRequest("PUT", addUri(ic.uri, name), bytes.NewBuffer([]byte(jsonBody)))
 	return ic
 }
 
 func (ic *APIRequest) Update(name []string, jsonBody string) *APIRequest {
+	ic.verb = "update"
 	ic.req, ic.Err = ic.newRequest("POST", addUri(ic.uri, name), bytes.NewBuffer([]byte(jsonBody)))
 	return ic
 }
 
 func (ic *APIRequest) Delete(name []string, jsonBody string) *APIRequest {
+	ic.verb = "delete"
 	ic.req, ic.Err = ic.newRequest("DELETE", addUri(ic.uri, name), bytes.NewBuffer([]byte(jsonBody)))
 	return ic
 }
 
+// Accept sets the media type accepted as response, application/json by default.
+func (ic *APIRequest) Accept(mediaType string) *APIRequest {
+	if ic.req != nil {
+		ic.req.Header.Set("Accept", mediaType)
+	}
+	return ic
+}
+
 func (ic *APIRequest) Params(param map[string]string) *APIRequest {
 	p := ic.req.URL.Query()
 	for k, v := range param {
@@ -17,7 +17,7 @@ func NewClusterHost(IcingaClient *Client, verbosity string) *ClusterHost {
 	}
 }
 
-func (h *ClusterHost) getHost(namespace string) IcingaHost {
+func (h *ClusterHost) GetHost(namespace string) IcingaHost {
 	return IcingaHost{
 		Type:           TypeCluster,
 		AlertNamespace: namespace,
@@ -27,7 +27,7 @@ func (h *ClusterHost) getHost(namespace string) IcingaHost {
 
 func (h *ClusterHost) Apply(alert *api.ClusterAlert) error {
 	alertSpec := alert.Spec
-	kh := h.getHost(alert.Namespace)
+	kh := h.GetHost(alert.Namespace)
 
 	if err := h.reconcileIcingaHost(kh); err != nil {
 		return err
@@ -47,16 +47,9 @@ func (h *ClusterHost) Apply(alert *api.ClusterAlert) error {
 		return nil
 	}
 
-	attrs := make(map[string]interface{})
-	if alertSpec.CheckInterval.Seconds() > 0 {
-		attrs["check_interval"] = alertSpec.CheckInterval.Seconds()
-	}
-	cmd, _ := api.ClusterCommands.Get(alertSpec.Check)
-	commandVars := cmd.Vars.Fields
-	for key, val := range alertSpec.Vars {
-		if _, found := commandVars[key]; found {
-			attrs[IVar(key)] = val
-		}
+	attrs, err := h.ServiceAttrs(alert)
+	if err != nil {
+		return err
 	}
 
 	if !has {
@@ -73,8 +66,21 @@ func (h *ClusterHost) Apply(alert *api.ClusterAlert) error {
 	return h.reconcileIcingaNotification(alert, kh)
 }
 
+// ServiceAttrs returns the attributes of the Icinga Service of a ClusterAlert, except its check command.
+func (h *ClusterHost) ServiceAttrs(alert *api.ClusterAlert) (map[string]interface{}, error) {
+	alertSpec := alert.Spec
+	attrs := make(map[string]interface{})
+	if alertSpec.CheckInterval.Seconds() > 0 {
+		attrs["check_interval"] = alertSpec.CheckInterval.Seconds()
+	}
+	setCheckAttrs(attrs, alert)
+	cmd, _ := api.ClusterCommands.Get(alertSpec.Check)
+	setVars(attrs, cmd, alertSpec.Vars, alert.Namespace, alertSpec.VarsFrom)
+	return attrs, nil
+}
+
 func (h *ClusterHost) Delete(namespace, name string) error {
-	kh := h.getHost(namespace)
+	kh := h.GetHost(namespace)
 	if err := h.deleteIcingaService(name, kh); err != nil {
 		return err
 	}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
 	"github.com/pkg/errors"
@@ -69,7 +70,10 @@ func (h *commonHost) deleteIcingaHost(kh IcingaHost) error {
 		return errors.WithStack(err)
 	}
 
-	in := fmt.Sprintf(`{"filter": "match(\"%s\",host.name)"}`, host)
+	in, err := filterQuery("match(pattern,host.name)", map[string]string{"pattern": host})
+	if err != nil {
+		return err
+	}
 	var respService ResponseObject
 	if _, err := h.IcingaClient.Service("").Update([]string{}, in).Do().Into(&respService); err != nil {
 		return errors.Wrap(err, "can't get Icinga service")
@@ -93,7 +97,10 @@ func (h *commonHost) ForceDeleteIcingaHost(kh IcingaHost) error {
 		return errors.WithStack(err)
 	}
 
-	in := fmt.Sprintf(`{"filter": "match(\"%s\",host.name)"}`, host)
+	in, err := filterQuery("match(pattern,host.name)", map[string]string{"pattern": host})
+	if err != nil {
+		return err
+	}
 	resp := h.IcingaClient.Hosts("").Delete([]string{}, in).Params(param).Do()
 	if resp.Err != nil {
 		return errors.Wrap(resp.Err, "Failed to delete IcingaHost")
@@ -104,6 +111,66 @@ func (h *commonHost) ForceDeleteIcingaHost(kh IcingaHost) error {
 	return errors.New("can't delete Icinga host")
 }
 
+// Defaults of the check settings of Icinga Services, which are set on the Services of alerts without the
+// setting, so that removing a setting from an alert resets it in Icinga. The defaults of max_check_attempts
+// and retry_interval are the values of the template generic-service.
+const (
+	defaultMaxCheckAttempts      = 5
+	defaultRetryInterval         = 30 * time.Second
+	defaultFlappingThresholdLow  = 25
+	defaultFlappingThresholdHigh = 30
+)
+
+// setCheckAttrs sets the Icinga Service attributes which control soft states, retries,
+// timeout and flap detection of the check of an alert. Without checkTimeout, check_timeout is
+// unset, so that the timeout of the CheckCommand applies.
+func setCheckAttrs(attrs map[string]interface{}, alert api.Alert) {
+	attrs["max_check_attempts"] = int32(defaultMaxCheckAttempts)
+	if alert.GetMaxCheckAttempts() > 0 {
+		attrs["max_check_attempts"] = alert.GetMaxCheckAttempts()
+	}
+	attrs["retry_interval"] = defaultRetryInterval.Seconds()
+	if alert.GetRetryInterval() > 0 {
+		attrs["retry_interval"] = alert.GetRetryInterval().Seconds()
+	}
+	attrs["check_timeout"] = nil
+	if alert.GetCheckTimeout() > 0 {
+		attrs["check_timeout"] = alert.GetCheckTimeout().Seconds()
+	}
+	enabled, low, high := alert.GetFlapping()
+	attrs["enable_flapping"] = enabled
+	attrs["flapping_threshold_low"] = int32(defaultFlappingThresholdLow)
+	if low > 0 {
+		attrs["flapping_threshold_low"] = low
+	}
+	attrs["flapping_threshold_high"] = int32(defaultFlappingThresholdHigh)
+	if high > 0 {
+		attrs["flapping_threshold_high"] = high
+	}
+}
+
+// setVars sets the vars of an alert which are declared by its check command, with vars read from
+// secrets in the namespace of the alert. Check commands without a vars schema get all vars, as alert
+// validation accepts any var for them.
+func setVars(attrs map[string]interface{}, cmd api.IcingaCommand, vars map[string]string, namespace string, varsFrom map[string]api.VarSource) {
+	if cmd.Vars == nil {
+		for key, val := range vars {
+			attrs[IVar(key)] = val
+		}
+		return
+	}
+	for key, val := range vars {
+		if _, found := cmd.Vars.Fields[key]; found {
+			attrs[IVar(key)] = val
+		}
+	}
+	for key, val := range api.SecretVarRefs(namespace, varsFrom) {
+		if _, found := cmd.Vars.Fields[key]; found {
+			attrs[IVar(key)] = val
+		}
+	}
+}
+
 // createIcingaServiceForCluster
 func (h *commonHost) createIcingaService(svc string, kh IcingaHost, attrs map[string]interface{}) error {
 	obj := IcingaObject{
@@ -159,7 +226,10 @@ func (h *commonHost) deleteIcingaService(svc string, kh IcingaHost) error {
 	param := map[string]string{
 		"cascade": "1",
 	}
-	in := h.IcingaServiceSearchQuery(svc, kh)
+	in, err := h.IcingaServiceSearchQuery(svc, kh)
+	if err != nil {
+		return err
+	}
 
 	resp := h.IcingaClient.Service("").Delete([]string{}, in).Params(param).Do()
 	if resp.Err != nil {
@@ -176,7 +246,10 @@ func (h *commonHost) deleteIcingaServiceForCheckCommand(name string) error {
 	param := map[string]string{
 		"cascade": "1",
 	}
-	in := fmt.Sprintf(`{"filter": "match(\"%s\",service.check_command)"}`, name)
+	in, err := filterQuery("match(pattern,service.check_command)", map[string]string{"pattern": name})
+	if err != nil {
+		return err
+	}
 
 	resp := h.IcingaClient.Service("").Delete([]string{}, in).Params(param).Do()
 	if resp.Err != nil {
@@ -190,7 +263,10 @@ func (h *commonHost) deleteIcingaServiceForCheckCommand(name string) error {
 }
 
 func (h *commonHost) checkIcingaService(svc string, kh IcingaHost) (bool, error) {
-	in := h.IcingaServiceSearchQuery(svc, kh)
+	in, err := h.IcingaServiceSearchQuery(svc, kh)
+	if err != nil {
+		return true, err
+	}
 	var respService ResponseObject
 
 	if _, err := h.IcingaClient.Service("").Get([]string{}, in).Do().Into(&respService); err != nil {
@@ -199,26 +275,64 @@ func (h *commonHost) checkIcingaService(svc string, kh IcingaHost) (bool, error)
 	return len(respService.Results) > 0, nil
 }
 
-func (h *commonHost) IcingaServiceSearchQuery(svc string, kids ...IcingaHost) string {
-	matchHost := ""
-	for i, kh := range kids {
-		if i > 0 {
-			matchHost = matchHost + "||"
+// ServiceState is the last known state of an Icinga service.
+type ServiceState struct {
+	State     State
+	LastCheck time.Time
+}
+
+// GetServiceStates returns the state of service svc for each of the given hosts, keyed by host name.
+// Hosts that don't have the service are omitted.
+func (h *commonHost) GetServiceStates(svc string, kids ...IcingaHost) (map[string]ServiceState, error) {
+	states := make(map[string]ServiceState)
+	if len(kids) == 0 {
+		return states, nil
+	}
+
+	in, err := h.IcingaServiceSearchQuery(svc, kids...)
+	if err != nil {
+		return nil, err
+	}
+	var respService ResponseObject
+	if _, err := h.IcingaClient.Service("").Get([]string{}, in).Do().Into(&respService); err != nil {
+		return nil, errors.Wrap(err, "can't get icinga service")
+	}
+	for _, r := range respService.Results {
+		st := ServiceState{State: State(r.Attrs.State)}
+		if r.Attrs.LastCheck > 0 {
+			sec := int64(r.Attrs.LastCheck)
+			st.LastCheck = time.Unix(sec, int64((r.Attrs.LastCheck-float64(sec))*1e9))
 		}
+		states[r.Attrs.HostName] = st
+	}
+	return states, nil
+}
+
+// IcingaServiceSearchQuery returns the body of a request for the Icinga services named svc of the given hosts.
+func (h *commonHost) IcingaServiceSearchQuery(svc string, kids ...IcingaHost) (string, error) {
+	vars := map[string]string{"svc": svc}
+	matchHost := make([]string, 0, len(kids))
+	for i, kh := range kids {
 		host, _ := kh.Name()
+		key := fmt.Sprintf("host%d", i)
+		vars[key] = host
+		matchHost = append(matchHost, fmt.Sprintf("match(%s,host.name)", key))
+	}
+	return filterQuery("("+strings.Join(matchHost, "||")+")&&match(svc,service.name)", vars)
+}
 
-		matchHost = matchHost + fmt.Sprintf(`match(\"%s\",host.name)`, host)
+// NotificationAttrs returns the attributes of the Icinga Notification of an alert.
+func NotificationAttrs(alert api.Alert) map[string]interface{} {
+	return map[string]interface{}{
+		"interval": int(alert.GetAlertInterval().Seconds()),
+		"users":    []string{"searchlight_user"},
 	}
-	return fmt.Sprintf(`{"filter": "(%s)&&match(\"%s\",service.name)"}`, matchHost, svc)
 }
 
 func (h *commonHost) reconcileIcingaNotification(alert api.Alert, kh IcingaHost) error {
 	obj := IcingaObject{
 		Templates: []string{"icinga2-notifier-template"},
-		Attrs: map[string]interface{}{
-			"interval": int(alert.GetAlertInterval().Seconds()),
-			"users":    []string{"searchlight_user"},
-		},
+		Attrs:     NotificationAttrs(alert),
 	}
 	jsonStr, err := json.Marshal(obj)
 	if err != nil {
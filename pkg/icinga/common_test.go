@@ -0,0 +1,58 @@
+package icinga
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetCheckAttrs(t *testing.T) {
+	cases := []struct {
+		name     string
+		spec     api.PodAlertSpec
+		expected map[string]interface{}
+	}{
+		{
+			name: "defaults",
+			expected: map[string]interface{}{
+				"max_check_attempts":      int32(5),
+				"retry_interval":          float64(30),
+				"check_timeout":           nil,
+				"enable_flapping":         false,
+				"flapping_threshold_low":  int32(25),
+				"flapping_threshold_high": int32(30),
+			},
+		},
+		{
+			name: "set",
+			spec: api.PodAlertSpec{
+				MaxCheckAttempts:      3,
+				RetryInterval:         metav1.Duration{Duration: time.Minute},
+				CheckTimeout:          metav1.Duration{Duration: 10 * time.Second},
+				EnableFlapping:        true,
+				FlappingThresholdLow:  10,
+				FlappingThresholdHigh: 50,
+			},
+			expected: map[string]interface{}{
+				"max_check_attempts":      int32(3),
+				"retry_interval":          float64(60),
+				"check_timeout":           float64(10),
+				"enable_flapping":         true,
+				"flapping_threshold_low":  int32(10),
+				"flapping_threshold_high": int32(50),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			attrs := map[string]interface{}{}
+			setCheckAttrs(attrs, &api.PodAlert{Spec: c.spec})
+			if !reflect.DeepEqual(attrs, c.expected) {
+				t.Errorf("expected attrs %v, got %v", c.expected, attrs)
+			}
+		})
+	}
+}
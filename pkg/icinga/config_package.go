@@ -0,0 +1,191 @@
+package icinga
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ConfigPackageManager deploys configuration files through an Icinga config package. Every deployment
+// uploads all files as a new stage, which Icinga validates and, if valid, activates with a reload, so that
+// the objects defined by the files change without restarting Icinga.
+type ConfigPackageManager struct {
+	IcingaClient *Client
+	Package      string
+	// Timeout is how long Deploy waits for Icinga to validate a stage
+	Timeout time.Duration
+}
+
+func NewConfigPackageManager(IcingaClient *Client, pkg string) *ConfigPackageManager {
+	return &ConfigPackageManager{IcingaClient: IcingaClient, Package: pkg, Timeout: time.Minute}
+}
+
+// ValidationError is returned by Deploy if Icinga rejected a stage.
+type ValidationError struct {
+	Stage string
+	// Log is the output of the config validation of the stage
+	Log string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("Icinga config validation of stage %s failed: %s", e.Stage, e.Errors())
+}
+
+// Errors returns the errors and their locations logged by the config validation.
+func (e *ValidationError) Errors() string {
+	var lines []string
+	for _, line := range strings.Split(e.Log, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.Contains(line, "critical/config") || strings.HasPrefix(line, "Location:") {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return strings.TrimSpace(e.Log)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Mentions reports whether the validation log refers to a file of the stage.
+func (e *ValidationError) Mentions(path string) bool {
+	return strings.Contains(e.Log, "/"+e.Stage+"/"+path)
+}
+
+type configPackage struct {
+	Name        string   `json:"name"`
+	ActiveStage string   `json:"active-stage"`
+	Stages      []string `json:"stages"`
+}
+
+// ActiveFiles returns the files in the conf.d folder of the active stage, keyed by their path relative to
+// the stage.
+func (m *ConfigPackageManager) ActiveFiles() (map[string]string, error) {
+	p, err := m.get()
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]string{}
+	if p == nil || p.ActiveStage == "" {
+		return files, nil
+	}
+
+	var resp struct {
+		Results []struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		} `json:"results"`
+	}
+	if _, err := m.IcingaClient.ConfigStages(m.Package, p.ActiveStage).Get([]string{}).Do().Into(&resp); err != nil {
+		return nil, errors.Wrapf(err, "can't list files of icinga config package %s", m.Package)
+	}
+	for _, r := range resp.Results {
+		if r.Type != "file" || !strings.HasPrefix(r.Name, "conf.d/") {
+			continue
+		}
+		if files[r.Name], err = m.file(p.ActiveStage, r.Name); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// Deploy uploads files, keyed by their path relative to the stage, as a new stage and waits until Icinga
+// validated it. Icinga includes the files in the conf.d folder of the active stage. A ValidationError is
+// returned if Icinga rejected the stage, which keeps the previous stage active.
+func (m *ConfigPackageManager) Deploy(files map[string]string) error {
+	p, err := m.ensure()
+	if err != nil {
+		return err
+	}
+	// Stages which are not active were rejected or replaced, removing them is best effort
+	for _, stage := range p.Stages {
+		if stage != p.ActiveStage {
+			m.IcingaClient.ConfigStages(m.Package, stage).Delete([]string{}, "").Do()
+		}
+	}
+
+	jsonStr, err := json.Marshal(map[string]interface{}{"files": files})
+	if err != nil {
+		return errors.Wrap(err, "Failed to Marshal config files")
+	}
+	var resp struct {
+		Results []struct {
+			Stage string `json:"stage"`
+		} `json:"results"`
+	}
+	status, err := m.IcingaClient.ConfigStages(m.Package, "").Update([]string{}, string(jsonStr)).Do().Into(&resp)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to create stage of Icinga config package %s", m.Package)
+	}
+	if status != 200 || len(resp.Results) == 0 {
+		return errors.Errorf("can't create stage of Icinga config package %s. Status: %d", m.Package, status)
+	}
+	stage := resp.Results[0].Stage
+
+	// The status file is written once validation finished. Requests fail while Icinga reloads.
+	var result string
+	for deadline := time.Now().Add(m.Timeout); ; {
+		if result, err = m.file(stage, "status"); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			return errors.Errorf("timed out waiting for validation of stage %s of Icinga config package %s", stage, m.Package)
+		}
+		time.Sleep(time.Second)
+	}
+	if strings.TrimSpace(result) == "0" {
+		return nil
+	}
+
+	log, err := m.file(stage, "startup.log")
+	if err != nil {
+		return errors.Wrapf(err, "Icinga rejected stage %s of config package %s", stage, m.Package)
+	}
+	m.IcingaClient.ConfigStages(m.Package, stage).Delete([]string{}, "").Do()
+	return &ValidationError{Stage: stage, Log: log}
+}
+
+func (m *ConfigPackageManager) get() (*configPackage, error) {
+	var resp struct {
+		Results []configPackage `json:"results"`
+	}
+	if _, err := m.IcingaClient.ConfigPackages("").Get([]string{}).Do().Into(&resp); err != nil {
+		return nil, errors.Wrap(err, "can't list icinga config packages")
+	}
+	for i := range resp.Results {
+		if resp.Results[i].Name == m.Package {
+			return &resp.Results[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *ConfigPackageManager) ensure() (*configPackage, error) {
+	p, err := m.get()
+	if err != nil || p != nil {
+		return p, err
+	}
+	resp := m.IcingaClient.ConfigPackages(m.Package).Update([]string{}, "").Do()
+	if resp.Err != nil {
+		return nil, errors.Wrapf(resp.Err, "Failed to create Icinga config package %s", m.Package)
+	}
+	if resp.Status != 200 {
+		return nil, errors.Errorf("can't create Icinga config package %s. Status: %d", m.Package, resp.Status)
+	}
+	return &configPackage{Name: m.Package}, nil
+}
+
+// file returns the content of a file of a stage.
+func (m *ConfigPackageManager) file(stage, path string) (string, error) {
+	resp := m.IcingaClient.ConfigFiles(m.Package, stage, path).Get([]string{}).Accept("application/octet-stream").Do()
+	if resp.Err != nil {
+		return "", errors.Wrapf(resp.Err, "Failed to read file %s of Icinga config package %s", path, m.Package)
+	}
+	if resp.Status != 200 {
+		return "", errors.Errorf("can't read file %s of Icinga config package %s. Status: %d", path, m.Package, resp.Status)
+	}
+	return string(resp.ResponseBody), nil
+}
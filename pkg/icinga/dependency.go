@@ -0,0 +1,88 @@
+package icinga
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// reconcileIcingaDependency makes service svc of host kh depend on service parentSvc of host parent,
+// so that its checks and notifications are suppressed while the parent service is failing. Dependencies
+// on other parents are removed. If parent is nil, all dependencies of the service are removed.
+func (h *commonHost) reconcileIcingaDependency(svc string, kh IcingaHost, parent *IcingaHost, parentSvc string) error {
+	host, err := kh.Name()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	var parentHost string
+	if parent != nil {
+		if parentHost, err = parent.Name(); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	in, err := filterQuery("dependency.child_host_name==hostName&&dependency.child_service_name==svc", map[string]string{
+		"hostName": host,
+		"svc":      svc,
+	})
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		Results []struct {
+			Attrs struct {
+				Name              string `json:"__name"`
+				ParentHostName    string `json:"parent_host_name"`
+				ParentServiceName string `json:"parent_service_name"`
+			} `json:"attrs"`
+		} `json:"results"`
+	}
+	if _, err := h.IcingaClient.Dependencies("").Get([]string{}, in).Do().Into(&resp); err != nil {
+		return errors.Wrap(err, "can't get Icinga dependencies")
+	}
+
+	found := false
+	for _, r := range resp.Results {
+		if parent != nil && r.Attrs.ParentHostName == parentHost && r.Attrs.ParentServiceName == parentSvc {
+			found = true
+			continue
+		}
+		del, err := filterQuery("dependency.__name==name", map[string]string{"name": r.Attrs.Name})
+		if err != nil {
+			return err
+		}
+		resp := h.IcingaClient.Dependencies("").Delete([]string{}, del).Do()
+		if resp.Err != nil {
+			return errors.Wrap(resp.Err, "Failed to delete Icinga Dependency")
+		}
+		if resp.Status != 200 && resp.Status != 404 {
+			return errors.Errorf("can't delete Icinga dependency. Status: %d", resp.Status)
+		}
+	}
+	if parent == nil || found {
+		return nil
+	}
+
+	obj := IcingaObject{
+		Attrs: map[string]interface{}{
+			"parent_host_name":      parentHost,
+			"parent_service_name":   parentSvc,
+			"child_host_name":       host,
+			"child_service_name":    svc,
+			"disable_checks":        true,
+			"disable_notifications": true,
+		},
+	}
+	jsonStr, err := json.Marshal(obj)
+	if err != nil {
+		return errors.Wrap(err, "Failed to Marshal IcingaObject")
+	}
+	cr := h.IcingaClient.Dependencies(host).Create([]string{svc, parentSvc}, string(jsonStr)).Do()
+	if cr.Err != nil {
+		return errors.Wrap(cr.Err, "Failed to create Icinga Dependency")
+	}
+	if cr.Status != 200 {
+		return errors.Errorf("can't create Icinga dependency. Status: %d", cr.Status)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package icinga
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const downtimeAuthor = "searchlight"
+
+// Downtime is an Icinga downtime of a service.
+type Downtime struct {
+	Name        string
+	HostName    string
+	ServiceName string
+	StartTime   time.Time
+	EndTime     time.Time
+}
+
+// DowntimeManager schedules and removes Icinga downtimes. Downtimes are grouped by a tag which
+// prefixes their comment, so that all downtimes of an owner can be found again.
+type DowntimeManager struct {
+	IcingaClient *Client
+}
+
+func NewDowntimeManager(IcingaClient *Client) *DowntimeManager {
+	return &DowntimeManager{IcingaClient: IcingaClient}
+}
+
+// ListServiceHosts returns the names of hosts matching the glob pattern which have the named service.
+func (m *DowntimeManager) ListServiceHosts(hostPattern, svc string) ([]string, error) {
+	in, err := filterQuery("match(pattern,host.name)&&service.name==svc", map[string]string{
+		"pattern": hostPattern,
+		"svc":     svc,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var respService ResponseObject
+	if _, err := m.IcingaClient.Service("").Get([]string{}, in).Do().Into(&respService); err != nil {
+		return nil, errors.Wrap(err, "can't get icinga service")
+	}
+
+	hosts := make([]string, 0, len(respService.Results))
+	for _, r := range respService.Results {
+		hosts = append(hosts, r.Attrs.HostName)
+	}
+	return hosts, nil
+}
+
+// List returns the downtimes with the given tag.
+func (m *DowntimeManager) List(tag string) ([]Downtime, error) {
+	in, err := filterQuery("downtime.author==author&&match(pattern,downtime.comment)", map[string]string{
+		"author":  downtimeAuthor,
+		"pattern": tag + "*",
+	})
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Results []struct {
+			Attrs struct {
+				HostName    string  `json:"host_name"`
+				ServiceName string  `json:"service_name"`
+				StartTime   float64 `json:"start_time"`
+				EndTime     float64 `json:"end_time"`
+			} `json:"attrs"`
+			Name string `json:"name"`
+		} `json:"results"`
+	}
+	if _, err := m.IcingaClient.Downtimes("").Get([]string{}, in).Do().Into(&resp); err != nil {
+		return nil, errors.Wrap(err, "can't get icinga downtimes")
+	}
+
+	downtimes := make([]Downtime, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		downtimes = append(downtimes, Downtime{
+			Name:        r.Name,
+			HostName:    r.Attrs.HostName,
+			ServiceName: r.Attrs.ServiceName,
+			StartTime:   time.Unix(int64(r.Attrs.StartTime), 0),
+			EndTime:     time.Unix(int64(r.Attrs.EndTime), 0),
+		})
+	}
+	return downtimes, nil
+}
+
+// Schedule schedules a fixed downtime with the given tag for the service of a host,
+// and returns the name of the downtime.
+func (m *DowntimeManager) Schedule(host, svc string, start, end time.Time, tag, comment string) (string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"type":        "Service",
+		"filter":      "host.name==hostName&&service.name==svc",
+		"filter_vars": map[string]string{"hostName": host, "svc": svc},
+		"start_time":  start.Unix(),
+		"end_time":    end.Unix(),
+		"fixed":       true,
+		"author":      downtimeAuthor,
+		"comment":     tag + " " + comment,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to Marshal Icinga downtime")
+	}
+
+	var resp struct {
+		Results []struct {
+			Code   float64 `json:"code"`
+			Name   string  `json:"name"`
+			Status string  `json:"status"`
+		} `json:"results"`
+	}
+	status, err := m.IcingaClient.Actions("schedule-downtime").Update([]string{}, string(body)).Do().Into(&resp)
+	if err != nil {
+		return "", errors.Wrap(err, "can't schedule icinga downtime")
+	}
+	if status != 200 || len(resp.Results) == 0 {
+		return "", errors.Errorf("Fail to schedule downtime for service %s of host %s. Status: %d", svc, host, status)
+	}
+	return resp.Results[0].Name, nil
+}
+
+// Remove removes the named downtime.
+func (m *DowntimeManager) Remove(name string) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"type":        "Downtime",
+		"filter":      "downtime.__name==name",
+		"filter_vars": map[string]string{"name": name},
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to Marshal Icinga downtime")
+	}
+
+	resp := m.IcingaClient.Actions("remove-downtime").Update([]string{}, string(body)).Do()
+	if resp.Err != nil {
+		return errors.Wrap(resp.Err, "can't remove icinga downtime")
+	}
+	if resp.Status == 200 || resp.Status == 404 {
+		return nil
+	}
+	return errors.Errorf("Fail to remove downtime %s. Status: %d", name, resp.Status)
+}
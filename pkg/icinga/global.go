@@ -0,0 +1,103 @@
+package icinga
+
+import (
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	core "k8s.io/api/core/v1"
+)
+
+// GlobalHost manages Icinga objects of GlobalAlerts. Each pod targeted by a GlobalAlert
+// gets an Icinga host named {pod namespace}@global@{pod name}.
+type GlobalHost struct {
+	commonHost
+}
+
+func NewGlobalHost(IcingaClient *Client, verbosity string) *GlobalHost {
+	return &GlobalHost{
+		commonHost: commonHost{
+			IcingaClient: IcingaClient,
+			verbosity:    verbosity,
+		},
+	}
+}
+
+func (h *GlobalHost) GetHost(pod *core.Pod) IcingaHost {
+	return IcingaHost{
+		ObjectName:     pod.Name,
+		Type:           TypeGlobal,
+		AlertNamespace: pod.Namespace,
+		IP:             pod.Status.PodIP,
+	}
+}
+
+func (h *GlobalHost) Apply(alert *api.GlobalAlert, pod *core.Pod) error {
+	alertSpec := alert.Spec
+	kh := h.GetHost(pod)
+
+	if err := h.reconcileIcingaHost(kh); err != nil {
+		return err
+	}
+
+	has, err := h.checkIcingaService(alert.Name, kh)
+	if err != nil {
+		return err
+	}
+
+	if alertSpec.Paused {
+		if has {
+			if err := h.deleteIcingaService(alert.Name, kh); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	attrs, err := h.ServiceAttrs(alert, pod)
+	if err != nil {
+		return err
+	}
+
+	if !has {
+		attrs["check_command"] = alertSpec.Check
+		if err := h.createIcingaService(alert.Name, kh, attrs); err != nil {
+			return err
+		}
+	} else {
+		if err := h.updateIcingaService(alert.Name, kh, attrs); err != nil {
+			return err
+		}
+	}
+
+	return h.reconcileIcingaNotification(alert, kh)
+}
+
+// ServiceAttrs returns the attributes of the Icinga Service of a GlobalAlert for a pod, except its check command.
+func (h *GlobalHost) ServiceAttrs(alert *api.GlobalAlert, pod *core.Pod) (map[string]interface{}, error) {
+	alertSpec := alert.Spec
+	attrs := make(map[string]interface{})
+	if alertSpec.CheckInterval.Seconds() > 0 {
+		attrs["check_interval"] = alertSpec.CheckInterval.Seconds()
+	}
+	setCheckAttrs(attrs, alert)
+
+	vars, err := renderVars(alertSpec.Vars, TemplateData{Pod: pod})
+	if err != nil {
+		return nil, err
+	}
+	// GlobalAlerts have no varsFrom, since they aren't bound to the namespace of any secret
+	cmd, _ := api.PodCommands.Get(alertSpec.Check)
+	setVars(attrs, cmd, vars, "", nil)
+	return attrs, nil
+}
+
+func (h *GlobalHost) Delete(alertName string, pod *core.Pod) error {
+	kh := h.GetHost(pod)
+
+	if err := h.deleteIcingaService(alertName, kh); err != nil {
+		return err
+	}
+	return h.deleteIcingaHost(kh)
+}
+
+func (h *GlobalHost) DeleteChecks(cmd string) error {
+	return h.deleteIcingaServiceForCheckCommand(cmd)
+}
@@ -0,0 +1,257 @@
+package icinga
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Service is an Icinga service as listed by Inventory.
+type Service struct {
+	HostName     string
+	Name         string
+	CheckCommand string
+	// Attrs are the attributes of the service set by Searchlight, with vars flattened as vars.{name}
+	Attrs map[string]interface{}
+}
+
+// Notification is an Icinga notification as listed by Inventory.
+type Notification struct {
+	// Name is the full name of the notification, formatted as {host}!{service}!{notification}
+	Name  string
+	Attrs map[string]interface{}
+}
+
+// serviceAttrs are the attributes of Icinga services set by Searchlight, besides vars.
+var serviceAttrs = []string{
+	"check_interval",
+	"max_check_attempts",
+	"retry_interval",
+	"check_timeout",
+	"enable_flapping",
+	"flapping_threshold_low",
+	"flapping_threshold_high",
+}
+
+// DiffAttrs returns the keys of the desired attributes whose value differs from the actual attributes
+// of an Icinga object, in sorted order. Actual attributes which aren't desired are ignored, as
+// updates of Icinga objects leave them alone as well.
+func DiffAttrs(actual, desired map[string]interface{}) []string {
+	var keys []string
+	for key, val := range desired {
+		if !reflect.DeepEqual(actual[key], normalizeAttr(val)) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// normalizeAttr converts the value of an attribute to the type it is decoded to from the Icinga API.
+func normalizeAttr(val interface{}) interface{} {
+	switch v := val.(type) {
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case []string:
+		out := make([]interface{}, 0, len(v))
+		for _, s := range v {
+			out = append(out, s)
+		}
+		return out
+	}
+	return val
+}
+
+// Inventory lists and removes Icinga objects regardless of the alert they belong to, so that
+// Icinga can be compared with the alerts known to Searchlight operator.
+type Inventory struct {
+	IcingaClient *Client
+}
+
+func NewInventory(IcingaClient *Client) *Inventory {
+	return &Inventory{IcingaClient: IcingaClient}
+}
+
+// Hosts returns the names of all Icinga hosts.
+func (m *Inventory) Hosts() ([]string, error) {
+	in := `{"attrs": ["name"]}`
+	var resp ResponseObject
+	if _, err := m.IcingaClient.Hosts("").Get([]string{}, in).Do().Into(&resp); err != nil {
+		return nil, errors.Wrap(err, "can't list icinga hosts")
+	}
+	hosts := make([]string, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		hosts = append(hosts, r.Name)
+	}
+	return hosts, nil
+}
+
+// Services returns all Icinga services.
+func (m *Inventory) Services() ([]Service, error) {
+	in, err := json.Marshal(map[string][]string{
+		"attrs": append([]string{"name", "host_name", "check_command", "vars"}, serviceAttrs...),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var resp struct {
+		Results []struct {
+			Attrs map[string]interface{} `json:"attrs"`
+		} `json:"results"`
+	}
+	if _, err := m.IcingaClient.Service("").Get([]string{}, string(in)).Do().Into(&resp); err != nil {
+		return nil, errors.Wrap(err, "can't list icinga services")
+	}
+	services := make([]Service, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		svc := Service{Attrs: map[string]interface{}{}}
+		svc.HostName, _ = r.Attrs["host_name"].(string)
+		svc.Name, _ = r.Attrs["name"].(string)
+		svc.CheckCommand, _ = r.Attrs["check_command"].(string)
+		for _, key := range serviceAttrs {
+			if val, ok := r.Attrs[key]; ok {
+				svc.Attrs[key] = val
+			}
+		}
+		vars, _ := r.Attrs["vars"].(map[string]interface{})
+		for key, val := range vars {
+			svc.Attrs[IVar(key)] = val
+		}
+		services = append(services, svc)
+	}
+	return services, nil
+}
+
+// Notifications returns all Icinga notifications.
+func (m *Inventory) Notifications() ([]Notification, error) {
+	in := `{"attrs": ["interval", "users"]}`
+	var resp struct {
+		Results []struct {
+			Name  string                 `json:"name"`
+			Attrs map[string]interface{} `json:"attrs"`
+		} `json:"results"`
+	}
+	if _, err := m.IcingaClient.Notifications("").Get([]string{}, in).Do().Into(&resp); err != nil {
+		return nil, errors.Wrap(err, "can't list icinga notifications")
+	}
+	notifications := make([]Notification, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		notifications = append(notifications, Notification{Name: r.Name, Attrs: r.Attrs})
+	}
+	return notifications, nil
+}
+
+// DeleteHost deletes an Icinga host with its services and notifications.
+func (m *Inventory) DeleteHost(host string) error {
+	resp := m.IcingaClient.Hosts(host).Delete([]string{}, "").Params(map[string]string{"cascade": "1"}).Do()
+	if resp.Err != nil {
+		return errors.Wrap(resp.Err, "Failed to delete Icinga Host")
+	}
+	if resp.Status == 200 || resp.Status == 404 {
+		return nil
+	}
+	return errors.Errorf("can't delete Icinga host %s. Status: %d", host, resp.Status)
+}
+
+// DeleteService deletes an Icinga service with its notifications and dependencies.
+func (m *Inventory) DeleteService(host, svc string) error {
+	resp := m.IcingaClient.Service(host).Delete([]string{svc}, "").Params(map[string]string{"cascade": "1"}).Do()
+	if resp.Err != nil {
+		return errors.Wrap(resp.Err, "Failed to delete Icinga Service")
+	}
+	if resp.Status == 200 || resp.Status == 404 {
+		return nil
+	}
+	return errors.Errorf("can't delete Icinga service %s!%s. Status: %d", host, svc, resp.Status)
+}
+
+// DeleteAlert deletes the Icinga services named svc from the hosts matching hostPattern, with their
+// notifications and dependencies, and the hosts left without any service. It returns an error unless
+// none of these objects remain in Icinga afterwards.
+func (m *Inventory) DeleteAlert(hostPattern, svc string) error {
+	filter, err := filterQuery("match(pattern,host.name)&&service.name==svc", map[string]string{
+		"pattern": hostPattern,
+		"svc":     svc,
+	})
+	if err != nil {
+		return err
+	}
+	var resp ResponseObject
+	if _, err := m.IcingaClient.Service("").Get([]string{}, filter).Do().Into(&resp); err != nil {
+		return errors.Wrap(err, "can't list icinga services")
+	}
+	hosts := sets.NewString()
+	for _, r := range resp.Results {
+		hosts.Insert(r.Attrs.HostName)
+	}
+
+	for _, host := range hosts.List() {
+		if err := m.DeleteService(host, svc); err != nil {
+			return err
+		}
+	}
+	if n, err := m.count(m.IcingaClient.Service(""), filter); err != nil {
+		return err
+	} else if n > 0 {
+		return errors.Errorf("%d Icinga services %s remain on hosts %s", n, svc, hostPattern)
+	}
+	if n, err := m.count(m.IcingaClient.Notifications(""), filter); err != nil {
+		return err
+	} else if n > 0 {
+		return errors.Errorf("%d Icinga notifications of services %s remain on hosts %s", n, svc, hostPattern)
+	}
+
+	for _, host := range hosts.List() {
+		hostFilter, err := filterQuery("host.name==hostName", map[string]string{"hostName": host})
+		if err != nil {
+			return err
+		}
+		n, err := m.count(m.IcingaClient.Service(""), hostFilter)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			continue
+		}
+		if err := m.DeleteHost(host); err != nil {
+			return err
+		}
+		if n, err := m.count(m.IcingaClient.Hosts(""), hostFilter); err != nil {
+			return err
+		} else if n > 0 {
+			return errors.Errorf("Icinga host %s remains", host)
+		}
+	}
+	return nil
+}
+
+// filterQuery returns the body of a request for the Icinga objects matching filter. Values are passed
+// to the filter as filter_vars, so that names are never parsed as part of the filter expression.
+func filterQuery(filter string, vars map[string]string) (string, error) {
+	data, err := json.Marshal(map[string]interface{}{
+		"filter":      filter,
+		"filter_vars": vars,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal Icinga filter")
+	}
+	return string(data), nil
+}
+
+// count returns the number of Icinga objects of a request matching filter.
+func (m *Inventory) count(req *APIRequest, filter string) (int, error) {
+	var resp ResponseObject
+	if _, err := req.Get([]string{}, filter).Do().Into(&resp); err != nil {
+		return 0, errors.Wrap(err, "can't list icinga objects")
+	}
+	return len(resp.Results), nil
+}
@@ -0,0 +1,141 @@
+package icinga
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeleteAlertFilter(t *testing.T) {
+	var queries []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var query map[string]interface{}
+		if err := json.Unmarshal(body, &query); err != nil {
+			t.Errorf("invalid request body %s: %v", body, err)
+		}
+		queries = append(queries, query)
+		w.Write([]byte(`{"results": []}`))
+	}))
+	defer server.Close()
+
+	// quotes in names must not end the string literals of the filter
+	svc := `x"||match("*`
+	m := NewInventory(NewClient(Config{Endpoint: server.URL}))
+	if err := m.DeleteAlert("demo@pod@*", svc); err != nil {
+		t.Fatal(err)
+	}
+	if len(queries) == 0 {
+		t.Fatal("expected Icinga to be queried")
+	}
+	for _, q := range queries {
+		if q["filter"] != "match(pattern,host.name)&&service.name==svc" {
+			t.Errorf("unexpected filter %v", q["filter"])
+		}
+		vars, _ := q["filter_vars"].(map[string]interface{})
+		if vars["pattern"] != "demo@pod@*" || vars["svc"] != svc {
+			t.Errorf("unexpected filter vars %v", q["filter_vars"])
+		}
+	}
+}
+
+func TestFilterVars(t *testing.T) {
+	// quotes in names must not end the string literals of filters
+	const name = `x"||match("*`
+	var queries []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var query map[string]interface{}
+		if err := json.Unmarshal(body, &query); err != nil {
+			t.Errorf("invalid request body %s: %v", body, err)
+		}
+		if _, ok := query["filter"]; ok {
+			queries = append(queries, query)
+		}
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/objects/dependencies") && r.Method == http.MethodGet:
+			w.Write([]byte(`{"results": [{"attrs": {"__name": "x\"||match(\"*"}}]}`))
+		case strings.HasPrefix(r.URL.Path, "/actions/schedule-downtime"):
+			w.Write([]byte(`{"results": [{"code": 200, "name": "downtime"}]}`))
+		default:
+			w.Write([]byte(`{"results": []}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Endpoint: server.URL})
+	h := &commonHost{IcingaClient: client}
+	kh := IcingaHost{Type: TypePod, AlertNamespace: "demo", ObjectName: name, IP: "10.0.0.1"}
+	downtimes := NewDowntimeManager(client)
+	ops := map[string]func() error{
+		"delete host": func() error {
+			return h.deleteIcingaHost(kh)
+		},
+		"force delete host": func() error {
+			return h.ForceDeleteIcingaHost(kh)
+		},
+		"delete service": func() error {
+			return h.deleteIcingaService(name, kh)
+		},
+		"delete services of check command": func() error {
+			return h.deleteIcingaServiceForCheckCommand(name)
+		},
+		"service states": func() error {
+			_, err := h.GetServiceStates(name, kh, kh)
+			return err
+		},
+		"workload hosts": func() error {
+			_, err := NewWorkloadHost(client, "").ListHosts("demo", name)
+			return err
+		},
+		"dependencies": func() error {
+			return h.reconcileIcingaDependency(name, kh, nil, "")
+		},
+		"downtime hosts": func() error {
+			_, err := downtimes.ListServiceHosts("demo@pod@*", name)
+			return err
+		},
+		"downtimes": func() error {
+			_, err := downtimes.List(name)
+			return err
+		},
+		"schedule downtime": func() error {
+			_, err := downtimes.Schedule("demo@pod@web-0", name, time.Now(), time.Now().Add(time.Hour), "tag", "comment")
+			return err
+		},
+		"remove downtime": func() error {
+			return downtimes.Remove(name)
+		},
+	}
+	for op, fn := range ops {
+		t.Run(op, func(t *testing.T) {
+			queries = nil
+			if err := fn(); err != nil {
+				t.Fatal(err)
+			}
+			if len(queries) == 0 {
+				t.Fatal("expected Icinga to be queried with a filter")
+			}
+			for _, q := range queries {
+				filter, _ := q["filter"].(string)
+				if strings.Contains(filter, `"`) {
+					t.Errorf("expected filter without literals, got %s", filter)
+				}
+				found := false
+				vars, _ := q["filter_vars"].(map[string]interface{})
+				for _, v := range vars {
+					if s, _ := v.(string); strings.Contains(s, name) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("expected %q in filter vars, got %v", name, q["filter_vars"])
+				}
+			}
+		})
+	}
+}
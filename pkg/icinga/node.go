@@ -20,7 +20,7 @@ func NewNodeHost(IcingaClient *Client, verbosity string) *NodeHost {
 	}
 }
 
-func (h *NodeHost) getHost(namespace string, node *core.Node) IcingaHost {
+func (h *NodeHost) GetHost(namespace string, node *core.Node) IcingaHost {
 	nodeIP := "127.0.0.1"
 	for _, ip := range node.Status.Addresses {
 		if ip.Type == internalIP {
@@ -39,7 +39,7 @@ func (h *NodeHost) getHost(namespace string, node *core.Node) IcingaHost {
 // set Alert in Icinga LocalHost
 func (h *NodeHost) Apply(alert *api.NodeAlert, node *core.Node) error {
 	alertSpec := alert.Spec
-	kh := h.getHost(alert.Namespace, node)
+	kh := h.GetHost(alert.Namespace, node)
 
 	if err := h.reconcileIcingaHost(kh); err != nil {
 		return err
@@ -59,12 +59,9 @@ func (h *NodeHost) Apply(alert *api.NodeAlert, node *core.Node) error {
 		return nil
 	}
 
-	attrs := make(map[string]interface{})
-	if alertSpec.CheckInterval.Seconds() > 0 {
-		attrs["check_interval"] = alertSpec.CheckInterval.Seconds()
-	}
-	for key, val := range alertSpec.Vars {
-		attrs[IVar(key)] = val
+	attrs, err := h.ServiceAttrs(alert, node)
+	if err != nil {
+		return err
 	}
 
 	if !has {
@@ -81,8 +78,25 @@ func (h *NodeHost) Apply(alert *api.NodeAlert, node *core.Node) error {
 	return h.reconcileIcingaNotification(alert, kh)
 }
 
+// ServiceAttrs returns the attributes of the Icinga Service of a NodeAlert for a node, except its check command.
+func (h *NodeHost) ServiceAttrs(alert *api.NodeAlert, node *core.Node) (map[string]interface{}, error) {
+	alertSpec := alert.Spec
+	attrs := make(map[string]interface{})
+	if alertSpec.CheckInterval.Seconds() > 0 {
+		attrs["check_interval"] = alertSpec.CheckInterval.Seconds()
+	}
+	setCheckAttrs(attrs, alert)
+	vars, err := renderVars(alertSpec.Vars, TemplateData{Node: node})
+	if err != nil {
+		return nil, err
+	}
+	cmd, _ := api.NodeCommands.Get(alertSpec.Check)
+	setVars(attrs, cmd, vars, alert.Namespace, alertSpec.VarsFrom)
+	return attrs, nil
+}
+
 func (h *NodeHost) Delete(alertNamespace, alertName string, node *core.Node) error {
-	kh := h.getHost(alertNamespace, node)
+	kh := h.GetHost(alertNamespace, node)
 
 	if err := h.deleteIcingaService(alertName, kh); err != nil {
 		return err
@@ -18,7 +18,7 @@ func NewPodHost(IcingaClient *Client, verbosity string) *PodHost {
 	}
 }
 
-func (h *PodHost) getHost(namespace string, pod *core.Pod) IcingaHost {
+func (h *PodHost) GetHost(namespace string, pod *core.Pod) IcingaHost {
 	return IcingaHost{
 		ObjectName:     pod.Name,
 		Type:           TypePod,
@@ -29,7 +29,7 @@ func (h *PodHost) getHost(namespace string, pod *core.Pod) IcingaHost {
 
 func (h *PodHost) Apply(alert *api.PodAlert, pod *core.Pod) error {
 	alertSpec := alert.Spec
-	kh := h.getHost(alert.Namespace, pod)
+	kh := h.GetHost(alert.Namespace, pod)
 
 	if err := h.reconcileIcingaHost(kh); err != nil {
 		return err
@@ -49,13 +49,9 @@ func (h *PodHost) Apply(alert *api.PodAlert, pod *core.Pod) error {
 		return nil
 	}
 
-	attrs := make(map[string]interface{})
-	if alertSpec.CheckInterval.Seconds() > 0 {
-		attrs["check_interval"] = alertSpec.CheckInterval.Seconds()
-	}
-
-	for key, val := range alertSpec.Vars {
-		attrs[IVar(key)] = val
+	attrs, err := h.ServiceAttrs(alert, pod)
+	if err != nil {
+		return err
 	}
 
 	if !has {
@@ -69,11 +65,54 @@ func (h *PodHost) Apply(alert *api.PodAlert, pod *core.Pod) error {
 		}
 	}
 
-	return h.reconcileIcingaNotification(alert, kh)
+	if err := h.reconcileIcingaNotification(alert, kh); err != nil {
+		return err
+	}
+	return h.reconcileNodeDependency(alert, kh, pod)
+}
+
+// reconcileNodeDependency makes the service of a PodAlert depend on the service of its NodeAlert
+// dependency on the node of the pod. Dependencies are only created once the NodeAlert is applied to that node.
+func (h *PodHost) reconcileNodeDependency(alert *api.PodAlert, kh IcingaHost, pod *core.Pod) error {
+	if alert.Spec.DependsOn == nil || pod.Spec.NodeName == "" {
+		return h.reconcileIcingaDependency(alert.Name, kh, nil, "")
+	}
+
+	parent := IcingaHost{
+		ObjectName:     pod.Spec.NodeName,
+		Type:           TypeNode,
+		AlertNamespace: alert.Namespace,
+	}
+	has, err := h.checkIcingaService(alert.Spec.DependsOn.NodeAlert, parent)
+	if err != nil {
+		return err
+	}
+	if !has {
+		return h.reconcileIcingaDependency(alert.Name, kh, nil, "")
+	}
+	return h.reconcileIcingaDependency(alert.Name, kh, &parent, alert.Spec.DependsOn.NodeAlert)
+}
+
+// ServiceAttrs returns the attributes of the Icinga Service of a PodAlert for a pod, except its check command.
+func (h *PodHost) ServiceAttrs(alert *api.PodAlert, pod *core.Pod) (map[string]interface{}, error) {
+	alertSpec := alert.Spec
+	attrs := make(map[string]interface{})
+	if alertSpec.CheckInterval.Seconds() > 0 {
+		attrs["check_interval"] = alertSpec.CheckInterval.Seconds()
+	}
+	setCheckAttrs(attrs, alert)
+
+	vars, err := renderVars(alertSpec.Vars, TemplateData{Pod: pod})
+	if err != nil {
+		return nil, err
+	}
+	cmd, _ := api.PodCommands.Get(alertSpec.Check)
+	setVars(attrs, cmd, vars, alert.Namespace, alertSpec.VarsFrom)
+	return attrs, nil
 }
 
 func (h *PodHost) Delete(alertNamespace, alertName string, pod *core.Pod) error {
-	kh := h.getHost(alertNamespace, pod)
+	kh := h.GetHost(alertNamespace, pod)
 
 	if err := h.deleteIcingaService(alertName, kh); err != nil {
 		return err
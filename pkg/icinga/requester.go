@@ -10,15 +10,24 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/appscode/searchlight/pkg/metrics"
 )
 
 func (ic *APIRequest) Do() *APIResponse {
+	start := time.Now()
+	defer func() {
+		metrics.ObserveIcingaRequest(ic.verb, ic.object, ic.Status, time.Since(start))
+	}()
+
 	if ic.Err != nil {
 		return &APIResponse{
 			Err: ic.Err,
 		}
 	}
-	ic.req.Header.Set("Accept", "application/json")
+	if ic.req.Header.Get("Accept") == "" {
+		ic.req.Header.Set("Accept", "application/json")
+	}
 
 	if ic.userName != "" && ic.password != "" {
 		ic.req.SetBasicAuth(ic.userName, ic.password)
@@ -84,6 +93,7 @@ func (c *Client) newRequest(path string) *APIRequest {
 
 	return &APIRequest{
 		uri:      c.config.Endpoint + path,
+		object:   objectType(path),
 		client:   client,
 		userName: c.config.BasicAuth.Username,
 		password: c.config.BasicAuth.Password,
@@ -97,3 +107,15 @@ func (ic *APIRequest) newRequest(method, urlStr string, body io.Reader) (*http.R
 
 	return http.NewRequest(method, urlStr, body)
 }
+
+// objectType returns the type of Icinga objects of an API path, e.g. hosts for /objects/hosts/{name}.
+func objectType(path string) string {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	switch {
+	case parts[0] == "":
+		return "status"
+	case parts[0] == "objects" && len(parts) > 1:
+		return parts[1]
+	}
+	return parts[0]
+}
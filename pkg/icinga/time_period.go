@@ -0,0 +1,80 @@
+package icinga
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/pkg/errors"
+)
+
+// TimePeriodManager creates and deletes the Icinga TimePeriods of TimePeriod objects.
+type TimePeriodManager struct {
+	IcingaClient *Client
+}
+
+func NewTimePeriodManager(IcingaClient *Client) *TimePeriodManager {
+	return &TimePeriodManager{IcingaClient: IcingaClient}
+}
+
+// TimePeriodName returns the name of the Icinga TimePeriod of a TimePeriod object.
+func TimePeriodName(name string) string {
+	return "timeperiod@" + name
+}
+
+// Apply creates or updates the Icinga TimePeriod with the ranges of a TimePeriod object.
+func (m *TimePeriodManager) Apply(tp *api.TimePeriod) error {
+	ranges, err := tp.IcingaRanges(time.Now())
+	if err != nil {
+		return err
+	}
+
+	obj := IcingaObject{
+		Templates: []string{"legacy-timeperiod"},
+		Attrs: map[string]interface{}{
+			"display_name": tp.Name,
+			"ranges":       ranges,
+		},
+	}
+	jsonStr, err := json.Marshal(obj)
+	if err != nil {
+		return errors.Wrap(err, "Failed to Marshal IcingaObject")
+	}
+
+	name := TimePeriodName(tp.Name)
+	resp := m.IcingaClient.TimePeriods(name).Create([]string{}, string(jsonStr)).Do()
+	if resp.Err != nil {
+		return errors.Wrap(resp.Err, "Failed to create Icinga TimePeriod")
+	}
+	if resp.Status == 200 {
+		return nil
+	}
+	if !strings.Contains(string(resp.ResponseBody), "already exists") {
+		return errors.Errorf("Failed to create Icinga TimePeriod. Status: %d", resp.Status)
+	}
+
+	resp = m.IcingaClient.TimePeriods(name).Update([]string{}, string(jsonStr)).Do()
+	if resp.Err != nil {
+		return errors.Wrap(resp.Err, "Failed to update Icinga TimePeriod")
+	}
+	if resp.Status != 200 {
+		return errors.Errorf("can't update Icinga TimePeriod. Status: %d", resp.Status)
+	}
+	return nil
+}
+
+// Delete deletes the Icinga TimePeriod of a TimePeriod object.
+func (m *TimePeriodManager) Delete(name string) error {
+	param := map[string]string{
+		"cascade": "1",
+	}
+	resp := m.IcingaClient.TimePeriods(TimePeriodName(name)).Delete([]string{}, "").Params(param).Do()
+	if resp.Err != nil {
+		return errors.Wrap(resp.Err, "Failed to delete Icinga TimePeriod")
+	}
+	if resp.Status == 200 || resp.Status == 404 {
+		return nil
+	}
+	return errors.Errorf("can't delete Icinga TimePeriod. Status: %d", resp.Status)
+}
@@ -14,21 +14,26 @@ import (
 const (
 	internalIP = "InternalIP"
 
-	TypePod     = "pod"
-	TypeNode    = "node"
-	TypeCluster = "cluster"
+	TypePod      = "pod"
+	TypeNode     = "node"
+	TypeCluster  = "cluster"
+	TypeWorkload = "workload"
+	TypeGlobal   = "global"
 )
 
 type IcingaHost struct {
-	Type           string
+	Type string
+	// Namespace of the alert, or namespace of the pod for global hosts
 	AlertNamespace string
 	ObjectName     string
-	IP             string
+	// Kind of the workload, set for workload hosts only
+	WorkloadKind string
+	IP           string
 }
 
 func IsValidHostType(t string) bool {
 	switch t {
-	case TypePod, TypeNode, TypeCluster:
+	case TypePod, TypeNode, TypeCluster, TypeWorkload, TypeGlobal:
 		return true
 	}
 	return false
@@ -40,8 +45,12 @@ func (kh IcingaHost) Name() (string, error) {
 		return kh.AlertNamespace + "@" + kh.Type + "@" + kh.ObjectName, nil
 	case TypeNode:
 		return kh.AlertNamespace + "@" + kh.Type + "@" + kh.ObjectName, nil
+	case TypeGlobal:
+		return kh.AlertNamespace + "@" + kh.Type + "@" + kh.ObjectName, nil
 	case TypeCluster:
 		return kh.AlertNamespace + "@" + kh.Type, nil
+	case TypeWorkload:
+		return kh.AlertNamespace + "@" + kh.Type + "@" + strings.ToLower(kh.WorkloadKind) + "@" + kh.ObjectName, nil
 	}
 	return "", errors.Errorf("unknown host type %s", kh.Type)
 }
@@ -54,6 +63,10 @@ func (kh IcingaHost) GetAlert(extClient cs.Interface, alertName string) (api.Ale
 		return extClient.MonitoringV1alpha1().NodeAlerts(kh.AlertNamespace).Get(alertName, metav1.GetOptions{})
 	case TypeCluster:
 		return extClient.MonitoringV1alpha1().ClusterAlerts(kh.AlertNamespace).Get(alertName, metav1.GetOptions{})
+	case TypeWorkload:
+		return extClient.MonitoringV1alpha1().WorkloadAlerts(kh.AlertNamespace).Get(alertName, metav1.GetOptions{})
+	case TypeGlobal:
+		return extClient.MonitoringV1alpha1().GlobalAlerts().Get(alertName, metav1.GetOptions{})
 	}
 	return nil, errors.Errorf("unknown host type %s", kh.Type)
 }
@@ -65,7 +78,7 @@ func ParseHost(name string) (*IcingaHost, error) {
 	}
 	t := parts[1]
 	switch t {
-	case TypePod, TypeNode:
+	case TypePod, TypeNode, TypeGlobal:
 		if len(parts) != 3 {
 			return nil, errors.Errorf("host %s has a bad format", name)
 		}
@@ -82,10 +95,37 @@ func ParseHost(name string) (*IcingaHost, error) {
 			AlertNamespace: parts[0],
 			Type:           t,
 		}, nil
+	case TypeWorkload:
+		if len(parts) != 3 {
+			return nil, errors.Errorf("host %s has a bad format", name)
+		}
+		kindAndName := strings.SplitN(parts[2], "@", 2)
+		if len(kindAndName) != 2 {
+			return nil, errors.Errorf("host %s has a bad format", name)
+		}
+		kind, err := workloadKind(kindAndName[0])
+		if err != nil {
+			return nil, err
+		}
+		return &IcingaHost{
+			AlertNamespace: parts[0],
+			Type:           t,
+			WorkloadKind:   kind,
+			ObjectName:     kindAndName[1],
+		}, nil
 	}
 	return nil, errors.Errorf("unknown host type %s", t)
 }
 
+func workloadKind(s string) (string, error) {
+	for _, kind := range []string{api.WorkloadKindDeployment, api.WorkloadKindStatefulSet, api.WorkloadKindDaemonSet} {
+		if strings.EqualFold(s, kind) {
+			return kind, nil
+		}
+	}
+	return "", errors.Errorf("unknown workload kind %s", s)
+}
+
 type IcingaObject struct {
 	Templates []string               `json:"templates,omitempty"`
 	Attrs     map[string]interface{} `json:"attrs"`
@@ -95,9 +135,12 @@ type ResponseObject struct {
 	Results []struct {
 		Attrs struct {
 			Name            string                 `json:"name"`
+			HostName        string                 `json:"host_name"`
 			CheckInterval   float64                `json:"check_interval"`
 			Vars            map[string]interface{} `json:"vars"`
+			State           float64                `json:"state"`
 			LastState       float64                `json:"last_state"`
+			LastCheck       float64                `json:"last_check"`
 			Acknowledgement float64                `json:"acknowledgement"`
 		} `json:"attrs"`
 		Name string `json:"name"`
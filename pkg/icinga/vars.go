@@ -0,0 +1,43 @@
+package icinga
+
+import (
+	"bytes"
+	"text/template"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+)
+
+// TemplateData is the data against which templated vars of an alert are evaluated.
+type TemplateData struct {
+	// Pod checked by a PodAlert or GlobalAlert
+	Pod *core.Pod
+	// Node checked by a NodeAlert
+	Node *core.Node
+}
+
+// renderVars evaluates the templated vars of an alert against its target object.
+// Other vars are returned as is. Rendered values must not refer to secrets, as only varsFrom may.
+func renderVars(vars map[string]string, data TemplateData) (map[string]string, error) {
+	out := make(map[string]string, len(vars))
+	for key, val := range vars {
+		if !api.IsVarTemplate(val) {
+			out[key] = val
+			continue
+		}
+		tpl, err := template.New(key).Parse(val)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse template of var %s", key)
+		}
+		var buf bytes.Buffer
+		if err := tpl.Execute(&buf, data); err != nil {
+			return nil, errors.Wrapf(err, "failed to render template of var %s", key)
+		}
+		if api.IsSecretVarRef(buf.String()) {
+			return nil, errors.Errorf("rendered value of var %s must not start with %s", key, api.SecretVarPrefix)
+		}
+		out[key] = buf.String()
+	}
+	return out, nil
+}
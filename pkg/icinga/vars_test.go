@@ -0,0 +1,201 @@
+package icinga
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRenderVars(t *testing.T) {
+	data := TemplateData{
+		Pod: &core.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "web-0",
+				Namespace:   "demo",
+				Annotations: map[string]string{"secret": "secretKeyRef://kube-system/admin/token"},
+			},
+		},
+	}
+
+	cases := []struct {
+		name    string
+		vars    map[string]string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "plain",
+			vars: map[string]string{"warning": "10"},
+			want: map[string]string{"warning": "10"},
+		},
+		{
+			name: "templated",
+			vars: map[string]string{"url": "http://{{ .Pod.Name }}.{{ .Pod.Namespace }}"},
+			want: map[string]string{"url": "http://web-0.demo"},
+		},
+		{
+			name:    "rendered secret reference",
+			vars:    map[string]string{"token": "{{ index .Pod.Annotations \"secret\" }}"},
+			wantErr: true,
+		},
+		{
+			name:    "composed secret reference",
+			vars:    map[string]string{"token": "secretKeyRef://{{ \"kube-system\" }}/admin/token"},
+			wantErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := renderVars(c.vars, data)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for k, v := range c.want {
+				if got[k] != v {
+					t.Errorf("var %s: expected %q, got %q", k, v, got[k])
+				}
+			}
+		})
+	}
+}
+
+func TestServiceAttrsVars(t *testing.T) {
+	cmd := api.IcingaCommand{
+		Name: "test-vars",
+		Vars: &api.PluginVars{Fields: map[string]api.PluginVarField{
+			"url":   {Type: api.VarTypeString},
+			"token": {Type: api.VarTypeString},
+		}},
+	}
+	for _, reg := range []*api.Registry{api.PodCommands, api.NodeCommands, api.ClusterCommands, api.WorkloadCommands} {
+		reg.Insert(cmd.Name, cmd)
+		defer reg.Delete(cmd.Name)
+	}
+	vars := map[string]string{"url": "http://{{ .Pod.Name }}", "unknown": "1"}
+	varsFrom := map[string]api.VarSource{
+		"token":   {SecretKeyRef: &api.SecretKeySelector{Name: "auth", Key: "token"}},
+		"unknown": {SecretKeyRef: &api.SecretKeySelector{Name: "auth", Key: "other"}},
+	}
+	token := api.SecretVarRef("demo", api.SecretKeySelector{Name: "auth", Key: "token"})
+	meta := metav1.ObjectMeta{Name: "vars", Namespace: "demo"}
+	pod := &core.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "demo"}}
+	node := &core.Node{ObjectMeta: metav1.ObjectMeta{Name: "minikube"}}
+
+	cases := []struct {
+		kind  string
+		attrs func() (map[string]interface{}, error)
+		want  map[string]interface{}
+	}{
+		{
+			kind: api.ResourceKindPodAlert,
+			attrs: func() (map[string]interface{}, error) {
+				return (&PodHost{}).ServiceAttrs(&api.PodAlert{ObjectMeta: meta, Spec: api.PodAlertSpec{Check: cmd.Name, Vars: vars, VarsFrom: varsFrom}}, pod)
+			},
+			want: map[string]interface{}{"vars.url": "http://web-0", "vars.token": token},
+		},
+		{
+			kind: api.ResourceKindNodeAlert,
+			attrs: func() (map[string]interface{}, error) {
+				return (&NodeHost{}).ServiceAttrs(&api.NodeAlert{ObjectMeta: meta, Spec: api.NodeAlertSpec{Check: cmd.Name, Vars: map[string]string{"url": "http://{{ .Node.Name }}"}, VarsFrom: varsFrom}}, node)
+			},
+			want: map[string]interface{}{"vars.url": "http://minikube", "vars.token": token},
+		},
+		{
+			kind: api.ResourceKindClusterAlert,
+			attrs: func() (map[string]interface{}, error) {
+				return (&ClusterHost{}).ServiceAttrs(&api.ClusterAlert{ObjectMeta: meta, Spec: api.ClusterAlertSpec{Check: cmd.Name, Vars: map[string]string{"url": "http://a", "unknown": "1"}, VarsFrom: varsFrom}})
+			},
+			want: map[string]interface{}{"vars.url": "http://a", "vars.token": token},
+		},
+		{
+			kind: api.ResourceKindWorkloadAlert,
+			attrs: func() (map[string]interface{}, error) {
+				return (&WorkloadHost{}).ServiceAttrs(&api.WorkloadAlert{ObjectMeta: meta, Spec: api.WorkloadAlertSpec{Check: cmd.Name, Vars: map[string]string{"url": "http://a", "unknown": "1"}, VarsFrom: varsFrom}})
+			},
+			want: map[string]interface{}{"vars.url": "http://a", "vars.token": token},
+		},
+		{
+			kind: api.ResourceKindGlobalAlert,
+			attrs: func() (map[string]interface{}, error) {
+				return (&GlobalHost{}).ServiceAttrs(&api.GlobalAlert{ObjectMeta: metav1.ObjectMeta{Name: "vars"}, Spec: api.GlobalAlertSpec{Check: cmd.Name, Vars: vars}}, pod)
+			},
+			want: map[string]interface{}{"vars.url": "http://web-0"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.kind, func(t *testing.T) {
+			attrs, err := c.attrs()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := map[string]interface{}{}
+			for key, val := range attrs {
+				if strings.HasPrefix(key, "vars.") {
+					got[key] = val
+				}
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("expected vars %v, got %v", c.want, got)
+			}
+		})
+	}
+}
+
+func TestServiceAttrsVarsWithoutSchema(t *testing.T) {
+	cmd := api.IcingaCommand{Name: "test-no-vars"}
+	for _, reg := range []*api.Registry{api.NodeCommands, api.ClusterCommands} {
+		reg.Insert(cmd.Name, cmd)
+		defer reg.Delete(cmd.Name)
+	}
+	meta := metav1.ObjectMeta{Name: "vars", Namespace: "demo"}
+	node := &core.Node{ObjectMeta: metav1.ObjectMeta{Name: "minikube"}}
+	want := map[string]interface{}{"vars.warning": "10", "vars.host": "minikube"}
+
+	cases := []struct {
+		kind  string
+		attrs func() (map[string]interface{}, error)
+		want  map[string]interface{}
+	}{
+		{
+			kind: api.ResourceKindNodeAlert,
+			attrs: func() (map[string]interface{}, error) {
+				return (&NodeHost{}).ServiceAttrs(&api.NodeAlert{ObjectMeta: meta, Spec: api.NodeAlertSpec{Check: cmd.Name, Vars: map[string]string{"warning": "10", "host": "{{ .Node.Name }}"}}}, node)
+			},
+			want: want,
+		},
+		{
+			kind: api.ResourceKindClusterAlert,
+			attrs: func() (map[string]interface{}, error) {
+				return (&ClusterHost{}).ServiceAttrs(&api.ClusterAlert{ObjectMeta: meta, Spec: api.ClusterAlertSpec{Check: cmd.Name, Vars: map[string]string{"warning": "10", "host": "minikube"}}})
+			},
+			want: want,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.kind, func(t *testing.T) {
+			attrs, err := c.attrs()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := map[string]interface{}{}
+			for key, val := range attrs {
+				if strings.HasPrefix(key, "vars.") {
+					got[key] = val
+				}
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("expected vars %v, got %v", c.want, got)
+			}
+		})
+	}
+}
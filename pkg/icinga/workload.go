@@ -0,0 +1,120 @@
+package icinga
+
+import (
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/pkg/errors"
+)
+
+type WorkloadHost struct {
+	commonHost
+}
+
+func NewWorkloadHost(IcingaClient *Client, verbosity string) *WorkloadHost {
+	return &WorkloadHost{
+		commonHost: commonHost{
+			IcingaClient: IcingaClient,
+			verbosity:    verbosity,
+		},
+	}
+}
+
+func (h *WorkloadHost) GetHost(namespace, kind, name string) IcingaHost {
+	return IcingaHost{
+		ObjectName:     name,
+		WorkloadKind:   kind,
+		Type:           TypeWorkload,
+		AlertNamespace: namespace,
+		IP:             "127.0.0.1",
+	}
+}
+
+// Apply creates or updates the Icinga Service of a WorkloadAlert for the workload with the given name.
+func (h *WorkloadHost) Apply(alert *api.WorkloadAlert, name string) error {
+	alertSpec := alert.Spec
+	kh := h.GetHost(alert.Namespace, alertSpec.TargetRef.Kind, name)
+
+	if err := h.reconcileIcingaHost(kh); err != nil {
+		return err
+	}
+
+	has, err := h.checkIcingaService(alert.Name, kh)
+	if err != nil {
+		return err
+	}
+
+	if alertSpec.Paused {
+		if has {
+			if err := h.deleteIcingaService(alert.Name, kh); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	attrs, err := h.ServiceAttrs(alert)
+	if err != nil {
+		return err
+	}
+
+	if !has {
+		attrs["check_command"] = alertSpec.Check
+		if err := h.createIcingaService(alert.Name, kh, attrs); err != nil {
+			return err
+		}
+	} else {
+		if err := h.updateIcingaService(alert.Name, kh, attrs); err != nil {
+			return err
+		}
+	}
+
+	return h.reconcileIcingaNotification(alert, kh)
+}
+
+// ServiceAttrs returns the attributes of the Icinga Service of a WorkloadAlert, except its check command.
+func (h *WorkloadHost) ServiceAttrs(alert *api.WorkloadAlert) (map[string]interface{}, error) {
+	alertSpec := alert.Spec
+	attrs := make(map[string]interface{})
+	if alertSpec.CheckInterval.Seconds() > 0 {
+		attrs["check_interval"] = alertSpec.CheckInterval.Seconds()
+	}
+	setCheckAttrs(attrs, alert)
+	cmd, _ := api.WorkloadCommands.Get(alertSpec.Check)
+	setVars(attrs, cmd, alertSpec.Vars, alert.Namespace, alertSpec.VarsFrom)
+	return attrs, nil
+}
+
+func (h *WorkloadHost) Delete(alertName string, kh IcingaHost) error {
+	if err := h.deleteIcingaService(alertName, kh); err != nil {
+		return err
+	}
+	return h.deleteIcingaHost(kh)
+}
+
+func (h *WorkloadHost) DeleteChecks(cmd string) error {
+	return h.deleteIcingaServiceForCheckCommand(cmd)
+}
+
+// ListHosts returns the workload hosts in alertNamespace that have an Icinga Service for the named alert.
+func (h *WorkloadHost) ListHosts(alertNamespace, alertName string) ([]IcingaHost, error) {
+	in, err := filterQuery("match(pattern,host.name)&&match(svc,service.name)", map[string]string{
+		"pattern": alertNamespace + "@" + TypeWorkload + "@*",
+		"svc":     alertName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var respService ResponseObject
+	if _, err := h.IcingaClient.Service("").Get([]string{}, in).Do().Into(&respService); err != nil {
+		return nil, errors.Wrap(err, "can't get icinga service")
+	}
+
+	hosts := make([]IcingaHost, 0, len(respService.Results))
+	for _, r := range respService.Results {
+		kh, err := ParseHost(r.Attrs.HostName)
+		if err != nil {
+			continue
+		}
+		hosts = append(hosts, *kh)
+	}
+	return hosts, nil
+}
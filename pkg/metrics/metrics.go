@@ -0,0 +1,120 @@
+// Package metrics defines the Prometheus metrics of Searchlight operator. Metrics are registered
+// in the default registry, which is served by searchlight server at /metrics.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "searchlight"
+
+var (
+	reconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "reconcile",
+		Name:      "total",
+		Help:      "Number of reconciliations of queue items, by queue and result.",
+	}, []string{"queue", "result"})
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "reconcile",
+		Name:      "duration_seconds",
+		Help:      "Duration of reconciliations of queue items, by queue.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"queue"})
+
+	icingaRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "icinga_api",
+		Name:      "requests_total",
+		Help:      "Number of requests to the Icinga API, by verb, object type and status code.",
+	}, []string{"verb", "object", "code"})
+	icingaRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "icinga_api",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of requests to the Icinga API, by verb and object type.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"verb", "object"})
+
+	// DriftCorrections counts Icinga objects found missing, divergent or orphaned by the drift reconciler.
+	DriftCorrections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "drift",
+		Name:      "corrections_total",
+		Help:      "Number of Icinga objects found missing, divergent or orphaned by the drift reconciler.",
+	}, []string{"object", "action"})
+	// DriftRuns counts runs of the drift reconciler by result.
+	DriftRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "drift",
+		Name:      "runs_total",
+		Help:      "Number of runs of the drift reconciler.",
+	}, []string{"result"})
+	// DriftLastRun is the time of the last successful run of the drift reconciler.
+	DriftLastRun = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "drift",
+		Name:      "last_run_timestamp_seconds",
+		Help:      "Time of the last successful run of the drift reconciler.",
+	})
+
+	// IncidentsExpired counts Incidents deleted by incident retention, by the reason they expired.
+	IncidentsExpired = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "incidents",
+		Name:      "expired_total",
+		Help:      "Number of Incidents deleted by incident retention, by the reason they expired.",
+	}, []string{"reason"})
+	// IncidentArchiveErrors counts Incidents which failed to be archived, and were kept.
+	IncidentArchiveErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "incidents",
+		Name:      "archive_errors_total",
+		Help:      "Number of Incidents which failed to be archived before deletion.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		reconcileTotal,
+		reconcileDuration,
+		icingaRequests,
+		icingaRequestDuration,
+		DriftCorrections,
+		DriftRuns,
+		DriftLastRun,
+		IncidentsExpired,
+		IncidentArchiveErrors,
+	)
+}
+
+// InstrumentReconcile returns a reconcile function of a queue which records the count, result and
+// duration of reconciliations of fn.
+func InstrumentReconcile(queue string, fn func(key string) error) func(key string) error {
+	return func(key string) error {
+		start := time.Now()
+		err := fn(key)
+		reconcileDuration.WithLabelValues(queue).Observe(time.Since(start).Seconds())
+		if err != nil {
+			reconcileTotal.WithLabelValues(queue, "error").Inc()
+		} else {
+			reconcileTotal.WithLabelValues(queue, "success").Inc()
+		}
+		return err
+	}
+}
+
+// ObserveIcingaRequest records a request to the Icinga API. A status code of 0 means the request failed
+// without a response.
+func ObserveIcingaRequest(verb, object string, code int, duration time.Duration) {
+	status := "error"
+	if code > 0 {
+		status = strconv.Itoa(code)
+	}
+	icingaRequests.WithLabelValues(verb, object, status).Inc()
+	icingaRequestDuration.WithLabelValues(verb, object).Observe(duration.Seconds())
+}
@@ -0,0 +1,71 @@
+package operator
+
+import (
+	"reflect"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"kmodules.xyz/client-go/tools/queue"
+)
+
+func (op *Operator) initAlertTemplateWatcher() {
+	op.atInformer = op.monInformerFactory.Monitoring().V1alpha1().AlertTemplates().Informer()
+	op.atInformer.AddEventHandler(&cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			op.enqueueTemplateDependents(obj.(*api.AlertTemplate))
+		},
+		UpdateFunc: func(oldObj interface{}, newObj interface{}) {
+			old := oldObj.(*api.AlertTemplate)
+			nu := newObj.(*api.AlertTemplate)
+
+			if reflect.DeepEqual(old.Spec, nu.Spec) {
+				return
+			}
+			op.enqueueTemplateDependents(nu)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if t, ok := obj.(*api.AlertTemplate); ok {
+				op.enqueueTemplateDependents(t)
+			} else if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				if t, ok := tombstone.Obj.(*api.AlertTemplate); ok {
+					op.enqueueTemplateDependents(t)
+				}
+			}
+		},
+	})
+	op.atLister = op.monInformerFactory.Monitoring().V1alpha1().AlertTemplates().Lister()
+}
+
+// enqueueTemplateDependents enqueues the ClusterAlerts, NodeAlerts and PodAlerts referring to an
+// AlertTemplate, so that changes of the template are applied to them.
+func (op *Operator) enqueueTemplateDependents(t *api.AlertTemplate) {
+	if cas, err := op.caLister.ClusterAlerts(t.Namespace).List(labels.Everything()); err == nil {
+		for _, alert := range cas {
+			if alert.Spec.TemplateRef == t.Name && op.isValid(alert) {
+				queue.Enqueue(op.caQueue.GetQueue(), alert)
+			}
+		}
+	} else {
+		glog.Errorf("failed to list ClusterAlerts of AlertTemplate %s/%s: %v", t.Namespace, t.Name, err)
+	}
+	if nas, err := op.naLister.NodeAlerts(t.Namespace).List(labels.Everything()); err == nil {
+		for _, alert := range nas {
+			if alert.Spec.TemplateRef == t.Name && op.isValid(alert) {
+				queue.Enqueue(op.naQueue.GetQueue(), alert)
+			}
+		}
+	} else {
+		glog.Errorf("failed to list NodeAlerts of AlertTemplate %s/%s: %v", t.Namespace, t.Name, err)
+	}
+	if pas, err := op.paLister.PodAlerts(t.Namespace).List(labels.Everything()); err == nil {
+		for _, alert := range pas {
+			if alert.Spec.TemplateRef == t.Name && op.isValid(alert) {
+				queue.Enqueue(op.paQueue.GetQueue(), alert)
+			}
+		}
+	} else {
+		glog.Errorf("failed to list PodAlerts of AlertTemplate %s/%s: %v", t.Namespace, t.Name, err)
+	}
+}
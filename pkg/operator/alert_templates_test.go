@@ -0,0 +1,185 @@
+package operator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	mon_listers "github.com/appscode/searchlight/client/listers/monitoring/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestWithTemplate(t *testing.T) {
+	template := &api.AlertTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "demo"},
+		Spec: api.AlertTemplateSpec{
+			CheckInterval:      metav1.Duration{Duration: time.Minute},
+			AlertInterval:      metav1.Duration{Duration: time.Hour},
+			MaxCheckAttempts:   3,
+			RetryInterval:      metav1.Duration{Duration: 10 * time.Second},
+			CheckTimeout:       metav1.Duration{Duration: 20 * time.Second},
+			NotifierSecretName: "notifier-config",
+			Receivers:          []api.Receiver{{State: "Critical", To: []string{"ops"}, Notifier: "Slack"}},
+			Vars:               map[string]string{"warning": "80", "critical": "90", "token": "plain"},
+			VarsFrom:           map[string]api.VarSource{"password": {SecretKeyRef: &api.SecretKeySelector{Name: "db", Key: "password"}}},
+		},
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	indexer.Add(template)
+	lister := mon_listers.NewAlertTemplateLister(indexer)
+	// inherited sets the fields of a spec other than vars to those of the template
+	inherited := func(spec api.PodAlertSpec) api.PodAlertSpec {
+		spec.CheckInterval = template.Spec.CheckInterval
+		spec.AlertInterval = template.Spec.AlertInterval
+		spec.MaxCheckAttempts = template.Spec.MaxCheckAttempts
+		spec.RetryInterval = template.Spec.RetryInterval
+		spec.CheckTimeout = template.Spec.CheckTimeout
+		spec.NotifierSecretName = template.Spec.NotifierSecretName
+		spec.Receivers = template.Spec.Receivers
+		return spec
+	}
+	secretVar := func(name string) api.VarSource {
+		return api.VarSource{SecretKeyRef: &api.SecretKeySelector{Name: name, Key: "value"}}
+	}
+
+	cases := []struct {
+		name     string
+		spec     api.PodAlertSpec
+		expected api.PodAlertSpec
+	}{
+		{
+			name:     "no template",
+			spec:     api.PodAlertSpec{Check: "pod-status", Vars: map[string]string{"warning": "50"}},
+			expected: api.PodAlertSpec{Check: "pod-status", Vars: map[string]string{"warning": "50"}},
+		},
+		{
+			name: "unset fields from template",
+			spec: api.PodAlertSpec{Check: "pod-status", TemplateRef: "defaults"},
+			expected: api.PodAlertSpec{
+				Check:              "pod-status",
+				TemplateRef:        "defaults",
+				CheckInterval:      metav1.Duration{Duration: time.Minute},
+				AlertInterval:      metav1.Duration{Duration: time.Hour},
+				MaxCheckAttempts:   3,
+				RetryInterval:      metav1.Duration{Duration: 10 * time.Second},
+				CheckTimeout:       metav1.Duration{Duration: 20 * time.Second},
+				NotifierSecretName: "notifier-config",
+				Receivers:          []api.Receiver{{State: "Critical", To: []string{"ops"}, Notifier: "Slack"}},
+				Vars:               map[string]string{"warning": "80", "critical": "90", "token": "plain"},
+				VarsFrom:           map[string]api.VarSource{"password": {SecretKeyRef: &api.SecretKeySelector{Name: "db", Key: "password"}}},
+			},
+		},
+		{
+			name: "fields of alert take precedence",
+			spec: api.PodAlertSpec{
+				Check:              "pod-status",
+				TemplateRef:        "defaults",
+				CheckInterval:      metav1.Duration{Duration: 2 * time.Minute},
+				AlertInterval:      metav1.Duration{Duration: 2 * time.Hour},
+				MaxCheckAttempts:   1,
+				RetryInterval:      metav1.Duration{Duration: time.Second},
+				CheckTimeout:       metav1.Duration{Duration: 5 * time.Second},
+				NotifierSecretName: "team-notifier",
+				Receivers:          []api.Receiver{{State: "Warning", To: []string{"team"}, Notifier: "Mailgun"}},
+			},
+			expected: api.PodAlertSpec{
+				Check:              "pod-status",
+				TemplateRef:        "defaults",
+				CheckInterval:      metav1.Duration{Duration: 2 * time.Minute},
+				AlertInterval:      metav1.Duration{Duration: 2 * time.Hour},
+				MaxCheckAttempts:   1,
+				RetryInterval:      metav1.Duration{Duration: time.Second},
+				CheckTimeout:       metav1.Duration{Duration: 5 * time.Second},
+				NotifierSecretName: "team-notifier",
+				Receivers:          []api.Receiver{{State: "Warning", To: []string{"team"}, Notifier: "Mailgun"}},
+				Vars:               map[string]string{"warning": "80", "critical": "90", "token": "plain"},
+				VarsFrom:           map[string]api.VarSource{"password": {SecretKeyRef: &api.SecretKeySelector{Name: "db", Key: "password"}}},
+			},
+		},
+		{
+			name: "vars are merged",
+			spec: api.PodAlertSpec{
+				Check:       "pod-status",
+				TemplateRef: "defaults",
+				Vars:        map[string]string{"warning": "70", "host": "db"},
+			},
+			expected: inherited(api.PodAlertSpec{
+				Check:       "pod-status",
+				TemplateRef: "defaults",
+				Vars:        map[string]string{"warning": "70", "critical": "90", "token": "plain", "host": "db"},
+				VarsFrom:    map[string]api.VarSource{"password": {SecretKeyRef: &api.SecretKeySelector{Name: "db", Key: "password"}}},
+			}),
+		},
+		{
+			// a var of the alert hides the var of the template, whether in vars or varsFrom
+			name: "vars of alert hide varsFrom of template and the other way round",
+			spec: api.PodAlertSpec{
+				Check:       "pod-status",
+				TemplateRef: "defaults",
+				Vars:        map[string]string{"password": "plain"},
+				VarsFrom:    map[string]api.VarSource{"token": secretVar("token"), "user": secretVar("user")},
+			},
+			expected: inherited(api.PodAlertSpec{
+				Check:       "pod-status",
+				TemplateRef: "defaults",
+				Vars:        map[string]string{"warning": "80", "critical": "90", "password": "plain"},
+				VarsFrom:    map[string]api.VarSource{"token": secretVar("token"), "user": secretVar("user")},
+			}),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			alert := &api.PodAlert{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "demo"}, Spec: c.spec}
+			original := alert.DeepCopy()
+			merged, err := withTemplate(lister, alert)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := merged.(*api.PodAlert).Spec; !reflect.DeepEqual(got, c.expected) {
+				t.Errorf("expected spec %+v, got %+v", c.expected, got)
+			}
+			if !reflect.DeepEqual(alert, original) {
+				t.Errorf("expected alert to be unchanged, got %+v", alert.Spec)
+			}
+		})
+	}
+
+	t.Run("receivers of template are copied", func(t *testing.T) {
+		merged, err := withTemplate(lister, &api.ClusterAlert{
+			ObjectMeta: metav1.ObjectMeta{Name: "events", Namespace: "demo"},
+			Spec:       api.ClusterAlertSpec{TemplateRef: "defaults"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		merged.(*api.ClusterAlert).Spec.Receivers[0].To[0] = "changed"
+		if template.Spec.Receivers[0].To[0] != "ops" {
+			t.Errorf("expected template to be unchanged, got receivers %+v", template.Spec.Receivers)
+		}
+	})
+
+	t.Run("node alert", func(t *testing.T) {
+		merged, err := withTemplate(lister, &api.NodeAlert{
+			ObjectMeta: metav1.ObjectMeta{Name: "disk", Namespace: "demo"},
+			Spec:       api.NodeAlertSpec{TemplateRef: "defaults", MaxCheckAttempts: 1},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if spec := merged.(*api.NodeAlert).Spec; spec.MaxCheckAttempts != 1 || spec.CheckInterval.Duration != time.Minute {
+			t.Errorf("expected maxCheckAttempts 1 and checkInterval 1m, got %v and %v", spec.MaxCheckAttempts, spec.CheckInterval.Duration)
+		}
+	})
+
+	t.Run("template in other namespace", func(t *testing.T) {
+		_, err := withTemplate(lister, &api.PodAlert{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "prod"},
+			Spec:       api.PodAlertSpec{TemplateRef: "defaults"},
+		})
+		if err == nil {
+			t.Error("expected error for AlertTemplate which does not exist in the namespace of the alert")
+		}
+	})
+}
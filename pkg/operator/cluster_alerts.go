@@ -14,18 +14,26 @@ import (
 
 func (op *Operator) initClusterAlertWatcher() {
 	op.caInformer = op.monInformerFactory.Monitoring().V1alpha1().ClusterAlerts().Informer()
-	op.caQueue = queue.New("ClusterAlert", op.MaxNumRequeues, op.NumThreads, op.reconcileClusterAlert)
+	op.caQueue = op.newQueue("ClusterAlert", op.reconcileClusterAlert)
 	op.caInformer.AddEventHandler(&cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			alert := obj.(*api.ClusterAlert)
-			if op.isValid(alert) {
+			if alert.DeletionTimestamp != nil || op.isValid(alert) {
 				queue.Enqueue(op.caQueue.GetQueue(), obj)
 			}
+			op.enqueueAlertPlugins(obj)
 		},
 		UpdateFunc: func(oldObj interface{}, newObj interface{}) {
 			old := oldObj.(*api.ClusterAlert)
 			nu := newObj.(*api.ClusterAlert)
 
+			if alertPluginsChanged(old.ObjectMeta, nu.ObjectMeta, old.Spec, nu.Spec) {
+				op.enqueueAlertPlugins(old, nu)
+			}
+			if nu.DeletionTimestamp != nil {
+				queue.Enqueue(op.caQueue.GetQueue(), nu)
+				return
+			}
 			if reflect.DeepEqual(old.Spec, nu.Spec) {
 				return
 			}
@@ -35,6 +43,7 @@ func (op *Operator) initClusterAlertWatcher() {
 		},
 		DeleteFunc: func(obj interface{}) {
 			queue.Enqueue(op.caQueue.GetQueue(), obj)
+			op.enqueueAlertPlugins(obj)
 		},
 	})
 	op.caLister = op.monInformerFactory.Monitoring().V1alpha1().ClusterAlerts().Lister()
@@ -57,9 +66,17 @@ func (op *Operator) reconcileClusterAlert(key string) error {
 	}
 
 	alert := obj.(*api.ClusterAlert).DeepCopy()
+	if deleting, err := op.ensureAlertFinalizer(alert); deleting || err != nil {
+		return err
+	}
 	log.Infof("Sync/Add/Update for ClusterAlert %s\n", alert.GetName())
 
-	err = op.clusterHost.Apply(alert)
+	merged, err := withTemplate(op.atLister, alert)
+	if err == nil {
+		err = op.clusterHost.Apply(merged.(*api.ClusterAlert))
+	}
+	op.targetErrors.set(alertStatusKey(api.ResourceKindClusterAlert, alert.Namespace, alert.Name), op.clusterHost.GetHost(alert.Namespace), err)
+	op.enqueueAlertStatus(api.ResourceKindClusterAlert, alert.Namespace, alert.Name)
 	if err != nil {
 		op.recorder.Eventf(
 			alert.ObjectReference(),
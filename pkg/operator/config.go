@@ -5,12 +5,15 @@ import (
 
 	cs "github.com/appscode/searchlight/client/clientset/versioned"
 	mon_informers "github.com/appscode/searchlight/client/informers/externalversions"
+	"github.com/appscode/searchlight/pkg/archive"
 	"github.com/appscode/searchlight/pkg/eventer"
 	"github.com/appscode/searchlight/pkg/icinga"
+	"github.com/prometheus/client_golang/prometheus"
 	crd_cs "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	reg_util "kmodules.xyz/client-go/admissionregistration/v1beta1"
 	"kmodules.xyz/client-go/discovery"
 	hooks "kmodules.xyz/webhook-runtime/admission/v1beta1"
@@ -26,7 +29,20 @@ type Config struct {
 	ResyncPeriod     time.Duration
 	MaxNumRequeues   int
 	NumThreads       int
-	IncidentTTL      time.Duration
+	// Incident retention, namespaces may override it with annotations
+	IncidentTTL         time.Duration
+	IncidentStaleTTL    time.Duration
+	IncidentMaxPerAlert int
+	IncidentGCPeriod    time.Duration
+	// Location expired Incidents are archived to before they are deleted, empty disables archival
+	IncidentArchive string
+	// How frequently Icinga objects are compared with alerts, 0 disables drift reconciliation
+	DriftReconcilePeriod time.Duration
+	// If true, only the replica holding the leader Lease reconciles alerts and writes Icinga config
+	LeaderElect                 bool
+	LeaderElectionLeaseDuration time.Duration
+	LeaderElectionRenewDeadline time.Duration
+	LeaderElectionRetryPeriod   time.Duration
 	// V logging level, the value of the -v flag
 	Verbosity string
 }
@@ -65,9 +81,22 @@ func (c *OperatorConfig) New() (*Operator, error) {
 		clusterHost:         icinga.NewClusterHost(c.IcingaClient, c.Verbosity),
 		nodeHost:            icinga.NewNodeHost(c.IcingaClient, c.Verbosity),
 		podHost:             icinga.NewPodHost(c.IcingaClient, c.Verbosity),
+		workloadHost:        icinga.NewWorkloadHost(c.IcingaClient, c.Verbosity),
+		globalHost:          icinga.NewGlobalHost(c.IcingaClient, c.Verbosity),
+		downtimes:           icinga.NewDowntimeManager(c.IcingaClient),
+		timePeriods:         icinga.NewTimePeriodManager(c.IcingaClient),
+		inventory:           icinga.NewInventory(c.IcingaClient),
+		checkCommands:       icinga.NewConfigPackageManager(c.IcingaClient, checkCommandPackage),
 		recorder:            eventer.NewEventRecorder(c.KubeClient, "Searchlight operator"),
 	}
 
+	if c.IncidentArchive != "" {
+		var err error
+		if op.incidentArchive, err = archive.New(c.IncidentArchive); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := op.ensureCustomResourceDefinitions(); err != nil {
 		return nil, err
 	}
@@ -80,6 +109,64 @@ func (c *OperatorConfig) New() (*Operator, error) {
 	op.initClusterAlertWatcher()
 	op.initNodeAlertWatcher()
 	op.initPodAlertWatcher()
+	op.initRolloutWatcher()
+	op.initWorkloadWatcher()
+	op.initWorkloadAlertWatcher()
+	op.initGlobalAlertWatcher()
+	op.initSilenceWatcher()
+	op.initTimePeriodWatcher()
+	op.initAlertTemplateWatcher()
 	op.initPluginWatcher()
+	op.initAlertStatusWatcher()
+	op.initIncidentWatcher()
+
+	if err := prometheus.Register(alertCollector{op}); err != nil {
+		return nil, err
+	}
 	return op, nil
 }
+
+// NewPlanner returns an Operator which only watches alerts, their targets and SearchlightPlugins, to plan
+// changes of Icinga objects without making them, or to run migrations from the command line. It registers
+// no CRDs, queues or event handlers and records no events. With a nil IcingaClient, changes are planned
+// against an empty Icinga.
+func (c *OperatorConfig) NewPlanner() *Operator {
+	op := &Operator{
+		Config:              c.Config,
+		clientConfig:        c.ClientConfig,
+		kubeClient:          c.KubeClient,
+		kubeInformerFactory: informers.NewSharedInformerFactory(c.KubeClient, c.ResyncPeriod),
+		extClient:           c.ExtClient,
+		monInformerFactory:  mon_informers.NewSharedInformerFactory(c.ExtClient, c.ResyncPeriod),
+		icingaClient:        c.IcingaClient,
+		clusterHost:         icinga.NewClusterHost(c.IcingaClient, c.Verbosity),
+		nodeHost:            icinga.NewNodeHost(c.IcingaClient, c.Verbosity),
+		podHost:             icinga.NewPodHost(c.IcingaClient, c.Verbosity),
+		workloadHost:        icinga.NewWorkloadHost(c.IcingaClient, c.Verbosity),
+		globalHost:          icinga.NewGlobalHost(c.IcingaClient, c.Verbosity),
+		inventory:           icinga.NewInventory(c.IcingaClient),
+		checkCommands:       icinga.NewConfigPackageManager(c.IcingaClient, checkCommandPackage),
+		recorder:            &record.FakeRecorder{},
+	}
+
+	core := op.kubeInformerFactory.Core().V1()
+	op.nsLister = core.Namespaces().Lister()
+	op.nodeLister = core.Nodes().Lister()
+	op.podLister = core.Pods().Lister()
+
+	apps := op.kubeInformerFactory.Apps().V1()
+	op.deployLister = apps.Deployments().Lister()
+	op.stsLister = apps.StatefulSets().Lister()
+	op.dsLister = apps.DaemonSets().Lister()
+	op.rsLister = apps.ReplicaSets().Lister()
+
+	mon := op.monInformerFactory.Monitoring().V1alpha1()
+	op.caInformer, op.caLister = mon.ClusterAlerts().Informer(), mon.ClusterAlerts().Lister()
+	op.naInformer, op.naLister = mon.NodeAlerts().Informer(), mon.NodeAlerts().Lister()
+	op.paInformer, op.paLister = mon.PodAlerts().Informer(), mon.PodAlerts().Lister()
+	op.waInformer, op.waLister = mon.WorkloadAlerts().Informer(), mon.WorkloadAlerts().Lister()
+	op.gaInformer, op.gaLister = mon.GlobalAlerts().Informer(), mon.GlobalAlerts().Lister()
+	op.atInformer, op.atLister = mon.AlertTemplates().Informer(), mon.AlertTemplates().Lister()
+	op.pluginInformer, op.pluginLister = mon.SearchlightPlugins().Informer(), mon.SearchlightPlugins().Lister()
+	return op
+}
@@ -7,13 +7,16 @@ import (
 	cs "github.com/appscode/searchlight/client/clientset/versioned"
 	mon_informers "github.com/appscode/searchlight/client/informers/externalversions"
 	mon_listers "github.com/appscode/searchlight/client/listers/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/pkg/archive"
 	"github.com/appscode/searchlight/pkg/icinga"
 	"github.com/golang/glog"
 	crd_api "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	ecs "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	apps_listers "k8s.io/client-go/listers/apps/v1"
 	core_listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
@@ -32,10 +35,15 @@ type Operator struct {
 	extClient    cs.Interface
 	icingaClient *icinga.Client // TODO: init
 
-	clusterHost *icinga.ClusterHost
-	nodeHost    *icinga.NodeHost
-	podHost     *icinga.PodHost
-	recorder    record.EventRecorder
+	clusterHost  *icinga.ClusterHost
+	nodeHost     *icinga.NodeHost
+	podHost      *icinga.PodHost
+	workloadHost *icinga.WorkloadHost
+	globalHost   *icinga.GlobalHost
+	downtimes    *icinga.DowntimeManager
+	timePeriods  *icinga.TimePeriodManager
+	inventory    *icinga.Inventory
+	recorder     record.EventRecorder
 
 	kubeInformerFactory informers.SharedInformerFactory
 	monInformerFactory  mon_informers.SharedInformerFactory
@@ -54,6 +62,19 @@ type Operator struct {
 	podInformer cache.SharedIndexInformer
 	podLister   core_listers.PodLister
 
+	// Workloads
+	deployInformer cache.SharedIndexInformer
+	deployLister   apps_listers.DeploymentLister
+	stsInformer    cache.SharedIndexInformer
+	stsLister      apps_listers.StatefulSetLister
+	dsInformer     cache.SharedIndexInformer
+	dsLister       apps_listers.DaemonSetLister
+	rsLister       apps_listers.ReplicaSetLister
+
+	// Rollouts of Deployments and StatefulSets
+	rolloutQueue *queue.Worker
+	rollouts     *rolloutStarts
+
 	// ClusterAlert
 	caQueue    *queue.Worker
 	caInformer cache.SharedIndexInformer
@@ -69,10 +90,44 @@ type Operator struct {
 	paInformer cache.SharedIndexInformer
 	paLister   mon_listers.PodAlertLister
 
+	// WorkloadAlert
+	waQueue    *queue.Worker
+	waInformer cache.SharedIndexInformer
+	waLister   mon_listers.WorkloadAlertLister
+
+	// GlobalAlert
+	gaQueue    *queue.Worker
+	gaInformer cache.SharedIndexInformer
+	gaLister   mon_listers.GlobalAlertLister
+
+	// Silence
+	silenceQueue    *queue.Worker
+	silenceInformer cache.SharedIndexInformer
+	silenceLister   mon_listers.SilenceLister
+
+	// TimePeriod
+	tpQueue    *queue.Worker
+	tpInformer cache.SharedIndexInformer
+	tpLister   mon_listers.TimePeriodLister
+
+	// AlertTemplate
+	atInformer cache.SharedIndexInformer
+	atLister   mon_listers.AlertTemplateLister
+
 	// SearchlightPlugin
 	pluginQueue    *queue.Worker
 	pluginInformer cache.SharedIndexInformer
 	pluginLister   mon_listers.SearchlightPluginLister
+	checkCommands  *icinga.ConfigPackageManager
+	commandState   *checkCommandState
+
+	// Alert status
+	statusQueue  *queue.Worker
+	targetErrors *targetErrors
+
+	// Incident
+	incidentLister  mon_listers.IncidentLister
+	incidentArchive archive.Sink
 }
 
 func (op *Operator) ensureCustomResourceDefinitions() error {
@@ -80,16 +135,22 @@ func (op *Operator) ensureCustomResourceDefinitions() error {
 		api.ClusterAlert{}.CustomResourceDefinition(),
 		api.NodeAlert{}.CustomResourceDefinition(),
 		api.PodAlert{}.CustomResourceDefinition(),
+		api.WorkloadAlert{}.CustomResourceDefinition(),
+		api.GlobalAlert{}.CustomResourceDefinition(),
+		api.Silence{}.CustomResourceDefinition(),
+		api.TimePeriod{}.CustomResourceDefinition(),
+		api.AlertTemplate{}.CustomResourceDefinition(),
+		api.NotifierConfig{}.CustomResourceDefinition(),
 		api.Incident{}.CustomResourceDefinition(),
 		api.SearchlightPlugin{}.CustomResourceDefinition(),
 	}
 	return apiext_util.RegisterCRDs(op.crdClient, crds)
 }
 
-func (op *Operator) RunInformers(stopCh <-chan struct{}) {
-	defer runtime.HandleCrash()
-
-	glog.Info("Starting Searchlight controller")
+// RunInformers starts the informers and waits for their caches to be synced. Informers run on
+// every replica, so that a replica becoming leader starts with warm caches.
+func (op *Operator) RunInformers(stopCh <-chan struct{}) error {
+	glog.Info("Starting Searchlight informers")
 
 	go op.kubeInformerFactory.Start(stopCh)
 	go op.monInformerFactory.Start(stopCh)
@@ -97,47 +158,64 @@ func (op *Operator) RunInformers(stopCh <-chan struct{}) {
 	// Wait for all involved caches to be synced, before processing items from the queue is started
 	for _, v := range op.kubeInformerFactory.WaitForCacheSync(stopCh) {
 		if !v {
-			runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
-			return
+			return fmt.Errorf("timed out waiting for caches to sync")
 		}
 	}
 	for _, v := range op.monInformerFactory.WaitForCacheSync(stopCh) {
 		if !v {
-			runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
-			return
+			return fmt.Errorf("timed out waiting for caches to sync")
 		}
 	}
+	return nil
+}
+
+// RunControllers applies pending migrations, creates the builtin SearchlightPlugins and processes the queues
+// until stopCh is closed. With leader election enabled, it only runs on the leader.
+func (op *Operator) RunControllers(stopCh <-chan struct{}) error {
+	defer runtime.HandleCrash()
+
+	op.runMigrations(stopCh)
+
+	op.runIncidentGC(stopCh)
+
+	// Create build-in SearchlighPlugin
+	if err := op.createBuiltinSearchlightPlugin(); err != nil {
+		return err
+	}
+
+	glog.Info("Starting Searchlight controller")
 
 	op.nodeQueue.Run(stopCh)
 	op.podQueue.Run(stopCh)
 	op.caQueue.Run(stopCh)
 	op.naQueue.Run(stopCh)
 	op.paQueue.Run(stopCh)
+	op.waQueue.Run(stopCh)
+	op.gaQueue.Run(stopCh)
+	op.silenceQueue.Run(stopCh)
+	op.tpQueue.Run(stopCh)
 	op.pluginQueue.Run(stopCh)
+	op.statusQueue.Run(stopCh)
+	op.rolloutQueue.Run(stopCh)
+
+	go wait.Until(op.refreshAlertStatus, op.ResyncPeriod, stopCh)
+	op.runDriftReconciler(stopCh)
 
 	<-stopCh
 	glog.Info("Stopping Searchlight controller")
+	return nil
 }
 
 func (op *Operator) Run(stopCh <-chan struct{}) error {
-	err := op.MigrateAlerts()
-	if err != nil {
-		return err
-	}
-
-	op.gcIncidents()
+	// CA bundle of the admission webhook is synced by every replica
+	cancel, _ := reg_util.SyncValidatingWebhookCABundle(op.clientConfig, validatingWebhook)
+	defer cancel()
 
-	// Create build-in SearchlighPlugin
-	if err := op.createBuiltinSearchlightPlugin(); err != nil {
+	if err := op.RunInformers(stopCh); err != nil {
 		return err
 	}
-
-	go op.RunInformers(stopCh)
-
-	cancel, _ := reg_util.SyncValidatingWebhookCABundle(op.clientConfig, validatingWebhook)
-
-	<-stopCh
-
-	cancel()
-	return nil
+	if op.LeaderElect {
+		return op.runLeaderElection(stopCh, op.RunControllers)
+	}
+	return op.RunControllers(stopCh)
 }
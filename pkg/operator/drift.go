@@ -0,0 +1,556 @@
+package operator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/appscode/go/log"
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/pkg/eventer"
+	"github.com/appscode/searchlight/pkg/icinga"
+	"github.com/appscode/searchlight/pkg/metrics"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"kmodules.xyz/client-go/tools/queue"
+)
+
+const (
+	driftObjectHost         = "host"
+	driftObjectService      = "service"
+	driftObjectNotification = "notification"
+
+	driftActionCreate = "create"
+	driftActionUpdate = "update"
+	driftActionDelete = "delete"
+)
+
+// driftTarget is an Icinga service which should exist according to the informer caches.
+type driftTarget struct {
+	alert api.Alert
+	host  icinga.IcingaHost
+	// attrs are the attributes of the service, except its check command
+	attrs map[string]interface{}
+	// event is the object to record events of corrections on
+	event runtime.Object
+	// enqueue enqueues the object whose reconciliation applies the alert to the host
+	enqueue func()
+}
+
+// driftState is the set of Icinga services which should exist, keyed by host and service name.
+// Services of alerts which can't be validated at the moment are ignored rather than deleted.
+type driftState struct {
+	desired map[string]map[string]*driftTarget
+	ignored map[string]sets.String
+}
+
+func newDriftState() *driftState {
+	return &driftState{
+		desired: map[string]map[string]*driftTarget{},
+		ignored: map[string]sets.String{},
+	}
+}
+
+// ignoreKey groups hosts whose services are named after alerts of the same kind and namespace.
+func ignoreKey(kh icinga.IcingaHost) string {
+	if kh.Type == icinga.TypeGlobal {
+		return kh.Type
+	}
+	return kh.Type + "/" + kh.AlertNamespace
+}
+
+func (s *driftState) add(t *driftTarget) {
+	host, _ := t.host.Name()
+	if s.desired[host] == nil {
+		s.desired[host] = map[string]*driftTarget{}
+	}
+	s.desired[host][t.alert.GetName()] = t
+}
+
+func (s *driftState) ignore(kh icinga.IcingaHost, alertName string) {
+	key := ignoreKey(kh)
+	if s.ignored[key] == nil {
+		s.ignored[key] = sets.NewString()
+	}
+	s.ignored[key].Insert(alertName)
+}
+
+func (s *driftState) isIgnored(kh icinga.IcingaHost, svc string) bool {
+	return s.ignored[ignoreKey(kh)].Has(svc)
+}
+
+// driftSummary counts the corrections made for an alert, so that they are reported as a single event.
+type driftSummary struct {
+	event              runtime.Object
+	missing, divergent int
+}
+
+// reconcileDrift compares the Icinga hosts, services and notifications with the alerts in the informer
+// caches. Missing and divergent objects are re-applied by enqueueing the objects owning them, and objects
+// of Searchlight hosts which don't belong to any alert are deleted.
+func (op *Operator) reconcileDrift() {
+	if err := op.correctDrift(); err != nil {
+		metrics.DriftRuns.WithLabelValues("failure").Inc()
+		log.Errorf("failed to reconcile drift of Icinga objects. reason: %s", err)
+		return
+	}
+	metrics.DriftRuns.WithLabelValues("success").Inc()
+	metrics.DriftLastRun.SetToCurrentTime()
+}
+
+func (op *Operator) correctDrift() error {
+	// Icinga is listed before the caches are read, so that objects created in the meantime
+	// are always backed by a cache at least as recent as themselves.
+	hosts, err := op.inventory.Hosts()
+	if err != nil {
+		return err
+	}
+	services, err := op.inventory.Services()
+	if err != nil {
+		return err
+	}
+	notifications, err := op.inventory.Notifications()
+	if err != nil {
+		return err
+	}
+	state, err := op.desiredIcingaState()
+	if err != nil {
+		return err
+	}
+
+	summaries := map[string]*driftSummary{}
+	summaryOf := func(t *driftTarget) *driftSummary {
+		key := fmt.Sprintf("%T/%s/%s", t.alert, t.alert.GetNamespace(), t.alert.GetName())
+		if api.IsAnnotationAlert(t.alert.GetName()) {
+			// alerts declared in annotations of different pods may share a name
+			key += "/" + t.host.ObjectName
+		}
+		if s, ok := summaries[key]; ok {
+			return s
+		}
+		s := &driftSummary{event: t.event}
+		summaries[key] = s
+		return s
+	}
+	corrected := map[string]int{}
+	count := func(object, action string) {
+		metrics.DriftCorrections.WithLabelValues(object, action).Inc()
+		corrected[object+" "+action]++
+	}
+
+	// hosts whose orphaned services couldn't be deleted are kept
+	failed := sets.NewString()
+	for _, c := range diffIcingaState(hosts, services, notifications, state) {
+		switch {
+		case c.target == nil && c.object == driftObjectService:
+			log.Infof("deleting orphaned Icinga service %s", c.name())
+			if err := op.inventory.DeleteService(c.host, c.service); err != nil {
+				log.Errorln(err)
+				failed.Insert(c.host)
+				continue
+			}
+		case c.target == nil && c.object == driftObjectHost && c.action == driftActionDelete:
+			if failed.Has(c.host) {
+				continue
+			}
+			log.Infof("deleting orphaned Icinga host %s", c.host)
+			if err := op.inventory.DeleteHost(c.host); err != nil {
+				log.Errorln(err)
+				continue
+			}
+		case c.target == nil:
+			// missing hosts are created along with their services
+		default:
+			log.Infof("reapplying Icinga %s %s: %s", c.object, c.name(), c.reason)
+			if c.recreate {
+				// check command of a service can't be updated, so it is recreated
+				if err := op.inventory.DeleteService(c.host, c.service); err != nil {
+					log.Errorln(err)
+					continue
+				}
+			}
+			c.target.enqueue()
+			if c.action == driftActionCreate {
+				summaryOf(c.target).missing++
+			} else {
+				summaryOf(c.target).divergent++
+			}
+		}
+		count(c.object, c.action)
+	}
+
+	for _, s := range summaries {
+		op.recorder.Eventf(
+			s.event,
+			core.EventTypeNormal,
+			eventer.EventReasonDriftCorrected,
+			`Reapplied %d missing and %d divergent Icinga objects`,
+			s.missing, s.divergent,
+		)
+	}
+	if len(corrected) > 0 {
+		var parts []string
+		for k, n := range corrected {
+			parts = append(parts, fmt.Sprintf("%s: %d", k, n))
+		}
+		log.Infof("corrected drift of Icinga objects: %s", strings.Join(parts, ", "))
+	}
+	return nil
+}
+
+// icingaChange is a difference between the Icinga objects and the alerts in the informer caches.
+type icingaChange struct {
+	object  string
+	action  string
+	host    string
+	service string
+	reason  string
+	// recreate is set for services whose check command changed, which can't be updated in place
+	recreate bool
+	// target is the desired service, nil for hosts and orphaned services
+	target *driftTarget
+}
+
+// name returns the full Icinga name of the changed object.
+func (c icingaChange) name() string {
+	switch c.object {
+	case driftObjectHost:
+		return c.host
+	case driftObjectNotification:
+		return c.host + "!" + c.service + "!" + c.service
+	}
+	return c.host + "!" + c.service
+}
+
+// diffIcingaState returns the changes which turn the given Icinga objects into the desired state.
+// Orphaned services are deleted before the hosts left without services.
+func diffIcingaState(hosts []string, services []icinga.Service, notifications []icinga.Notification, state *driftState) []icingaChange {
+	var changes []icingaChange
+
+	notificationAttrs := make(map[string]map[string]interface{}, len(notifications))
+	for _, n := range notifications {
+		notificationAttrs[n.Name] = n.Attrs
+	}
+	actual := map[string]sets.String{}
+	for _, svc := range services {
+		kh, err := icinga.ParseHost(svc.HostName)
+		if err != nil {
+			continue // not managed by Searchlight
+		}
+		if actual[svc.HostName] == nil {
+			actual[svc.HostName] = sets.NewString()
+		}
+
+		t, ok := state.desired[svc.HostName][svc.Name]
+		if !ok {
+			if state.isIgnored(*kh, svc.Name) {
+				actual[svc.HostName].Insert(svc.Name)
+				continue
+			}
+			changes = append(changes, icingaChange{
+				object: driftObjectService, action: driftActionDelete, host: svc.HostName, service: svc.Name,
+				reason: "no alert applies to the host",
+			})
+			continue
+		}
+		actual[svc.HostName].Insert(svc.Name)
+
+		change := icingaChange{object: driftObjectService, action: driftActionUpdate, host: svc.HostName, service: svc.Name, target: t}
+		nAttrs, hasNotification := notificationAttrs[svc.HostName+"!"+svc.Name+"!"+svc.Name]
+		if svc.CheckCommand != t.alert.Command() {
+			change.recreate = true
+			change.reason = fmt.Sprintf("check command %s instead of %s", t.alert.Command(), svc.CheckCommand)
+		} else if keys := icinga.DiffAttrs(svc.Attrs, t.attrs); len(keys) > 0 {
+			change.reason = fmt.Sprintf("attributes %s differ", strings.Join(keys, ", "))
+		} else if !hasNotification {
+			change.object = driftObjectNotification
+			change.action = driftActionCreate
+			change.reason = "notification is missing"
+		} else if keys := icinga.DiffAttrs(nAttrs, icinga.NotificationAttrs(t.alert)); len(keys) > 0 {
+			change.object = driftObjectNotification
+			change.reason = fmt.Sprintf("attributes %s differ", strings.Join(keys, ", "))
+		} else {
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	existing := sets.NewString(hosts...)
+	desiredHosts := make([]string, 0, len(state.desired))
+	for host := range state.desired {
+		desiredHosts = append(desiredHosts, host)
+	}
+	sort.Strings(desiredHosts)
+	for _, host := range desiredHosts {
+		if !existing.Has(host) {
+			changes = append(changes, icingaChange{object: driftObjectHost, action: driftActionCreate, host: host, reason: "host is missing"})
+		}
+		targets := state.desired[host]
+		names := make([]string, 0, len(targets))
+		for name := range targets {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if actual[host].Has(name) {
+				continue
+			}
+			changes = append(changes, icingaChange{
+				object: driftObjectService, action: driftActionCreate, host: host, service: name,
+				reason: "service is missing", target: targets[name],
+			})
+		}
+	}
+
+	for _, host := range hosts {
+		if _, err := icinga.ParseHost(host); err != nil {
+			continue // not managed by Searchlight
+		}
+		if _, ok := state.desired[host]; ok || actual[host].Len() > 0 {
+			continue
+		}
+		changes = append(changes, icingaChange{object: driftObjectHost, action: driftActionDelete, host: host, reason: "no alert applies to the host"})
+	}
+	return changes
+}
+
+// desiredIcingaState computes the Icinga services which should exist from the alerts and targets in the informer caches.
+func (op *Operator) desiredIcingaState() (*driftState, error) {
+	state := newDriftState()
+
+	nodes, err := op.nodeLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	pods, err := op.podLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	enqueuePod := func(pod *core.Pod) func() {
+		return func() { queue.Enqueue(op.podQueue.GetQueue(), pod) }
+	}
+
+	cas, err := op.caLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, ca := range cas {
+		kh := op.clusterHost.GetHost(ca.Namespace)
+		merged, valid := op.driftAlert(ca)
+		if !valid {
+			state.ignore(kh, ca.Name)
+			continue
+		}
+		if ca.Spec.Paused || ca.DeletionTimestamp != nil {
+			continue
+		}
+		attrs, err := op.clusterHost.ServiceAttrs(merged.(*api.ClusterAlert))
+		if err != nil {
+			state.ignore(kh, ca.Name)
+			continue
+		}
+		alert := ca
+		state.add(&driftTarget{alert: merged, host: kh, attrs: attrs, event: alert.ObjectReference(), enqueue: func() {
+			queue.Enqueue(op.caQueue.GetQueue(), alert)
+		}})
+	}
+
+	nas, err := op.naLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, na := range nas {
+		merged, valid := op.driftAlert(na)
+		if !valid {
+			state.ignore(icinga.IcingaHost{Type: icinga.TypeNode, AlertNamespace: na.Namespace}, na.Name)
+			continue
+		}
+		if na.Spec.Paused || na.DeletionTimestamp != nil {
+			continue
+		}
+		for _, node := range nodes {
+			if ok, err := merged.(*api.NodeAlert).MatchesNode(node); err != nil || !ok {
+				continue
+			}
+			kh := op.nodeHost.GetHost(na.Namespace, node)
+			attrs, err := op.nodeHost.ServiceAttrs(merged.(*api.NodeAlert), node)
+			if err != nil {
+				state.ignore(kh, na.Name)
+				continue
+			}
+			node := node
+			state.add(&driftTarget{alert: merged, host: kh, attrs: attrs, event: na.ObjectReference(), enqueue: func() {
+				queue.Enqueue(op.nodeQueue.GetQueue(), node)
+			}})
+		}
+	}
+
+	pas, err := op.paLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, pa := range pas {
+		merged, valid := op.driftAlert(pa)
+		if !valid {
+			state.ignore(icinga.IcingaHost{Type: icinga.TypePod, AlertNamespace: pa.Namespace}, pa.Name)
+			continue
+		}
+		alert := merged.(*api.PodAlert)
+		if alert.Spec.Paused || alert.DeletionTimestamp != nil {
+			continue
+		}
+		var sel labels.Selector
+		if alert.Spec.PodName == nil {
+			if sel, err = metav1.LabelSelectorAsSelector(alert.Spec.Selector); err != nil {
+				state.ignore(icinga.IcingaHost{Type: icinga.TypePod, AlertNamespace: pa.Namespace}, pa.Name)
+				continue
+			}
+		}
+		for _, pod := range pods {
+			if pod.Namespace != alert.Namespace {
+				continue
+			}
+			if alert.Spec.PodName != nil && *alert.Spec.PodName != pod.Name {
+				continue
+			}
+			if sel != nil && !sel.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+			kh := op.podHost.GetHost(alert.Namespace, pod)
+			attrs, err := op.podHost.ServiceAttrs(alert, pod)
+			if err != nil {
+				state.ignore(kh, pa.Name)
+				continue
+			}
+			state.add(&driftTarget{alert: alert, host: kh, attrs: attrs, event: pa.ObjectReference(), enqueue: enqueuePod(pod)})
+		}
+	}
+
+	// Alerts declared in pod annotations are only applied if valid, which is recorded in the alerts annotation
+	for _, pod := range pods {
+		kh := op.podHost.GetHost(pod.Namespace, pod)
+		for _, name := range strings.Split(pod.Annotations[api.AnnotationKeyAlerts], ",") {
+			if !api.IsAnnotationAlert(name) {
+				continue
+			}
+			alert, err := api.GetAnnotationAlert(pod, name)
+			if err != nil {
+				state.ignore(kh, name)
+				continue
+			}
+			merged, err := withTemplate(op.atLister, alert)
+			if err != nil {
+				state.ignore(kh, name)
+				continue
+			}
+			if merged.(*api.PodAlert).Spec.Paused {
+				continue
+			}
+			attrs, err := op.podHost.ServiceAttrs(merged.(*api.PodAlert), pod)
+			if err != nil {
+				state.ignore(kh, name)
+				continue
+			}
+			state.add(&driftTarget{alert: merged, host: kh, attrs: attrs, event: pod, enqueue: enqueuePod(pod)})
+		}
+	}
+
+	gas, err := op.gaLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, ga := range gas {
+		if _, valid := op.driftAlert(ga); !valid {
+			state.ignore(icinga.IcingaHost{Type: icinga.TypeGlobal}, ga.Name)
+			continue
+		}
+		if ga.Spec.Paused || ga.DeletionTimestamp != nil {
+			continue
+		}
+		nsSel, err := ga.NamespaceSelector()
+		if err != nil {
+			continue
+		}
+		sel, err := metav1.LabelSelectorAsSelector(ga.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		for _, pod := range pods {
+			ns, err := op.nsLister.Get(pod.Namespace)
+			if err != nil || !nsSel.Matches(labels.Set(ns.Labels)) || !sel.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+			kh := op.globalHost.GetHost(pod)
+			attrs, err := op.globalHost.ServiceAttrs(ga, pod)
+			if err != nil {
+				state.ignore(kh, ga.Name)
+				continue
+			}
+			state.add(&driftTarget{alert: ga, host: kh, attrs: attrs, event: ga.ObjectReference(), enqueue: enqueuePod(pod)})
+		}
+	}
+
+	was, err := op.waLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, wa := range was {
+		merged, valid := op.driftAlert(wa)
+		if !valid {
+			state.ignore(icinga.IcingaHost{Type: icinga.TypeWorkload, AlertNamespace: wa.Namespace}, wa.Name)
+			continue
+		}
+		if wa.Spec.Paused || wa.DeletionTimestamp != nil {
+			continue
+		}
+		kids, err := op.workloadAlertTargets(merged.(*api.WorkloadAlert))
+		if err != nil {
+			state.ignore(icinga.IcingaHost{Type: icinga.TypeWorkload, AlertNamespace: wa.Namespace}, wa.Name)
+			continue
+		}
+		attrs, err := op.workloadHost.ServiceAttrs(merged.(*api.WorkloadAlert))
+		if err != nil {
+			state.ignore(icinga.IcingaHost{Type: icinga.TypeWorkload, AlertNamespace: wa.Namespace}, wa.Name)
+			continue
+		}
+		alert := wa
+		for _, kh := range kids {
+			state.add(&driftTarget{alert: merged, host: kh, attrs: attrs, event: alert.ObjectReference(), enqueue: func() {
+				queue.Enqueue(op.waQueue.GetQueue(), alert)
+			}})
+		}
+	}
+	return state, nil
+}
+
+// driftAlert returns an alert merged with its AlertTemplate and whether it is valid. Services of invalid
+// alerts are left alone, since validation may fail temporarily, e.g. while reading notifier secrets.
+func (op *Operator) driftAlert(alert api.Alert) (api.Alert, bool) {
+	merged, err := withTemplate(op.atLister, alert)
+	if err != nil {
+		return nil, false
+	}
+	return merged, merged.IsValid(op.kubeClient) == nil
+}
+
+// runDriftReconciler periodically reconciles drift of Icinga objects until stopCh is closed.
+func (op *Operator) runDriftReconciler(stopCh <-chan struct{}) {
+	if op.DriftReconcilePeriod <= 0 {
+		log.Warningln("skipping drift reconciliation of Icinga objects")
+		return
+	}
+	go func() {
+		// the first run is delayed, so that the queues apply the alerts after a restart first
+		select {
+		case <-time.After(op.DriftReconcilePeriod):
+		case <-stopCh:
+			return
+		}
+		wait.Until(op.reconcileDrift, op.DriftReconcilePeriod, stopCh)
+	}()
+}
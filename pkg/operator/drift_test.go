@@ -0,0 +1,247 @@
+package operator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/pkg/icinga"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	driftHost  = "demo@pod@web-0"
+	driftAlert = "pod-exec-demo"
+)
+
+// testDriftState returns the desired state of a PodAlert applied to a single pod.
+func testDriftState(t *testing.T) *driftState {
+	api.PodCommands.Insert("pod-exec", api.IcingaCommand{
+		Name: "pod-exec",
+		Vars: &api.PluginVars{Fields: map[string]api.PluginVarField{"cmd": {Type: api.VarTypeString}}},
+	})
+	alert := &api.PodAlert{
+		ObjectMeta: metav1.ObjectMeta{Name: driftAlert, Namespace: "demo"},
+		Spec: api.PodAlertSpec{
+			Check:            "pod-exec",
+			CheckInterval:    metav1.Duration{Duration: time.Minute},
+			AlertInterval:    metav1.Duration{Duration: 5 * time.Minute},
+			MaxCheckAttempts: 3,
+			RetryInterval:    metav1.Duration{Duration: 30 * time.Second},
+			Vars:             map[string]string{"cmd": "/bin/true"},
+		},
+	}
+	pod := &core.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "demo"}}
+	host := &icinga.PodHost{}
+	attrs, err := host.ServiceAttrs(alert, pod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := newDriftState()
+	state.add(&driftTarget{alert: alert, host: host.GetHost("demo", pod), attrs: attrs})
+	return state
+}
+
+// testDriftService returns the Icinga service of the PodAlert of testDriftState as listed by the Inventory.
+func testDriftService() icinga.Service {
+	return icinga.Service{
+		HostName:     driftHost,
+		Name:         driftAlert,
+		CheckCommand: "pod-exec",
+		Attrs: map[string]interface{}{
+			"check_interval":          float64(60),
+			"max_check_attempts":      float64(3),
+			"retry_interval":          float64(30),
+			"check_timeout":           nil,
+			"enable_flapping":         false,
+			"flapping_threshold_low":  float64(25),
+			"flapping_threshold_high": float64(30),
+			"vars.cmd":                "/bin/true",
+			"vars.verbosity":          "3",
+		},
+	}
+}
+
+func testDriftNotification() icinga.Notification {
+	return icinga.Notification{
+		Name:  driftHost + "!" + driftAlert + "!" + driftAlert,
+		Attrs: map[string]interface{}{"interval": float64(300), "users": []interface{}{"searchlight_user"}},
+	}
+}
+
+func TestDiffIcingaState(t *testing.T) {
+	type change struct {
+		object, action, name string
+		recreate             bool
+	}
+	cases := []struct {
+		name          string
+		hosts         []string
+		services      func(svc *icinga.Service) []icinga.Service
+		notifications func(n *icinga.Notification) []icinga.Notification
+		expected      []change
+	}{
+		{
+			name:  "in sync",
+			hosts: []string{driftHost},
+		},
+		{
+			name:  "orphaned host",
+			hosts: []string{driftHost, "demo@pod@web-1", "default@node@minikube", "localhost"},
+			expected: []change{
+				{object: driftObjectHost, action: driftActionDelete, name: "demo@pod@web-1"},
+				{object: driftObjectHost, action: driftActionDelete, name: "default@node@minikube"},
+			},
+		},
+		{
+			name:  "orphaned service",
+			hosts: []string{driftHost, "demo@pod@web-1"},
+			services: func(svc *icinga.Service) []icinga.Service {
+				orphan := *svc
+				orphan.HostName = "demo@pod@web-1"
+				return []icinga.Service{*svc, orphan, {HostName: driftHost, Name: "deleted-alert", CheckCommand: "pod-status"}}
+			},
+			expected: []change{
+				{object: driftObjectService, action: driftActionDelete, name: "demo@pod@web-1!" + driftAlert},
+				{object: driftObjectService, action: driftActionDelete, name: driftHost + "!deleted-alert"},
+				{object: driftObjectHost, action: driftActionDelete, name: "demo@pod@web-1"},
+			},
+		},
+		{
+			name:  "missing host and service",
+			hosts: []string{},
+			services: func(svc *icinga.Service) []icinga.Service {
+				return nil
+			},
+			expected: []change{
+				{object: driftObjectHost, action: driftActionCreate, name: driftHost},
+				{object: driftObjectService, action: driftActionCreate, name: driftHost + "!" + driftAlert},
+			},
+		},
+		{
+			name:  "check command changed",
+			hosts: []string{driftHost},
+			services: func(svc *icinga.Service) []icinga.Service {
+				svc.CheckCommand = "pod-status"
+				return []icinga.Service{*svc}
+			},
+			expected: []change{
+				{object: driftObjectService, action: driftActionUpdate, name: driftHost + "!" + driftAlert, recreate: true},
+			},
+		},
+		{
+			name:  "check interval changed",
+			hosts: []string{driftHost},
+			services: func(svc *icinga.Service) []icinga.Service {
+				svc.Attrs["check_interval"] = float64(300)
+				return []icinga.Service{*svc}
+			},
+			expected: []change{
+				{object: driftObjectService, action: driftActionUpdate, name: driftHost + "!" + driftAlert},
+			},
+		},
+		{
+			name:  "var changed",
+			hosts: []string{driftHost},
+			services: func(svc *icinga.Service) []icinga.Service {
+				svc.Attrs["vars.cmd"] = "/bin/false"
+				return []icinga.Service{*svc}
+			},
+			expected: []change{
+				{object: driftObjectService, action: driftActionUpdate, name: driftHost + "!" + driftAlert},
+			},
+		},
+		{
+			name:  "var missing",
+			hosts: []string{driftHost},
+			services: func(svc *icinga.Service) []icinga.Service {
+				delete(svc.Attrs, "vars.cmd")
+				return []icinga.Service{*svc}
+			},
+			expected: []change{
+				{object: driftObjectService, action: driftActionUpdate, name: driftHost + "!" + driftAlert},
+			},
+		},
+		{
+			name:  "retry attrs changed",
+			hosts: []string{driftHost},
+			services: func(svc *icinga.Service) []icinga.Service {
+				svc.Attrs["max_check_attempts"] = float64(1)
+				svc.Attrs["enable_flapping"] = true
+				return []icinga.Service{*svc}
+			},
+			expected: []change{
+				{object: driftObjectService, action: driftActionUpdate, name: driftHost + "!" + driftAlert},
+			},
+		},
+		{
+			name:  "removed check timeout",
+			hosts: []string{driftHost},
+			services: func(svc *icinga.Service) []icinga.Service {
+				svc.Attrs["check_timeout"] = float64(10)
+				return []icinga.Service{*svc}
+			},
+			expected: []change{
+				{object: driftObjectService, action: driftActionUpdate, name: driftHost + "!" + driftAlert},
+			},
+		},
+		{
+			name:  "missing notification",
+			hosts: []string{driftHost},
+			notifications: func(n *icinga.Notification) []icinga.Notification {
+				return nil
+			},
+			expected: []change{
+				{object: driftObjectNotification, action: driftActionCreate, name: driftHost + "!" + driftAlert + "!" + driftAlert},
+			},
+		},
+		{
+			name:  "notification interval changed",
+			hosts: []string{driftHost},
+			notifications: func(n *icinga.Notification) []icinga.Notification {
+				n.Attrs["interval"] = float64(1800)
+				return []icinga.Notification{*n}
+			},
+			expected: []change{
+				{object: driftObjectNotification, action: driftActionUpdate, name: driftHost + "!" + driftAlert + "!" + driftAlert},
+			},
+		},
+		{
+			name:  "notification users changed",
+			hosts: []string{driftHost},
+			notifications: func(n *icinga.Notification) []icinga.Notification {
+				n.Attrs["users"] = []interface{}{}
+				return []icinga.Notification{*n}
+			},
+			expected: []change{
+				{object: driftObjectNotification, action: driftActionUpdate, name: driftHost + "!" + driftAlert + "!" + driftAlert},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			svc, n := testDriftService(), testDriftNotification()
+			services, notifications := []icinga.Service{svc}, []icinga.Notification{n}
+			if c.services != nil {
+				services = c.services(&svc)
+			}
+			if c.notifications != nil {
+				notifications = c.notifications(&n)
+			}
+
+			var got []change
+			for _, ch := range diffIcingaState(c.hosts, services, notifications, testDriftState(t)) {
+				got = append(got, change{object: ch.object, action: ch.action, name: ch.name(), recreate: ch.recreate})
+				if ch.action != driftActionDelete && ch.object != driftObjectHost && ch.target == nil {
+					t.Errorf("change %s %s has no target", ch.action, ch.name())
+				}
+			}
+			if !reflect.DeepEqual(got, c.expected) {
+				t.Errorf("expected changes %+v, got %+v", c.expected, got)
+			}
+		})
+	}
+}
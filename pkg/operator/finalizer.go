@@ -0,0 +1,148 @@
+package operator
+
+import (
+	"github.com/appscode/go/log"
+	utilerrors "github.com/appscode/go/util/errors"
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/client/clientset/versioned/typed/monitoring/v1alpha1/util"
+	"github.com/appscode/searchlight/pkg/eventer"
+	"github.com/appscode/searchlight/pkg/icinga"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	core_util "kmodules.xyz/client-go/core/v1"
+)
+
+// ensureAlertFinalizer adds the alert finalizer to an alert, or finalizes an alert being deleted.
+// It returns true for alerts being deleted, which must not be applied to Icinga anymore.
+func (op *Operator) ensureAlertFinalizer(alert api.Alert) (bool, error) {
+	obj, err := meta.Accessor(alert)
+	if err != nil {
+		return false, err
+	}
+	has := sets.NewString(obj.GetFinalizers()...).Has(api.AlertFinalizer)
+
+	if obj.GetDeletionTimestamp() == nil {
+		if has {
+			return false, nil
+		}
+		return false, op.patchAlertFinalizer(alert, core_util.AddFinalizer)
+	}
+
+	if !has {
+		return true, nil
+	}
+	kind := alert.ObjectReference().Kind
+	if err := op.finalizeAlert(kind, alert.GetNamespace(), alert.GetName()); err != nil {
+		op.recorder.Eventf(
+			alert.ObjectReference(),
+			core.EventTypeWarning,
+			eventer.EventReasonFailedToDelete,
+			`failed to clean up Icinga objects. Reason: %v`,
+			err,
+		)
+		return true, err
+	}
+	log.Infof("Finalized %s %s/%s\n", kind, alert.GetNamespace(), alert.GetName())
+	return true, op.patchAlertFinalizer(alert, core_util.RemoveFinalizer)
+}
+
+// finalizeAlert removes the Icinga services, notifications and emptied hosts of an alert, and
+// the Incidents raised for it.
+func (op *Operator) finalizeAlert(kind, namespace, name string) error {
+	hostType, hostPattern, err := alertHostPattern(kind, namespace)
+	if err != nil {
+		return err
+	}
+	if err := op.inventory.DeleteAlert(hostPattern, name); err != nil {
+		return err
+	}
+	return op.deleteAlertIncidents(hostType, namespace, name)
+}
+
+// alertHostPattern returns the type of Icinga hosts an alert is applied to, and a pattern
+// matching the names of these hosts.
+func alertHostPattern(kind, namespace string) (string, string, error) {
+	switch kind {
+	case api.ResourceKindClusterAlert:
+		return icinga.TypeCluster, namespace + "@" + icinga.TypeCluster, nil
+	case api.ResourceKindNodeAlert:
+		return icinga.TypeNode, namespace + "@" + icinga.TypeNode + "@*", nil
+	case api.ResourceKindPodAlert:
+		return icinga.TypePod, namespace + "@" + icinga.TypePod + "@*", nil
+	case api.ResourceKindWorkloadAlert:
+		return icinga.TypeWorkload, namespace + "@" + icinga.TypeWorkload + "@*", nil
+	case api.ResourceKindGlobalAlert:
+		// GlobalAlerts are applied to pods in any namespace
+		return icinga.TypeGlobal, "*@" + icinga.TypeGlobal + "@*", nil
+	}
+	return "", "", errors.Errorf("unknown alert kind %s", kind)
+}
+
+// deleteAlertIncidents archives and deletes the Incidents raised for an alert. Incidents of GlobalAlerts
+// are created in the namespaces of their pods.
+func (op *Operator) deleteAlertIncidents(hostType, namespace, name string) error {
+	if hostType == icinga.TypeGlobal {
+		namespace = core.NamespaceAll
+	}
+	sel := labels.SelectorFromSet(labels.Set{
+		api.LabelKeyAlert:     name,
+		api.LabelKeyAlertType: hostType,
+	})
+	incidents, err := op.extClient.MonitoringV1alpha1().Incidents(namespace).List(metav1.ListOptions{LabelSelector: sel.String()})
+	if err != nil {
+		return err
+	}
+
+	var errlist []error
+	for i := range incidents.Items {
+		if err := op.archiveAndDeleteIncident(&incidents.Items[i]); err != nil {
+			errlist = append(errlist, err)
+		}
+	}
+	return utilerrors.NewAggregate(errlist)
+}
+
+// patchAlertFinalizer patches the finalizers of an alert with fn.
+func (op *Operator) patchAlertFinalizer(alert api.Alert, fn func(metav1.ObjectMeta, string) metav1.ObjectMeta) error {
+	c := op.extClient.MonitoringV1alpha1()
+
+	var err error
+	switch a := alert.(type) {
+	case *api.ClusterAlert:
+		_, _, err = util.PatchClusterAlert(c, a, func(in *api.ClusterAlert) *api.ClusterAlert {
+			in.ObjectMeta = fn(in.ObjectMeta, api.AlertFinalizer)
+			return in
+		})
+	case *api.NodeAlert:
+		_, _, err = util.PatchNodeAlert(c, a, func(in *api.NodeAlert) *api.NodeAlert {
+			in.ObjectMeta = fn(in.ObjectMeta, api.AlertFinalizer)
+			return in
+		})
+	case *api.PodAlert:
+		_, _, err = util.PatchPodAlert(c, a, func(in *api.PodAlert) *api.PodAlert {
+			in.ObjectMeta = fn(in.ObjectMeta, api.AlertFinalizer)
+			return in
+		})
+	case *api.WorkloadAlert:
+		_, _, err = util.PatchWorkloadAlert(c, a, func(in *api.WorkloadAlert) *api.WorkloadAlert {
+			in.ObjectMeta = fn(in.ObjectMeta, api.AlertFinalizer)
+			return in
+		})
+	case *api.GlobalAlert:
+		_, _, err = util.PatchGlobalAlert(c, a, func(in *api.GlobalAlert) *api.GlobalAlert {
+			in.ObjectMeta = fn(in.ObjectMeta, api.AlertFinalizer)
+			return in
+		})
+	default:
+		return errors.Errorf("unknown alert type %T", alert)
+	}
+	if kerr.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,279 @@
+package operator
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	mon_fake "github.com/appscode/searchlight/client/clientset/versioned/fake"
+	"github.com/appscode/searchlight/pkg/icinga"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+)
+
+// fakeIcinga serves the Icinga API requests made by icinga.Inventory from objects kept in memory.
+// Services and notifications are named {host}!{service}, every service has a notification.
+type fakeIcinga struct {
+	mu       sync.Mutex
+	hosts    map[string]bool
+	services map[string]bool
+	// stuck services are reported as deleted, but kept
+	stuck map[string]bool
+}
+
+func newFakeIcinga(services ...string) *fakeIcinga {
+	f := &fakeIcinga{hosts: map[string]bool{}, services: map[string]bool{}, stuck: map[string]bool{}}
+	for _, svc := range services {
+		f.hosts[strings.Split(svc, "!")[0]] = true
+		f.services[svc] = true
+	}
+	return f
+}
+
+func (f *fakeIcinga) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/v1/objects/"), "/", 2)
+	kind, name := parts[0], ""
+	if len(parts) == 2 {
+		name = parts[1]
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		var query struct {
+			Filter     string            `json:"filter"`
+			FilterVars map[string]string `json:"filter_vars"`
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &query); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		matches := func(host, svc string) bool {
+			switch query.Filter {
+			case "match(pattern,host.name)&&service.name==svc":
+				ok, _ := path.Match(query.FilterVars["pattern"], host)
+				return ok && svc == query.FilterVars["svc"]
+			case "host.name==hostName":
+				return host == query.FilterVars["hostName"]
+			}
+			return false
+		}
+		type result struct {
+			Name  string            `json:"name"`
+			Attrs map[string]string `json:"attrs"`
+		}
+		results := []result{}
+		switch kind {
+		case "hosts":
+			for host := range f.hosts {
+				if matches(host, "") {
+					results = append(results, result{Name: host, Attrs: map[string]string{"name": host}})
+				}
+			}
+		case "services", "notifications":
+			for name := range f.services {
+				parts := strings.Split(name, "!")
+				if matches(parts[0], parts[1]) {
+					results = append(results, result{Name: name, Attrs: map[string]string{"host_name": parts[0], "name": parts[1]}})
+				}
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	case http.MethodDelete:
+		switch kind {
+		case "hosts":
+			delete(f.hosts, name)
+			for svc := range f.services {
+				if strings.HasPrefix(svc, name+"!") {
+					delete(f.services, svc)
+				}
+			}
+		case "services":
+			if !f.services[name] {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if !f.stuck[name] {
+				delete(f.services, name)
+			}
+		}
+		w.Write([]byte(`{"results": []}`))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeIcinga) objects() (hosts, services []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for host := range f.hosts {
+		hosts = append(hosts, host)
+	}
+	for svc := range f.services {
+		services = append(services, svc)
+	}
+	sort.Strings(hosts)
+	sort.Strings(services)
+	return hosts, services
+}
+
+type memorySink map[string][]byte
+
+func (s memorySink) Put(key string, data []byte) error {
+	s[key] = data
+	return nil
+}
+
+func testIncidentOf(namespace, name, alert string) *api.Incident {
+	return &api.Incident{ObjectMeta: metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels:    map[string]string{api.LabelKeyAlertType: icinga.TypePod, api.LabelKeyAlert: alert},
+	}}
+}
+
+func TestAlertFinalizer(t *testing.T) {
+	cases := []struct {
+		name           string
+		stuck          string
+		expectErr      bool
+		hosts          []string
+		services       []string
+		archived       []string
+		finalizerAfter bool
+	}{
+		{
+			name:     "deleted",
+			hosts:    []string{"demo@pod@web-0", "prod@pod@web-0"},
+			services: []string{"demo@pod@web-0!pod-exec", "prod@pod@web-0!pod-status"},
+			archived: []string{"demo/pod-status.1.json", "demo/pod-status.2.json"},
+		},
+		{
+			name:           "service remains",
+			stuck:          "demo@pod@web-1!pod-status",
+			expectErr:      true,
+			hosts:          []string{"demo@pod@web-0", "demo@pod@web-1", "prod@pod@web-0"},
+			services:       []string{"demo@pod@web-0!pod-exec", "demo@pod@web-1!pod-status", "prod@pod@web-0!pod-status"},
+			finalizerAfter: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			fake := newFakeIcinga(
+				"demo@pod@web-0!pod-status",
+				"demo@pod@web-0!pod-exec",
+				"demo@pod@web-1!pod-status",
+				"prod@pod@web-0!pod-status",
+			)
+			if c.stuck != "" {
+				fake.stuck[c.stuck] = true
+			}
+			server := httptest.NewServer(fake)
+			defer server.Close()
+
+			alert := &api.PodAlert{ObjectMeta: metav1.ObjectMeta{Name: "pod-status", Namespace: "demo"}}
+			sink := memorySink{}
+			op := &Operator{
+				extClient: mon_fake.NewSimpleClientset([]runtime.Object{
+					alert,
+					testIncidentOf("demo", "pod-status.1", "pod-status"),
+					testIncidentOf("demo", "pod-status.2", "pod-status"),
+					testIncidentOf("demo", "pod-exec.1", "pod-exec"),
+					testIncidentOf("prod", "pod-status.1", "pod-status"),
+				}...),
+				inventory:       icinga.NewInventory(icinga.NewClient(icinga.Config{Endpoint: server.URL + "/v1"})),
+				incidentArchive: sink,
+				recorder:        record.NewFakeRecorder(10),
+			}
+			alerts := op.extClient.MonitoringV1alpha1().PodAlerts(alert.Namespace)
+
+			// a new alert gets the finalizer
+			deleting, err := op.ensureAlertFinalizer(alert)
+			if err != nil || deleting {
+				t.Fatalf("expected finalizer to be added to alert, got deleting %v, error %v", deleting, err)
+			}
+			cur, err := alerts.Get(alert.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(cur.Finalizers, []string{api.AlertFinalizer}) {
+				t.Fatalf("expected finalizers %v, got %v", []string{api.AlertFinalizer}, cur.Finalizers)
+			}
+
+			// a deleted alert is finalized
+			now := metav1.Now()
+			cur.DeletionTimestamp = &now
+			deleting, err = op.ensureAlertFinalizer(cur)
+			if !deleting {
+				t.Error("expected alert to be reported as deleted")
+			}
+			if (err != nil) != c.expectErr {
+				t.Errorf("expected error %v, got %v", c.expectErr, err)
+			}
+
+			hosts, services := fake.objects()
+			if !reflect.DeepEqual(hosts, c.hosts) {
+				t.Errorf("expected Icinga hosts %v, got %v", c.hosts, hosts)
+			}
+			if !reflect.DeepEqual(services, c.services) {
+				t.Errorf("expected Icinga services %v, got %v", c.services, services)
+			}
+
+			var archived []string
+			for key := range sink {
+				archived = append(archived, key)
+			}
+			sort.Strings(archived)
+			if !reflect.DeepEqual(archived, c.archived) {
+				t.Errorf("expected archived Incidents %v, got %v", c.archived, archived)
+			}
+			incidents, err := op.extClient.MonitoringV1alpha1().Incidents(metav1.NamespaceAll).List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := 4 - len(c.archived); len(incidents.Items) != n {
+				t.Errorf("expected %d Incidents to remain, got %d", n, len(incidents.Items))
+			}
+
+			// the fake clientset keeps fields removed by merge patches, so the last patch of the alert is checked
+			var finalizers []string
+			for _, action := range op.extClient.(*mon_fake.Clientset).Actions() {
+				if patch, ok := action.(clienttesting.PatchAction); ok && patch.GetResource().Resource == "podalerts" {
+					var obj api.PodAlert
+					if err := json.Unmarshal(patch.GetPatch(), &obj); err != nil {
+						t.Fatal(err)
+					}
+					finalizers = obj.Finalizers
+				}
+			}
+			if has := len(finalizers) > 0; has != c.finalizerAfter {
+				t.Errorf("expected finalizer kept %v, got finalizers %v", c.finalizerAfter, finalizers)
+			}
+			events := op.recorder.(*record.FakeRecorder).Events
+			if c.expectErr {
+				select {
+				case e := <-events:
+					if !strings.Contains(e, "FailedToDelete") {
+						t.Errorf("expected FailedToDelete event, got %s", e)
+					}
+				default:
+					t.Error("expected an event for the failed cleanup")
+				}
+			}
+		})
+	}
+}
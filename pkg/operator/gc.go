@@ -1,37 +1,199 @@
 package operator
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/appscode/go/log"
 	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
-	core "k8s.io/api/core/v1"
+	"github.com/appscode/searchlight/pkg/metrics"
+	"github.com/pkg/errors"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
-func (op *Operator) gcIncidents() {
-	if op.IncidentTTL <= 0 {
+const (
+	incidentExpiredRecovered = "recovered"
+	incidentExpiredStale     = "stale"
+	incidentExpiredCount     = "count"
+)
+
+func (op *Operator) initIncidentWatcher() {
+	op.incidentLister = op.monInformerFactory.Monitoring().V1alpha1().Incidents().Lister()
+}
+
+// incidentRetention limits how long and how many Incidents are kept. Zero values disable a limit.
+type incidentRetention struct {
+	// ttl is how long recovered Incidents are kept after their last notification
+	ttl time.Duration
+	// staleTTL is how long unrecovered Incidents are kept without any notification
+	staleTTL time.Duration
+	// maxPerAlert is the number of Incidents kept for each alert and target, recovered Incidents
+	// beyond it are deleted oldest first
+	maxPerAlert int
+}
+
+func (r incidentRetention) enabled() bool {
+	return r.ttl > 0 || r.staleTTL > 0 || r.maxPerAlert > 0
+}
+
+// incidentRetention returns the incident retention of a namespace, the retention of the operator
+// overridden by the annotations of the namespace.
+func (op *Operator) incidentRetention(namespace string) incidentRetention {
+	r := incidentRetention{
+		ttl:         op.IncidentTTL,
+		staleTTL:    op.IncidentStaleTTL,
+		maxPerAlert: op.IncidentMaxPerAlert,
+	}
+	ns, err := op.nsLister.Get(namespace)
+	if err != nil {
+		return r
+	}
+	parseDuration := func(key string, d *time.Duration) {
+		if v, ok := ns.Annotations[key]; ok {
+			if parsed, err := time.ParseDuration(v); err != nil || parsed < 0 {
+				log.Warningf("ignoring invalid annotation %s=%s of namespace %s", key, v, namespace)
+			} else {
+				*d = parsed
+			}
+		}
+	}
+	parseDuration(api.AnnotationKeyIncidentTTL, &r.ttl)
+	parseDuration(api.AnnotationKeyIncidentStaleTTL, &r.staleTTL)
+	if v, ok := ns.Annotations[api.AnnotationKeyIncidentMaxPerAlert]; ok {
+		if n, err := strconv.Atoi(v); err != nil || n < 0 {
+			log.Warningf("ignoring invalid annotation %s=%s of namespace %s", api.AnnotationKeyIncidentMaxPerAlert, v, namespace)
+		} else {
+			r.maxPerAlert = n
+		}
+	}
+	return r
+}
+
+// runIncidentGC periodically deletes expired Incidents until stopCh is closed.
+func (op *Operator) runIncidentGC(stopCh <-chan struct{}) {
+	if op.IncidentGCPeriod <= 0 {
 		log.Warningln("skipping garbage collection of incidents")
 		return
 	}
+	go wait.Until(op.gcIncidents, op.IncidentGCPeriod, stopCh)
+}
 
-	ticker := time.NewTicker(op.IncidentTTL)
-	go func() {
-		for t := range ticker.C {
-			log.Infoln("Incident GC run at", t)
+// gcIncidents archives and deletes the Incidents which expired by the retention of their namespace.
+func (op *Operator) gcIncidents() {
+	incidents, err := op.incidentLister.List(labels.Everything())
+	if err != nil {
+		log.Errorln(err)
+		return
+	}
 
-			objects, err := op.extClient.MonitoringV1alpha1().Incidents(core.NamespaceAll).List(metav1.ListOptions{})
-			if err != nil {
-				log.Errorln(err)
+	deleted := 0
+	for incident, reason := range expiredIncidents(incidents, op.incidentRetention, time.Now()) {
+		if err := op.archiveAndDeleteIncident(incident); err != nil {
+			log.Errorf("failed to expire Incident %s/%s. Reason: %v", incident.Namespace, incident.Name, err)
+			continue
+		}
+		metrics.IncidentsExpired.WithLabelValues(reason).Inc()
+		deleted++
+	}
+	if deleted > 0 {
+		log.Infof("deleted %d expired Incidents", deleted)
+	}
+}
+
+// expiredIncidents returns the Incidents which expired at now by the retention of their namespace,
+// with the reason they expired.
+func expiredIncidents(incidents []*api.Incident, retention func(namespace string) incidentRetention, now time.Time) map[*api.Incident]string {
+	retentions := map[string]incidentRetention{}
+	// Incidents which didn't expire by age, grouped by alert and target
+	kept := map[string][]*api.Incident{}
+	expired := map[*api.Incident]string{}
+	for _, incident := range incidents {
+		if incident.DeletionTimestamp != nil {
+			continue
+		}
+		r, ok := retentions[incident.Namespace]
+		if !ok {
+			r = retention(incident.Namespace)
+			retentions[incident.Namespace] = r
+		}
+		if !r.enabled() {
+			continue
+		}
+
+		idle := now.Sub(incidentLastActivity(incident))
+		if isRecovered(incident) {
+			if r.ttl > 0 && idle > r.ttl {
+				expired[incident] = incidentExpiredRecovered
 				continue
 			}
+		} else if r.staleTTL > 0 && idle > r.staleTTL {
+			expired[incident] = incidentExpiredStale
+			continue
+		}
+		key := fmt.Sprintf("%s/%s/%s/%s/%s", incident.Namespace,
+			incident.Labels[api.LabelKeyAlertType], incident.Labels[api.LabelKeyAlert],
+			incident.Labels[api.LabelKeyObjectKind], incident.Labels[api.LabelKeyObjectName])
+		kept[key] = append(kept[key], incident)
+	}
 
-			for _, item := range objects.Items {
-				if item.Status.LastNotificationType == api.NotificationRecovery &&
-					t.Sub(item.CreationTimestamp.Time) > op.IncidentTTL {
-					op.extClient.MonitoringV1alpha1().Incidents(item.Namespace).Delete(item.Name, nil)
-				}
+	for _, group := range kept {
+		limit := retentions[group[0].Namespace].maxPerAlert
+		if limit <= 0 || len(group) <= limit {
+			continue
+		}
+		sort.Slice(group, func(i, j int) bool {
+			return group[j].CreationTimestamp.Before(&group[i].CreationTimestamp)
+		})
+		// Incidents of ongoing problems are never deleted, but count against the limit
+		for _, incident := range group[limit:] {
+			if isRecovered(incident) {
+				expired[incident] = incidentExpiredCount
 			}
 		}
-	}()
+	}
+	return expired
+}
+
+func isRecovered(incident *api.Incident) bool {
+	return incident.Status.LastNotificationType == api.NotificationRecovery
+}
+
+// incidentLastActivity returns the time of the last notification of an Incident.
+func incidentLastActivity(incident *api.Incident) time.Time {
+	last := incident.CreationTimestamp.Time
+	for _, n := range incident.Status.Notifications {
+		if n.LastTimestamp.After(last) {
+			last = n.LastTimestamp.Time
+		}
+	}
+	return last
+}
+
+// archiveAndDeleteIncident deletes an Incident, after archiving it as JSON if an archive is
+// configured. Incidents which can't be archived are kept.
+func (op *Operator) archiveAndDeleteIncident(incident *api.Incident) error {
+	if op.incidentArchive != nil {
+		obj := incident.DeepCopy()
+		obj.APIVersion = api.SchemeGroupVersion.String()
+		obj.Kind = api.ResourceKindIncident
+		data, err := json.MarshalIndent(obj, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := op.incidentArchive.Put(incident.Namespace+"/"+incident.Name+".json", data); err != nil {
+			metrics.IncidentArchiveErrors.Inc()
+			return errors.Wrap(err, "failed to archive Incident")
+		}
+	}
+	err := op.extClient.MonitoringV1alpha1().Incidents(incident.Namespace).Delete(incident.Name, &metav1.DeleteOptions{})
+	if kerr.IsNotFound(err) {
+		return nil
+	}
+	return err
 }
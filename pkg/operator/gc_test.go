@@ -0,0 +1,172 @@
+package operator
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	core_listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+var gcNow = time.Date(2018, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+func testIncident(namespace, name, alert, object string, created, lastNotified time.Duration, last api.IncidentNotificationType) *api.Incident {
+	return &api.Incident{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.NewTime(gcNow.Add(-created)),
+			Labels: map[string]string{
+				api.LabelKeyAlertType:  "pod",
+				api.LabelKeyAlert:      alert,
+				api.LabelKeyObjectKind: "Pod",
+				api.LabelKeyObjectName: object,
+			},
+		},
+		Status: api.IncidentStatus{
+			LastNotificationType: last,
+			Notifications: []api.IncidentNotification{
+				{Type: last, LastTimestamp: metav1.NewTime(gcNow.Add(-lastNotified))},
+			},
+		},
+	}
+}
+
+func TestIncidentRetention(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, ns := range []*core.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "demo", Annotations: map[string]string{
+			api.AnnotationKeyIncidentTTL:         "24h",
+			api.AnnotationKeyIncidentStaleTTL:    "0",
+			api.AnnotationKeyIncidentMaxPerAlert: "5",
+		}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "invalid", Annotations: map[string]string{
+			api.AnnotationKeyIncidentTTL:         "a week",
+			api.AnnotationKeyIncidentStaleTTL:    "-1h",
+			api.AnnotationKeyIncidentMaxPerAlert: "-1",
+		}}},
+	} {
+		indexer.Add(ns)
+	}
+	op := &Operator{
+		Config: Config{
+			IncidentTTL:         720 * time.Hour,
+			IncidentStaleTTL:    2160 * time.Hour,
+			IncidentMaxPerAlert: 100,
+		},
+		nsLister: core_listers.NewNamespaceLister(indexer),
+	}
+
+	operatorRetention := incidentRetention{ttl: 720 * time.Hour, staleTTL: 2160 * time.Hour, maxPerAlert: 100}
+	cases := map[string]incidentRetention{
+		"default": operatorRetention,
+		"demo":    {ttl: 24 * time.Hour, staleTTL: 0, maxPerAlert: 5},
+		"invalid": operatorRetention,
+		"missing": operatorRetention,
+	}
+	for ns, want := range cases {
+		if got := op.incidentRetention(ns); got != want {
+			t.Errorf("namespace %s: expected retention %+v, got %+v", ns, want, got)
+		}
+	}
+}
+
+func TestExpiredIncidents(t *testing.T) {
+	day := 24 * time.Hour
+	retentions := map[string]incidentRetention{
+		"ttl":      {ttl: 7 * day},
+		"stale":    {staleTTL: 30 * day},
+		"count":    {maxPerAlert: 2},
+		"disabled": {},
+	}
+	retention := func(namespace string) incidentRetention {
+		return retentions[namespace]
+	}
+
+	deleting := testIncident("ttl", "deleting", "a", "p", 10*day, 10*day, api.NotificationRecovery)
+	deleting.DeletionTimestamp = &metav1.Time{Time: gcNow}
+
+	cases := []struct {
+		name      string
+		incidents []*api.Incident
+		expired   map[string]string
+	}{
+		{
+			name: "incident-ttl expires recovered incidents by their last notification",
+			incidents: []*api.Incident{
+				testIncident("ttl", "old", "a", "p", 10*day, 8*day, api.NotificationRecovery),
+				// created long ago, but notified recently
+				testIncident("ttl", "renotified", "a", "p", 10*day, 6*day, api.NotificationRecovery),
+				testIncident("ttl", "problem", "a", "p", 10*day, 10*day, api.NotificationProblem),
+				deleting,
+			},
+			expired: map[string]string{"old": incidentExpiredRecovered},
+		},
+		{
+			name: "incident-stale-ttl expires unrecovered incidents",
+			incidents: []*api.Incident{
+				testIncident("stale", "stale", "a", "p", 40*day, 31*day, api.NotificationProblem),
+				testIncident("stale", "acknowledged", "a", "p", 40*day, 29*day, api.NotificationAcknowledgement),
+				testIncident("stale", "recovered", "a", "p", 40*day, 40*day, api.NotificationRecovery),
+			},
+			expired: map[string]string{"stale": incidentExpiredStale},
+		},
+		{
+			name: "incident-max-per-alert expires the oldest recovered incidents of each alert and target",
+			incidents: []*api.Incident{
+				testIncident("count", "a-p-1", "a", "p", 4*time.Hour, time.Hour, api.NotificationRecovery),
+				testIncident("count", "a-p-2", "a", "p", 3*time.Hour, time.Hour, api.NotificationRecovery),
+				testIncident("count", "a-p-3", "a", "p", 2*time.Hour, time.Hour, api.NotificationRecovery),
+				testIncident("count", "a-p-4", "a", "p", 1*time.Hour, time.Hour, api.NotificationRecovery),
+				// another target and another alert have their own limit
+				testIncident("count", "a-q-1", "a", "q", 4*time.Hour, time.Hour, api.NotificationRecovery),
+				testIncident("count", "a-q-2", "a", "q", 3*time.Hour, time.Hour, api.NotificationRecovery),
+				testIncident("count", "b-p-1", "b", "p", 4*time.Hour, time.Hour, api.NotificationRecovery),
+			},
+			expired: map[string]string{"a-p-1": incidentExpiredCount, "a-p-2": incidentExpiredCount},
+		},
+		{
+			name: "incident-max-per-alert keeps ongoing problems",
+			incidents: []*api.Incident{
+				testIncident("count", "ongoing", "a", "p", 4*time.Hour, time.Hour, api.NotificationProblem),
+				testIncident("count", "recovered", "a", "p", 3*time.Hour, time.Hour, api.NotificationRecovery),
+				testIncident("count", "newer-1", "a", "p", 2*time.Hour, time.Hour, api.NotificationRecovery),
+				testIncident("count", "newer-2", "a", "p", 1*time.Hour, time.Hour, api.NotificationRecovery),
+			},
+			expired: map[string]string{"recovered": incidentExpiredCount},
+		},
+		{
+			name: "disabled retention keeps all incidents",
+			incidents: []*api.Incident{
+				testIncident("disabled", "old", "a", "p", 1000*day, 1000*day, api.NotificationRecovery),
+				testIncident("disabled", "stale", "a", "p", 1000*day, 1000*day, api.NotificationProblem),
+			},
+			expired: map[string]string{},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := map[string]string{}
+			for incident, reason := range expiredIncidents(c.incidents, retention, gcNow) {
+				got[incident.Name] = reason
+			}
+			if !reflect.DeepEqual(got, c.expired) {
+				names := func(m map[string]string) []string {
+					var out []string
+					for k, v := range m {
+						out = append(out, k+"="+v)
+					}
+					sort.Strings(out)
+					return out
+				}
+				t.Errorf("expected expired %v, got %v", names(c.expired), names(got))
+			}
+		})
+	}
+}
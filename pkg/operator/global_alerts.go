@@ -0,0 +1,162 @@
+package operator
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/appscode/go/log"
+	utilerrors "github.com/appscode/go/util/errors"
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/golang/glog"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"kmodules.xyz/client-go/tools/queue"
+)
+
+func (op *Operator) initGlobalAlertWatcher() {
+	op.gaInformer = op.monInformerFactory.Monitoring().V1alpha1().GlobalAlerts().Informer()
+	op.gaQueue = op.newQueue("GlobalAlert", op.reconcileGlobalAlert)
+	op.gaInformer.AddEventHandler(&cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			alert := obj.(*api.GlobalAlert)
+			if alert.DeletionTimestamp != nil || op.isValid(alert) {
+				queue.Enqueue(op.gaQueue.GetQueue(), obj)
+			}
+			op.enqueueAlertPlugins(obj)
+		},
+		UpdateFunc: func(oldObj interface{}, newObj interface{}) {
+			old := oldObj.(*api.GlobalAlert)
+			nu := newObj.(*api.GlobalAlert)
+
+			if alertPluginsChanged(old.ObjectMeta, nu.ObjectMeta, old.Spec, nu.Spec) {
+				op.enqueueAlertPlugins(old, nu)
+			}
+			if nu.DeletionTimestamp != nil {
+				queue.Enqueue(op.gaQueue.GetQueue(), nu)
+				return
+			}
+			if reflect.DeepEqual(old.Spec, nu.Spec) {
+				return
+			}
+			if op.isValid(nu) {
+				queue.Enqueue(op.gaQueue.GetQueue(), nu)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			queue.Enqueue(op.gaQueue.GetQueue(), obj)
+			op.enqueueAlertPlugins(obj)
+		},
+	})
+	op.gaLister = op.monInformerFactory.Monitoring().V1alpha1().GlobalAlerts().Lister()
+}
+
+func (op *Operator) reconcileGlobalAlert(key string) error {
+	obj, exists, err := op.gaInformer.GetIndexer().GetByKey(key)
+	if err != nil {
+		glog.Errorf("Fetching object with key %s from store failed with %v", key, err)
+		return err
+	}
+
+	if !exists {
+		log.Warningf("GlobalAlert %s does not exist anymore\n", key)
+		return op.ensureGlobalAlertDeleted(key)
+	}
+
+	alert := obj.(*api.GlobalAlert).DeepCopy()
+	if deleting, err := op.ensureAlertFinalizer(alert); deleting || err != nil {
+		return err
+	}
+	log.Infof("Sync/Add/Update for GlobalAlert %s\n", alert.GetName())
+
+	var errlist []error
+	if err := op.ensureGlobalAlert(alert); err != nil {
+		errlist = append(errlist, err)
+	}
+	if err := op.ensureGlobalAlertDeleted(alert.Name); err != nil {
+		errlist = append(errlist, err)
+	}
+	op.enqueueAlertStatus(api.ResourceKindGlobalAlert, "", alert.Name)
+	return utilerrors.NewAggregate(errlist)
+}
+
+// ensureGlobalAlert enqueues the pods selected by a GlobalAlert in all namespaces
+// selected by it. The GlobalAlert is applied to them while reconciling pods.
+func (op *Operator) ensureGlobalAlert(alert *api.GlobalAlert) error {
+	nsSel, err := alert.NamespaceSelector()
+	if err != nil {
+		return err
+	}
+	sel, err := metav1.LabelSelectorAsSelector(alert.Spec.Selector)
+	if err != nil {
+		return err
+	}
+	namespaces, err := op.nsLister.List(nsSel)
+	if err != nil {
+		return err
+	}
+	for _, ns := range namespaces {
+		pods, err := op.podLister.Pods(ns.Name).List(sel)
+		if err != nil {
+			return err
+		}
+		for i := range pods {
+			pod := pods[i]
+			key, err := cache.MetaNamespaceKeyFunc(pod)
+			if err == nil {
+				op.podQueue.GetQueue().Add(key)
+			}
+		}
+	}
+	return nil
+}
+
+func alertAppliedToPodGlobally(a map[string]string, key string) bool {
+	if a == nil {
+		return false
+	}
+	if val, ok := a[api.AnnotationKeyGlobalAlerts]; ok {
+		names := strings.Split(val, ",")
+		for _, name := range names {
+			if name == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ensureGlobalAlertDeleted enqueues the pods a GlobalAlert has been applied to,
+// so that its Icinga Services are removed from pods which it no longer selects.
+func (op *Operator) ensureGlobalAlertDeleted(alertName string) error {
+	pods, err := op.podLister.Pods(core.NamespaceAll).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods {
+		if alertAppliedToPodGlobally(pod.Annotations, alertName) {
+			key, err := cache.MetaNamespaceKeyFunc(pod)
+			if err == nil {
+				op.podQueue.GetQueue().Add(key)
+			}
+		}
+	}
+	return nil
+}
+
+// enqueueNamespacePods enqueues all pods of a namespace, so that GlobalAlerts are
+// applied to or removed from them as the namespace starts or stops matching.
+func (op *Operator) enqueueNamespacePods(namespace string) {
+	pods, err := op.podLister.Pods(namespace).List(labels.Everything())
+	if err != nil {
+		log.Errorln(err)
+		return
+	}
+	for _, pod := range pods {
+		key, err := cache.MetaNamespaceKeyFunc(pod)
+		if err == nil {
+			op.podQueue.GetQueue().Add(key)
+		}
+	}
+}
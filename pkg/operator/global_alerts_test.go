@@ -0,0 +1,78 @@
+package operator
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	mon_listers "github.com/appscode/searchlight/client/listers/monitoring/v1alpha1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestFindGlobalAlert(t *testing.T) {
+	api.PodCommands.Insert("pod-global", api.IcingaCommand{Name: "pod-global"})
+	newAlert := func(name string, nsSel *metav1.LabelSelector) *api.GlobalAlert {
+		return &api.GlobalAlert{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: api.GlobalAlertSpec{
+				NamespaceSelector: nsSel,
+				Selector:          &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}},
+				Check:             "pod-global",
+			},
+		}
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, alert := range []*api.GlobalAlert{
+		newAlert("all", nil),
+		newAlert("empty", &metav1.LabelSelector{}),
+		newAlert("prod", &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}),
+	} {
+		indexer.Add(alert)
+	}
+	lister := mon_listers.NewGlobalAlertLister(indexer)
+
+	cases := []struct {
+		name     string
+		nsLabels map[string]string
+		labels   map[string]string
+		expected []string
+	}{
+		{
+			name:     "namespace without labels",
+			labels:   map[string]string{"app": "nginx"},
+			expected: []string{"all", "empty"},
+		},
+		{
+			name:     "selected namespace",
+			nsLabels: map[string]string{"env": "prod"},
+			labels:   map[string]string{"app": "nginx"},
+			expected: []string{"all", "empty", "prod"},
+		},
+		{
+			name:     "unselected pod",
+			nsLabels: map[string]string{"env": "prod"},
+			labels:   map[string]string{"app": "redis"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ns := &core.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "demo", Labels: c.nsLabels}}
+			alerts, err := findGlobalAlert(fake.NewSimpleClientset(), lister, ns, metav1.ObjectMeta{Name: "web-0", Namespace: "demo", Labels: c.labels})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, alert := range alerts {
+				names = append(names, alert.Name)
+			}
+			sort.Strings(names)
+			if !reflect.DeepEqual(names, c.expected) {
+				t.Errorf("expected GlobalAlerts %v, got %v", c.expected, names)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package operator
+
+import (
+	"os"
+	"time"
+
+	"github.com/appscode/go/log"
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	coordination "k8s.io/api/coordination/v1"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	core_util "kmodules.xyz/client-go/core/v1"
+	"kmodules.xyz/client-go/meta"
+)
+
+const (
+	leaderElectionLeaseName = "searchlight-operator"
+)
+
+// leaderElector holds a Lease in the namespace of the operator, so that only one replica
+// reconciles alerts and writes Icinga config at a time.
+type leaderElector struct {
+	op       *Operator
+	identity string
+
+	// Lease as last observed, and when it was observed by this replica. Expiry of a Lease held
+	// by another replica is computed from the local clock to tolerate clock skew between nodes.
+	observed     *coordination.Lease
+	observedTime time.Time
+
+	// labeled is true once the pod of this replica got LabelKeyLeader after acquiring the Lease
+	labeled bool
+}
+
+// runLeaderElection blocks until this replica acquires the Lease or stopCh is closed, and then calls lead.
+// The process exits if the Lease can't be renewed within the renew deadline, so that a replica never
+// keeps writing to Icinga after another replica took over. The pod of the leader is labeled with
+// LabelKeyLeader, which the Service of the Icinga UI and API selects.
+func (op *Operator) runLeaderElection(stopCh <-chan struct{}, lead func(stopCh <-chan struct{}) error) error {
+	identity, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+	le := &leaderElector{op: op, identity: identity}
+
+	// The label survives restarts of the container, e.g. after this replica lost the Lease
+	if err := le.labelPods(false); err != nil {
+		log.Errorf("failed to remove label %s from pod %s: %v", api.LabelKeyLeader, identity, err)
+	}
+
+	log.Infof("attempting to acquire leader lease %s/%s as %s", meta.Namespace(), leaderElectionLeaseName, identity)
+	err = wait.PollImmediateUntil(op.LeaderElectionRetryPeriod, func() (bool, error) {
+		return le.tryAcquireOrRenew(), nil
+	}, stopCh)
+	if err == wait.ErrWaitTimeout {
+		return nil // stopped before acquiring the lease
+	} else if err != nil {
+		return err
+	}
+	log.Infof("acquired leader lease %s/%s", meta.Namespace(), leaderElectionLeaseName)
+	le.label()
+
+	go le.renew(stopCh)
+	return lead(stopCh)
+}
+
+// renew renews the Lease every retry period until stopCh is closed, then releases it.
+func (le *leaderElector) renew(stopCh <-chan struct{}) {
+	renewed := time.Now()
+	wait.Until(func() {
+		if le.tryAcquireOrRenew() {
+			renewed = time.Now()
+			if !le.labeled {
+				le.label()
+			}
+			return
+		}
+		if time.Since(renewed) > le.op.LeaderElectionRenewDeadline {
+			log.Fatalf("failed to renew leader lease %s/%s, exiting", meta.Namespace(), leaderElectionLeaseName)
+		}
+	}, le.op.LeaderElectionRetryPeriod, stopCh)
+	le.release()
+}
+
+// tryAcquireOrRenew creates or updates the Lease if it is free, expired or held by this replica.
+func (le *leaderElector) tryAcquireOrRenew() bool {
+	client := le.op.kubeClient.CoordinationV1().Leases(meta.Namespace())
+	now := metav1.NewMicroTime(time.Now())
+	duration := int32(le.op.LeaderElectionLeaseDuration / time.Second)
+
+	lease, err := client.Get(leaderElectionLeaseName, metav1.GetOptions{})
+	if kerr.IsNotFound(err) {
+		lease = &coordination.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      leaderElectionLeaseName,
+				Namespace: meta.Namespace(),
+			},
+			Spec: coordination.LeaseSpec{
+				HolderIdentity:       &le.identity,
+				LeaseDurationSeconds: &duration,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		if lease, err = client.Create(lease); err != nil {
+			log.Errorf("failed to create leader lease %s/%s: %v", meta.Namespace(), leaderElectionLeaseName, err)
+			return false
+		}
+		le.observe(lease)
+		return true
+	} else if err != nil {
+		log.Errorf("failed to get leader lease %s/%s: %v", meta.Namespace(), leaderElectionLeaseName, err)
+		return false
+	}
+
+	le.observe(lease)
+	holder := holderOf(lease)
+	// the holder may run with another lease duration, e.g. while replicas are updated
+	leaseDuration := le.op.LeaderElectionLeaseDuration
+	if lease.Spec.LeaseDurationSeconds != nil {
+		leaseDuration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	}
+	if holder != "" && holder != le.identity && le.observedTime.Add(leaseDuration).After(now.Time) {
+		log.Debugf("leader lease %s/%s is held by %s", meta.Namespace(), leaderElectionLeaseName, holder)
+		return false
+	}
+
+	lease = lease.DeepCopy()
+	if holder != le.identity {
+		var transitions int32
+		if lease.Spec.LeaseTransitions != nil {
+			transitions = *lease.Spec.LeaseTransitions
+		}
+		transitions++
+		lease.Spec.HolderIdentity = &le.identity
+		lease.Spec.AcquireTime = &now
+		lease.Spec.LeaseTransitions = &transitions
+	}
+	lease.Spec.LeaseDurationSeconds = &duration
+	lease.Spec.RenewTime = &now
+	if lease, err = client.Update(lease); err != nil {
+		log.Errorf("failed to update leader lease %s/%s: %v", meta.Namespace(), leaderElectionLeaseName, err)
+		return false
+	}
+	le.observe(lease)
+	return true
+}
+
+// release gives up the Lease held by this replica, so that another replica can take over without
+// waiting for the Lease to expire.
+func (le *leaderElector) release() {
+	if le.observed == nil || holderOf(le.observed) != le.identity {
+		return
+	}
+	if err := le.labelPods(false); err != nil {
+		log.Errorf("failed to remove label %s from pod %s: %v", api.LabelKeyLeader, le.identity, err)
+	}
+	lease := le.observed.DeepCopy()
+	lease.Spec.HolderIdentity = nil
+	if _, err := le.op.kubeClient.CoordinationV1().Leases(lease.Namespace).Update(lease); err != nil {
+		log.Errorf("failed to release leader lease %s/%s: %v", lease.Namespace, lease.Name, err)
+	}
+}
+
+func (le *leaderElector) label() {
+	if err := le.labelPods(true); err != nil {
+		log.Errorf("failed to set label %s on pod %s: %v", api.LabelKeyLeader, le.identity, err)
+		return
+	}
+	le.labeled = true
+}
+
+// labelPods sets LabelKeyLeader on the pod of this replica and removes it from the pods of other replicas,
+// if this replica leads. Otherwise, it only removes the label from the pod of this replica. Followers
+// run an empty Icinga, so they must not serve the Icinga UI or API. The admission webhook and the
+// acknowledgements API are served by every replica, so the label isn't selected by their Service.
+func (le *leaderElector) labelPods(leading bool) error {
+	client := le.op.kubeClient.CoreV1().Pods(meta.Namespace())
+	pods, err := client.List(metav1.ListOptions{LabelSelector: api.LabelKeyLeader})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	found := false
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Name == le.identity {
+			found = true
+		}
+		if (pod.Name == le.identity) == leading {
+			continue
+		}
+		_, err := core_util.TryUpdatePod(le.op.kubeClient, pod.ObjectMeta, func(in *core.Pod) *core.Pod {
+			delete(in.Labels, api.LabelKeyLeader)
+			return in
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if leading && !found {
+		_, err := core_util.TryUpdatePod(le.op.kubeClient, metav1.ObjectMeta{Namespace: meta.Namespace(), Name: le.identity}, func(in *core.Pod) *core.Pod {
+			if in.Labels == nil {
+				in.Labels = map[string]string{}
+			}
+			in.Labels[api.LabelKeyLeader] = "true"
+			return in
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (le *leaderElector) observe(lease *coordination.Lease) {
+	if le.observed == nil ||
+		holderOf(le.observed) != holderOf(lease) ||
+		!le.observed.Spec.RenewTime.Equal(lease.Spec.RenewTime) {
+		le.observedTime = time.Now()
+	}
+	le.observed = lease
+}
+
+func holderOf(lease *coordination.Lease) string {
+	if lease.Spec.HolderIdentity == nil {
+		return ""
+	}
+	return *lease.Spec.HolderIdentity
+}
@@ -0,0 +1,152 @@
+package operator
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	coordination "k8s.io/api/coordination/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"kmodules.xyz/client-go/meta"
+)
+
+func newTestLeaderElector(identity string, objects ...runtime.Object) *leaderElector {
+	op := &Operator{
+		Config: Config{
+			LeaderElectionLeaseDuration: 15 * time.Second,
+			LeaderElectionRenewDeadline: 10 * time.Second,
+			LeaderElectionRetryPeriod:   2 * time.Second,
+		},
+		kubeClient: fake.NewSimpleClientset(objects...),
+	}
+	return &leaderElector{op: op, identity: identity}
+}
+
+func testLease(holder string, duration int32, renewed time.Time) *coordination.Lease {
+	renewTime := metav1.NewMicroTime(renewed)
+	return &coordination.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: leaderElectionLeaseName, Namespace: meta.Namespace()},
+		Spec: coordination.LeaseSpec{
+			HolderIdentity:       &holder,
+			LeaseDurationSeconds: &duration,
+			AcquireTime:          &renewTime,
+			RenewTime:            &renewTime,
+		},
+	}
+}
+
+func getTestLease(t *testing.T, le *leaderElector) *coordination.Lease {
+	lease, err := le.op.kubeClient.CoordinationV1().Leases(meta.Namespace()).Get(leaderElectionLeaseName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get lease: %v", err)
+	}
+	return lease
+}
+
+func TestLeaderElectionAcquire(t *testing.T) {
+	le := newTestLeaderElector("op-0")
+	if !le.tryAcquireOrRenew() {
+		t.Fatal("expected to acquire a missing lease")
+	}
+	lease := getTestLease(t, le)
+	if holderOf(lease) != "op-0" {
+		t.Errorf("expected holder op-0, got %q", holderOf(lease))
+	}
+	if *lease.Spec.LeaseDurationSeconds != 15 {
+		t.Errorf("expected lease duration 15s, got %ds", *lease.Spec.LeaseDurationSeconds)
+	}
+}
+
+func TestLeaderElectionRenew(t *testing.T) {
+	renewed := time.Now().Add(-5 * time.Second)
+	le := newTestLeaderElector("op-0", testLease("op-0", 15, renewed))
+	if !le.tryAcquireOrRenew() {
+		t.Fatal("expected to renew own lease")
+	}
+	lease := getTestLease(t, le)
+	if !lease.Spec.RenewTime.Time.After(renewed) {
+		t.Errorf("expected renew time after %v, got %v", renewed, lease.Spec.RenewTime.Time)
+	}
+	if lease.Spec.LeaseTransitions != nil {
+		t.Errorf("expected no transitions, got %d", *lease.Spec.LeaseTransitions)
+	}
+}
+
+func TestLeaderElectionTakeover(t *testing.T) {
+	cases := []struct {
+		name     string
+		duration int32
+		observed time.Duration // since the lease was last observed to change
+		acquire  bool
+	}{
+		{name: "held", duration: 15, observed: 5 * time.Second, acquire: false},
+		{name: "expired", duration: 15, observed: 20 * time.Second, acquire: true},
+		// the duration of the lease takes precedence over the local one
+		{name: "held with longer lease duration", duration: 60, observed: 20 * time.Second, acquire: false},
+		{name: "expired with shorter lease duration", duration: 5, observed: 10 * time.Second, acquire: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			lease := testLease("op-1", c.duration, time.Now().Add(-c.observed))
+			le := newTestLeaderElector("op-0", lease)
+			le.observed = lease
+			le.observedTime = time.Now().Add(-c.observed)
+
+			if acquired := le.tryAcquireOrRenew(); acquired != c.acquire {
+				t.Fatalf("expected acquired=%v, got %v", c.acquire, acquired)
+			}
+			lease = getTestLease(t, le)
+			if !c.acquire {
+				if holderOf(lease) != "op-1" {
+					t.Errorf("expected holder op-1, got %q", holderOf(lease))
+				}
+				return
+			}
+			if holderOf(lease) != "op-0" {
+				t.Errorf("expected holder op-0, got %q", holderOf(lease))
+			}
+			if lease.Spec.LeaseTransitions == nil || *lease.Spec.LeaseTransitions != 1 {
+				t.Errorf("expected 1 transition, got %v", lease.Spec.LeaseTransitions)
+			}
+		})
+	}
+}
+
+func TestLeaderElectionLabelPods(t *testing.T) {
+	pod := func(name string, leader bool) *core.Pod {
+		p := &core.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: meta.Namespace(), Labels: map[string]string{}}}
+		if leader {
+			p.Labels[api.LabelKeyLeader] = "true"
+		}
+		return p
+	}
+	isLabeled := func(le *leaderElector, name string) bool {
+		p, err := le.op.kubeClient.CoreV1().Pods(meta.Namespace()).Get(name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get pod %s: %v", name, err)
+		}
+		_, found := p.Labels[api.LabelKeyLeader]
+		return found
+	}
+
+	// a new leader takes the label from the previous one
+	le := newTestLeaderElector("op-0", pod("op-0", false), pod("op-1", true))
+	if err := le.labelPods(true); err != nil {
+		t.Fatal(err)
+	}
+	if !isLabeled(le, "op-0") || isLabeled(le, "op-1") {
+		t.Errorf("expected only op-0 to be labeled")
+	}
+
+	// a follower only removes its own label
+	le = newTestLeaderElector("op-0", pod("op-0", true), pod("op-1", true))
+	if err := le.labelPods(false); err != nil {
+		t.Fatal(err)
+	}
+	if isLabeled(le, "op-0") || !isLabeled(le, "op-1") {
+		t.Errorf("expected only op-1 to be labeled")
+	}
+}
@@ -0,0 +1,81 @@
+package operator
+
+import (
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/labels"
+	"kmodules.xyz/client-go/tools/queue"
+)
+
+// newQueue returns a queue whose reconciliations are recorded in the operator metrics.
+func (op *Operator) newQueue(name string, fn func(key string) error) *queue.Worker {
+	return queue.New(name, op.MaxNumRequeues, op.NumThreads, metrics.InstrumentReconcile(name, fn))
+}
+
+var alertsDesc = prometheus.NewDesc(
+	"searchlight_alerts",
+	"Number of alerts, by kind and paused state.",
+	[]string{"kind", "paused"},
+	nil,
+)
+
+// alertCollector counts the alerts in the informer caches when metrics are scraped.
+type alertCollector struct {
+	op *Operator
+}
+
+var _ prometheus.Collector = alertCollector{}
+
+func (c alertCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- alertsDesc
+}
+
+func (c alertCollector) Collect(ch chan<- prometheus.Metric) {
+	counts := map[string]map[bool]int{}
+	count := func(kind string, paused bool) {
+		if counts[kind] == nil {
+			counts[kind] = map[bool]int{false: 0, true: 0}
+		}
+		counts[kind][paused]++
+	}
+
+	if alerts, err := c.op.caLister.List(labels.Everything()); err == nil {
+		for _, a := range alerts {
+			count(api.ResourceKindClusterAlert, a.Spec.Paused)
+		}
+	}
+	if alerts, err := c.op.naLister.List(labels.Everything()); err == nil {
+		for _, a := range alerts {
+			count(api.ResourceKindNodeAlert, a.Spec.Paused)
+		}
+	}
+	if alerts, err := c.op.paLister.List(labels.Everything()); err == nil {
+		for _, a := range alerts {
+			count(api.ResourceKindPodAlert, a.Spec.Paused)
+		}
+	}
+	if alerts, err := c.op.waLister.List(labels.Everything()); err == nil {
+		for _, a := range alerts {
+			count(api.ResourceKindWorkloadAlert, a.Spec.Paused)
+		}
+	}
+	if alerts, err := c.op.gaLister.List(labels.Everything()); err == nil {
+		for _, a := range alerts {
+			count(api.ResourceKindGlobalAlert, a.Spec.Paused)
+		}
+	}
+
+	for kind, byPaused := range counts {
+		for paused, n := range byPaused {
+			ch <- prometheus.MustNewConstMetric(alertsDesc, prometheus.GaugeValue, float64(n), kind, boolLabel(paused))
+		}
+	}
+}
+
+func boolLabel(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
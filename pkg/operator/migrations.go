@@ -1,107 +1,366 @@
 package operator
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/appscode/go/log"
 	utilerrors "github.com/appscode/go/util/errors"
-	"github.com/appscode/searchlight/apis/monitoring/v1alpha1"
 	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
 	"github.com/appscode/searchlight/client/clientset/versioned/typed/monitoring/v1alpha1/util"
+	"github.com/appscode/searchlight/pkg/eventer"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	core_util "kmodules.xyz/client-go/core/v1"
+	meta_util "kmodules.xyz/client-go/meta"
 )
 
-func (op *Operator) MigrateAlerts() error {
-	var errs []error
-	if err := op.MigrateClusterAlerts(); err != nil {
-		errs = append(errs, err)
-	}
-	if err := op.MigratePodAlert(); err != nil {
-		errs = append(errs, err)
-	}
-	if err := op.MigrateNodeAlert(); err != nil {
-		errs = append(errs, err)
-	}
+// MigrationsConfigMap is the name of the ConfigMap in the operator namespace recording the applied
+// migrations, keyed by version.
+const MigrationsConfigMap = "searchlight-migrations"
 
-	return utilerrors.NewAggregate(errs)
+// migration is a versioned change of stored objects. A migration is recorded as applied once it
+// succeeded for all objects, so it must be idempotent: objects it already changed are changed
+// again if it failed for other objects before.
+type migration struct {
+	version     int
+	description string
+	// kinds are the kinds of objects the migration applies to
+	kinds []string
+	// migrate changes an object in place, and reports whether it changed
+	migrate func(obj runtime.Object) bool
 }
 
-func (op *Operator) MigrateClusterAlerts() error {
-	ca, err := op.extClient.MonitoringV1alpha1().ClusterAlerts(metav1.NamespaceAll).List(metav1.ListOptions{})
+// migrations are applied in order. Append new migrations with the next version, and never change
+// migrations which were released.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "use - instead of _ in names of checks",
+		kinds:       []string{api.ResourceKindClusterAlert, api.ResourceKindNodeAlert, api.ResourceKindPodAlert},
+		migrate: func(obj runtime.Object) bool {
+			var check *string
+			switch a := obj.(type) {
+			case *api.ClusterAlert:
+				check = &a.Spec.Check
+			case *api.NodeAlert:
+				check = &a.Spec.Check
+			case *api.PodAlert:
+				check = &a.Spec.Check
+			default:
+				return false
+			}
+			migrated := strings.Replace(*check, "_", "-", -1)
+			if migrated == *check {
+				return false
+			}
+			*check = migrated
+			return true
+		},
+	},
+	{
+		version:     2,
+		description: "move NodeAlert selectors to labelSelector and rename the mountpoint var of node-volume checks",
+		kinds:       []string{api.ResourceKindNodeAlert},
+		migrate: func(obj runtime.Object) bool {
+			alert, ok := obj.(*api.NodeAlert)
+			if !ok {
+				return false
+			}
+			changed := false
+			if len(alert.Spec.Selector) > 0 && alert.Spec.LabelSelector == nil {
+				alert.Spec.LabelSelector = &metav1.LabelSelector{
+					MatchLabels: alert.Spec.Selector,
+				}
+				alert.Spec.Selector = nil
+				changed = true
+			}
+			if alert.Spec.Check == api.CheckNodeVolume {
+				if mp, found := alert.Spec.Vars["mountpoint"]; found {
+					delete(alert.Spec.Vars, "mountpoint")
+					alert.Spec.Vars["mountPoint"] = mp
+					changed = true
+				}
+			}
+			return changed
+		},
+	},
+	{
+		version:     3,
+		description: "set the namespace of SearchlightPlugin webhooks, which defaulted to default",
+		kinds:       []string{api.ResourceKindSearchlightPlugin},
+		migrate: func(obj runtime.Object) bool {
+			wp, ok := obj.(*api.SearchlightPlugin)
+			if !ok || wp.Spec.Webhook == nil || wp.Spec.Webhook.Namespace != "" {
+				return false
+			}
+			wp.Spec.Webhook.Namespace = core.NamespaceDefault
+			return true
+		},
+	},
+	{
+		version:     4,
+		description: "label Incidents by whether their problem recovered",
+		kinds:       []string{api.ResourceKindIncident},
+		migrate: func(obj runtime.Object) bool {
+			incident, ok := obj.(*api.Incident)
+			if !ok {
+				return false
+			}
+			recovered := strconv.FormatBool(incident.Status.LastNotificationType == api.NotificationRecovery)
+			if incident.Labels[api.LabelKeyProblemRecovered] == recovered {
+				return false
+			}
+			if incident.Labels == nil {
+				incident.Labels = map[string]string{}
+			}
+			incident.Labels[api.LabelKeyProblemRecovered] = recovered
+			return true
+		},
+	},
+}
+
+// MigrationResult reports the objects changed by a migration.
+type MigrationResult struct {
+	Version     int    `json:"version"`
+	Description string `json:"description"`
+	// AppliedAt is the time the migration was recorded as applied
+	AppliedAt string `json:"appliedAt,omitempty"`
+	// Changed lists the objects changed, or which would be changed in a dry run
+	Changed []string `json:"changed,omitempty"`
+	// Failed lists the objects which failed to migrate, with the reason
+	Failed map[string]string `json:"failed,omitempty"`
+}
+
+// Migrate applies the migrations which are not recorded as applied yet, in order. A migration
+// failing for some objects is not recorded, and stops later migrations, which may depend on it.
+// In a dry run, it reports the objects which would be changed, without changing anything.
+func (op *Operator) Migrate(dryRun bool) ([]MigrationResult, error) {
+	applied, err := op.appliedMigrations()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var errs []error
-	for i := range ca.Items {
-		_, _, err := util.PatchClusterAlert(op.extClient.MonitoringV1alpha1(), &ca.Items[i], func(alert *v1alpha1.ClusterAlert) *v1alpha1.ClusterAlert {
-			check := strings.Replace(alert.Spec.Check, "_", "-", -1)
-			alert.Spec.Check = check
-			return alert
-		})
-		if err != nil {
-			errs = append(errs, err)
+	var results []MigrationResult
+	for _, m := range migrations {
+		result := MigrationResult{Version: m.version, Description: m.description}
+		if at, ok := applied[migrationKey(m.version)]; ok {
+			result.AppliedAt = at
+			results = append(results, result)
+			continue
 		}
-	}
-	if len(errs) > 0 {
-		return utilerrors.NewAggregate(errs)
-	}
 
-	return nil
-}
-
-func (op *Operator) MigratePodAlert() error {
-	poa, err := op.extClient.MonitoringV1alpha1().PodAlerts(metav1.NamespaceAll).List(metav1.ListOptions{})
-	if err != nil {
-		return err
+		if err := op.runMigration(m, &result, dryRun); err != nil {
+			return append(results, result), err
+		}
+		if len(result.Failed) > 0 {
+			return append(results, result), errors.Errorf("migration %d failed for %d objects", m.version, len(result.Failed))
+		}
+		if !dryRun {
+			log.Infof("applied migration %d: %s, changed %d objects\n", m.version, m.description, len(result.Changed))
+			if result.AppliedAt, err = op.recordMigration(m.version); err != nil {
+				return append(results, result), err
+			}
+		}
+		results = append(results, result)
 	}
+	return results, nil
+}
 
-	var errs []error
-	for i := range poa.Items {
-		_, _, err := util.PatchPodAlert(op.extClient.MonitoringV1alpha1(), &poa.Items[i], func(alert *v1alpha1.PodAlert) *v1alpha1.PodAlert {
-			check := strings.Replace(alert.Spec.Check, "_", "-", -1)
-			alert.Spec.Check = check
-			return alert
-		})
-		if err != nil {
-			errs = append(errs, err)
+// runMigrations applies pending migrations. If they fail, they are retried every resync period until
+// applied, while the controllers run. Objects failing to migrate are reported with events.
+func (op *Operator) runMigrations(stopCh <-chan struct{}) {
+	migrate := func() (bool, error) {
+		if _, err := op.Migrate(false); err != nil {
+			log.Errorf("failed to apply migrations, retrying in %v: %v", op.ResyncPeriod, err)
+			return false, nil
 		}
+		return true, nil
 	}
-	if len(errs) > 0 {
-		return utilerrors.NewAggregate(errs)
+	if done, _ := migrate(); !done {
+		go wait.PollUntil(op.ResyncPeriod, migrate, stopCh)
 	}
+}
 
-	return nil
+func migrationKey(version int) string {
+	return "v" + strconv.Itoa(version)
 }
 
-func (op *Operator) MigrateNodeAlert() error {
-	noa, err := op.extClient.MonitoringV1alpha1().NodeAlerts(metav1.NamespaceAll).List(metav1.ListOptions{})
-	if err != nil {
-		return err
+// appliedMigrations returns the times the applied migrations were recorded, keyed by version.
+func (op *Operator) appliedMigrations() (map[string]string, error) {
+	cm, err := op.kubeClient.CoreV1().ConfigMaps(meta_util.Namespace()).Get(MigrationsConfigMap, metav1.GetOptions{})
+	if kerr.IsNotFound(err) {
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to read applied migrations from ConfigMap %s", MigrationsConfigMap)
+	}
+	return cm.Data, nil
+}
+
+func (op *Operator) recordMigration(version int) (string, error) {
+	at := time.Now().UTC().Format(time.RFC3339)
+	record := func(in *core.ConfigMap) *core.ConfigMap {
+		if in.Data == nil {
+			in.Data = map[string]string{}
+		}
+		in.Data[migrationKey(version)] = at
+		return in
 	}
 
-	var errs []error
-	for i := range noa.Items {
-		_, _, err := util.PatchNodeAlert(op.extClient.MonitoringV1alpha1(), &noa.Items[i], func(alert *v1alpha1.NodeAlert) *v1alpha1.NodeAlert {
-			check := strings.Replace(alert.Spec.Check, "_", "-", -1)
-			alert.Spec.Check = check
+	// updates fail on conflicts, so that concurrent runs don't drop each other's records
+	objMeta := metav1.ObjectMeta{Name: MigrationsConfigMap, Namespace: meta_util.Namespace()}
+	_, err := op.kubeClient.CoreV1().ConfigMaps(objMeta.Namespace).Get(objMeta.Name, metav1.GetOptions{})
+	if kerr.IsNotFound(err) {
+		_, err = op.kubeClient.CoreV1().ConfigMaps(objMeta.Namespace).Create(record(&core.ConfigMap{ObjectMeta: objMeta}))
+	} else if err == nil {
+		_, err = core_util.TryUpdateConfigMap(op.kubeClient, objMeta, record)
+	}
+	return at, errors.Wrapf(err, "failed to record migration %d", version)
+}
 
-			if check == api.CheckNodeVolume {
-				mp, found := alert.Spec.Vars["mountpoint"]
-				if found {
-					delete(alert.Spec.Vars, "mountpoint")
-					alert.Spec.Vars["mountPoint"] = mp
+// runMigration applies a migration to all objects of its kinds, recording the changed and failed
+// objects in result. Objects which fail are reported with events.
+func (op *Operator) runMigration(m migration, result *MigrationResult, dryRun bool) error {
+	var errlist []error
+	for _, kind := range m.kinds {
+		objects, err := op.listMigrationObjects(kind)
+		if err != nil {
+			errlist = append(errlist, err)
+			continue
+		}
+		for _, obj := range objects {
+			cur := obj.DeepCopyObject()
+			if !m.migrate(cur) {
+				continue
+			}
+			o, _ := meta.Accessor(obj)
+			name := kind + " " + o.GetName()
+			if o.GetNamespace() != "" {
+				name = kind + " " + o.GetNamespace() + "/" + o.GetName()
+			}
+			if dryRun {
+				result.Changed = append(result.Changed, name)
+				continue
+			}
+			if err := op.patchMigrationObject(obj, cur); err != nil {
+				if result.Failed == nil {
+					result.Failed = map[string]string{}
 				}
+				result.Failed[name] = err.Error()
+				op.recorder.Eventf(
+					obj,
+					core.EventTypeWarning,
+					eventer.EventReasonFailedToMigrate,
+					`Failed to apply migration %d: %s. Reason: %v`,
+					m.version, m.description, err,
+				)
+				continue
 			}
+			result.Changed = append(result.Changed, name)
+		}
+	}
+	return utilerrors.NewAggregate(errlist)
+}
 
-			return alert
-		})
+func (op *Operator) listMigrationObjects(kind string) ([]runtime.Object, error) {
+	c := op.extClient.MonitoringV1alpha1()
+	var objects []runtime.Object
+	switch kind {
+	case api.ResourceKindClusterAlert:
+		list, err := c.ClusterAlerts(metav1.NamespaceAll).List(metav1.ListOptions{})
 		if err != nil {
-			errs = append(errs, err)
+			return nil, err
 		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	case api.ResourceKindNodeAlert:
+		list, err := c.NodeAlerts(metav1.NamespaceAll).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	case api.ResourceKindPodAlert:
+		list, err := c.PodAlerts(metav1.NamespaceAll).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	case api.ResourceKindWorkloadAlert:
+		list, err := c.WorkloadAlerts(metav1.NamespaceAll).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	case api.ResourceKindGlobalAlert:
+		list, err := c.GlobalAlerts().List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	case api.ResourceKindSearchlightPlugin:
+		list, err := c.SearchlightPlugins().List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	case api.ResourceKindIncident:
+		list, err := c.Incidents(metav1.NamespaceAll).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	default:
+		return nil, errors.Errorf("unknown kind %s", kind)
 	}
-	if len(errs) > 0 {
-		return utilerrors.NewAggregate(errs)
-	}
+	return objects, nil
+}
+
+// patchMigrationObject patches an object to its migrated copy.
+func (op *Operator) patchMigrationObject(obj, migrated runtime.Object) error {
+	c := op.extClient.MonitoringV1alpha1()
 
-	return nil
+	var err error
+	switch cur := obj.(type) {
+	case *api.ClusterAlert:
+		_, _, err = util.PatchClusterAlertObject(c, cur, migrated.(*api.ClusterAlert))
+	case *api.NodeAlert:
+		_, _, err = util.PatchNodeAlertObject(c, cur, migrated.(*api.NodeAlert))
+	case *api.PodAlert:
+		_, _, err = util.PatchPodAlertObject(c, cur, migrated.(*api.PodAlert))
+	case *api.WorkloadAlert:
+		_, _, err = util.PatchWorkloadAlertObject(c, cur, migrated.(*api.WorkloadAlert))
+	case *api.GlobalAlert:
+		_, _, err = util.PatchGlobalAlertObject(c, cur, migrated.(*api.GlobalAlert))
+	case *api.SearchlightPlugin:
+		_, _, err = util.PatchSearchlightPluginObject(c, cur, migrated.(*api.SearchlightPlugin))
+	case *api.Incident:
+		_, _, err = util.PatchIncidentObject(c, cur, migrated.(*api.Incident))
+	default:
+		return fmt.Errorf("unknown object type %T", obj)
+	}
+	if kerr.IsNotFound(err) {
+		// deleted in the meantime
+		return nil
+	}
+	return err
 }
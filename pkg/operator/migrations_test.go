@@ -0,0 +1,290 @@
+package operator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	mon_fake "github.com/appscode/searchlight/client/clientset/versioned/fake"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	meta_util "kmodules.xyz/client-go/meta"
+)
+
+func newMigrationTestOperator(kubeObjects []runtime.Object, objects ...runtime.Object) *Operator {
+	return &Operator{
+		kubeClient: fake.NewSimpleClientset(kubeObjects...),
+		extClient:  mon_fake.NewSimpleClientset(objects...),
+		recorder:   record.NewFakeRecorder(100),
+	}
+}
+
+func appliedVersions(t *testing.T, op *Operator) []string {
+	applied, err := op.appliedMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var versions []string
+	for _, m := range migrations {
+		if _, ok := applied[migrationKey(m.version)]; ok {
+			versions = append(versions, migrationKey(m.version))
+		}
+	}
+	return versions
+}
+
+func TestMigrateOrder(t *testing.T) {
+	// migration 2 only renames the var of node-volume checks, which migration 1 renamed from node_volume
+	alert := &api.NodeAlert{
+		ObjectMeta: metav1.ObjectMeta{Name: "disk", Namespace: "demo"},
+		Spec: api.NodeAlertSpec{
+			Check: "node_volume",
+			Vars:  map[string]string{"mountpoint": "/", "warning": "80"},
+		},
+	}
+	op := newMigrationTestOperator(nil, alert)
+
+	results, err := op.Migrate(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range results {
+		if r.Version != migrations[i].version || r.AppliedAt == "" {
+			t.Errorf("expected migration %d to be applied at position %d, got %+v", migrations[i].version, i, r)
+		}
+	}
+
+	got, err := op.extClient.MonitoringV1alpha1().NodeAlerts("demo").Get("disk", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Spec.Check != api.CheckNodeVolume {
+		t.Errorf("expected check %s, got %s", api.CheckNodeVolume, got.Spec.Check)
+	}
+	// the fake clientset merges maps when applying patches, so the removal of mountpoint is checked in the patch
+	if got.Spec.Vars["mountPoint"] != "/" {
+		t.Errorf("expected var mountPoint, got %v", got.Spec.Vars)
+	}
+	var patches []string
+	for _, action := range op.extClient.(*mon_fake.Clientset).Actions() {
+		if patch, ok := action.(clienttesting.PatchAction); ok {
+			patches = append(patches, string(patch.GetPatch()))
+		}
+	}
+	want := []string{
+		`{"spec":{"check":"node-volume"}}`,
+		`{"spec":{"vars":{"mountPoint":"/","mountpoint":null}}}`,
+	}
+	if !reflect.DeepEqual(patches, want) {
+		t.Errorf("expected patches %v, got %v", want, patches)
+	}
+	if len(appliedVersions(t, op)) != len(migrations) {
+		t.Errorf("expected all migrations to be recorded, got %v", appliedVersions(t, op))
+	}
+}
+
+func TestMigrateSkipsApplied(t *testing.T) {
+	// migration 1 is recorded as applied, so the check is not renamed
+	cm := &core.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: MigrationsConfigMap, Namespace: meta_util.Namespace()},
+		Data:       map[string]string{migrationKey(1): "2018-01-01T00:00:00Z"},
+	}
+	alert := &api.ClusterAlert{
+		ObjectMeta: metav1.ObjectMeta{Name: "events", Namespace: "demo"},
+		Spec:       api.ClusterAlertSpec{Check: "event_check"},
+	}
+	op := newMigrationTestOperator([]runtime.Object{cm}, alert)
+
+	results, err := op.Migrate(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].AppliedAt != "2018-01-01T00:00:00Z" || len(results[0].Changed) != 0 {
+		t.Errorf("expected migration 1 to be skipped, got %+v", results[0])
+	}
+	got, err := op.extClient.MonitoringV1alpha1().ClusterAlerts("demo").Get("events", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Spec.Check != "event_check" {
+		t.Errorf("expected check event_check, got %s", got.Spec.Check)
+	}
+}
+
+func TestMigrateIdempotent(t *testing.T) {
+	alert := &api.PodAlert{
+		ObjectMeta: metav1.ObjectMeta{Name: "status", Namespace: "demo"},
+		Spec:       api.PodAlertSpec{Check: "pod_status"},
+	}
+	op := newMigrationTestOperator(nil, alert)
+
+	if _, err := op.Migrate(false); err != nil {
+		t.Fatal(err)
+	}
+	results, err := op.Migrate(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if len(r.Changed) != 0 {
+			t.Errorf("expected migration %d to change nothing, changed %v", r.Version, r.Changed)
+		}
+	}
+
+	// migrations reapplied to migrated objects, e.g. after failing for other objects, change nothing
+	op.kubeClient = fake.NewSimpleClientset()
+	results, err = op.Migrate(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if len(r.Changed) != 0 {
+			t.Errorf("expected migration %d to change nothing, changed %v", r.Version, r.Changed)
+		}
+	}
+}
+
+func TestMigrateRetriesFailed(t *testing.T) {
+	alerts := []runtime.Object{
+		&api.ClusterAlert{
+			ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "demo"},
+			Spec:       api.ClusterAlertSpec{Check: "json_path"},
+		},
+		&api.ClusterAlert{
+			ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "demo"},
+			Spec:       api.ClusterAlertSpec{Check: "node_exists"},
+		},
+	}
+	op := newMigrationTestOperator(nil, alerts...)
+	failing := true
+	op.extClient.(*mon_fake.Clientset).PrependReactor("patch", "clusteralerts", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if failing && action.(clienttesting.PatchAction).GetName() == "b" {
+			return true, nil, errors.New("conflict")
+		}
+		return false, nil, nil
+	})
+
+	results, err := op.Migrate(false)
+	if err == nil {
+		t.Fatal("expected migration 1 to fail")
+	}
+	if len(results) != 1 || len(results[0].Failed) != 1 || len(results[0].Changed) != 1 {
+		t.Fatalf("expected migration 1 to fail for one object and stop, got %+v", results)
+	}
+	if versions := appliedVersions(t, op); len(versions) != 0 {
+		t.Errorf("expected no migration to be recorded, got %v", versions)
+	}
+	if events := op.recorder.(*record.FakeRecorder).Events; len(events) != 1 {
+		t.Errorf("expected an event for the failed object, got %d", len(events))
+	}
+
+	failing = false
+	results, err = op.Migrate(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results[0].Changed) != 1 || results[0].Changed[0] != api.ResourceKindClusterAlert+" demo/b" {
+		t.Errorf("expected retry to change only demo/b, got %v", results[0].Changed)
+	}
+	if versions := appliedVersions(t, op); len(versions) != len(migrations) {
+		t.Errorf("expected all migrations to be recorded, got %v", versions)
+	}
+}
+
+func TestMigrateNodeAlertSelector(t *testing.T) {
+	var m migration
+	for _, m = range migrations {
+		if m.version == 2 {
+			break
+		}
+	}
+
+	cases := []struct {
+		name     string
+		spec     api.NodeAlertSpec
+		expected api.NodeAlertSpec
+		changed  bool
+	}{
+		{
+			name: "selector",
+			spec: api.NodeAlertSpec{Selector: map[string]string{"role": "worker"}},
+			expected: api.NodeAlertSpec{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "worker"}},
+			},
+			changed: true,
+		},
+		{
+			name:     "no selector",
+			spec:     api.NodeAlertSpec{},
+			expected: api.NodeAlertSpec{},
+		},
+		{
+			name:     "empty selector",
+			spec:     api.NodeAlertSpec{Selector: map[string]string{}},
+			expected: api.NodeAlertSpec{Selector: map[string]string{}},
+		},
+		{
+			name: "label selector",
+			spec: api.NodeAlertSpec{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "master"}},
+			},
+			expected: api.NodeAlertSpec{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "master"}},
+			},
+		},
+		{
+			// invalid, as IsValid rejects both, so the selector is kept for the user to resolve
+			name: "selector and label selector",
+			spec: api.NodeAlertSpec{
+				Selector:      map[string]string{"role": "worker"},
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "master"}},
+			},
+			expected: api.NodeAlertSpec{
+				Selector:      map[string]string{"role": "worker"},
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "master"}},
+			},
+		},
+		{
+			name: "selector and mountpoint var",
+			spec: api.NodeAlertSpec{
+				Selector: map[string]string{"role": "worker"},
+				Check:    api.CheckNodeVolume,
+				Vars:     map[string]string{"mountpoint": "/"},
+			},
+			expected: api.NodeAlertSpec{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "worker"}},
+				Check:         api.CheckNodeVolume,
+				Vars:          map[string]string{"mountPoint": "/"},
+			},
+			changed: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			alert := &api.NodeAlert{Spec: c.spec}
+			if changed := m.migrate(alert); changed != c.changed {
+				t.Errorf("expected changed %v, got %v", c.changed, changed)
+			}
+			if !reflect.DeepEqual(alert.Spec, c.expected) {
+				t.Errorf("expected spec %+v, got %+v", c.expected, alert.Spec)
+			}
+			// migrated alerts must select the same nodes
+			before, err := api.NodeAlert{Spec: c.spec}.NodeSelector()
+			if err != nil {
+				t.Fatal(err)
+			}
+			after, err := alert.NodeSelector()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if before.String() != after.String() {
+				t.Errorf("expected selector %s, got %s", before, after)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package operator
 
 import (
+	"reflect"
+
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
@@ -9,11 +11,34 @@ import (
 func (op *Operator) initNamespaceWatcher() {
 	op.nsInformer = op.kubeInformerFactory.Core().V1().Namespaces().Informer()
 	op.nsInformer.AddEventHandler(&cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if ns, ok := obj.(*core.Namespace); ok {
+				op.enqueueNamespacePods(ns.Name)
+			}
+		},
+		UpdateFunc: func(oldObj interface{}, newObj interface{}) {
+			old, ok := oldObj.(*core.Namespace)
+			if !ok {
+				return
+			}
+			nu, ok := newObj.(*core.Namespace)
+			if !ok {
+				return
+			}
+			// Namespace labels decide which GlobalAlerts apply to its pods
+			if !reflect.DeepEqual(old.Labels, nu.Labels) {
+				op.enqueueNamespacePods(nu.Name)
+			}
+		},
 		DeleteFunc: func(obj interface{}) {
 			if ns, ok := obj.(*core.Namespace); ok {
+				// Alerts are finalized by the operator, which removes their Icinga objects and Incidents
 				op.extClient.MonitoringV1alpha1().ClusterAlerts(ns.Name).DeleteCollection(&metav1.DeleteOptions{}, metav1.ListOptions{})
 				op.extClient.MonitoringV1alpha1().NodeAlerts(ns.Name).DeleteCollection(&metav1.DeleteOptions{}, metav1.ListOptions{})
 				op.extClient.MonitoringV1alpha1().PodAlerts(ns.Name).DeleteCollection(&metav1.DeleteOptions{}, metav1.ListOptions{})
+				op.extClient.MonitoringV1alpha1().WorkloadAlerts(ns.Name).DeleteCollection(&metav1.DeleteOptions{}, metav1.ListOptions{})
+				op.extClient.MonitoringV1alpha1().Silences(ns.Name).DeleteCollection(&metav1.DeleteOptions{}, metav1.ListOptions{})
+				op.extClient.MonitoringV1alpha1().AlertTemplates(ns.Name).DeleteCollection(&metav1.DeleteOptions{}, metav1.ListOptions{})
 			}
 		},
 	})
@@ -14,18 +14,26 @@ import (
 
 func (op *Operator) initNodeAlertWatcher() {
 	op.naInformer = op.monInformerFactory.Monitoring().V1alpha1().NodeAlerts().Informer()
-	op.naQueue = queue.New("NodeAlert", op.MaxNumRequeues, op.NumThreads, op.reconcileNodeAlert)
+	op.naQueue = op.newQueue("NodeAlert", op.reconcileNodeAlert)
 	op.naInformer.AddEventHandler(&cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			alert := obj.(*api.NodeAlert)
-			if op.isValid(alert) {
+			if alert.DeletionTimestamp != nil || op.isValid(alert) {
 				queue.Enqueue(op.naQueue.GetQueue(), obj)
 			}
+			op.enqueueAlertPlugins(obj)
 		},
 		UpdateFunc: func(oldObj interface{}, newObj interface{}) {
 			old := oldObj.(*api.NodeAlert)
 			nu := newObj.(*api.NodeAlert)
 
+			if alertPluginsChanged(old.ObjectMeta, nu.ObjectMeta, old.Spec, nu.Spec) {
+				op.enqueueAlertPlugins(old, nu)
+			}
+			if nu.DeletionTimestamp != nil {
+				queue.Enqueue(op.naQueue.GetQueue(), nu)
+				return
+			}
 			if reflect.DeepEqual(old.Spec, nu.Spec) {
 				return
 			}
@@ -35,6 +43,7 @@ func (op *Operator) initNodeAlertWatcher() {
 		},
 		DeleteFunc: func(obj interface{}) {
 			queue.Enqueue(op.naQueue.GetQueue(), obj)
+			op.enqueueAlertPlugins(obj)
 		},
 	})
 	op.naLister = op.monInformerFactory.Monitoring().V1alpha1().NodeAlerts().Lister()
@@ -58,10 +67,14 @@ func (op *Operator) reconcileNodeAlert(key string) error {
 	}
 
 	alert := obj.(*api.NodeAlert).DeepCopy()
+	if deleting, err := op.ensureAlertFinalizer(alert); deleting || err != nil {
+		return err
+	}
 	log.Infof("Sync/Add/Update for NodeAlert %s\n", key)
 
 	op.ensureNodeAlert(alert)
 	op.ensureNodeAlertDeleted(alert.Namespace, alert.Name)
+	op.enqueueAlertStatus(api.ResourceKindNodeAlert, alert.Namespace, alert.Name)
 	return nil
 }
 
@@ -78,13 +91,19 @@ func (op *Operator) ensureNodeAlert(alert *api.NodeAlert) error {
 		return nil
 	}
 
-	sel := labels.SelectorFromSet(alert.Spec.Selector)
+	sel, err := alert.NodeSelector()
+	if err != nil {
+		return err
+	}
 	nodes, err := op.nodeLister.List(sel)
 	if err != nil {
 		return err
 	}
 	for i := range nodes {
 		node := nodes[i]
+		if ok, err := alert.MatchesNode(node); err != nil || !ok {
+			continue
+		}
 		key, err := cache.MetaNamespaceKeyFunc(node)
 		if err == nil {
 			op.nodeQueue.GetQueue().Add(key)
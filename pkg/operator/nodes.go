@@ -20,7 +20,7 @@ import (
 
 func (op *Operator) initNodeWatcher() {
 	op.nodeInformer = op.kubeInformerFactory.Core().V1().Nodes().Informer()
-	op.nodeQueue = queue.New("Node", op.MaxNumRequeues, op.NumThreads, op.reconcileNode)
+	op.nodeQueue = op.newQueue("Node", op.reconcileNode)
 	op.nodeInformer.AddEventHandler(&cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			queue.Enqueue(op.nodeQueue.GetQueue(), obj)
@@ -28,7 +28,10 @@ func (op *Operator) initNodeWatcher() {
 		UpdateFunc: func(oldObj interface{}, newObj interface{}) {
 			old := oldObj.(*core.Node)
 			nu := newObj.(*core.Node)
-			if !reflect.DeepEqual(old.Labels, nu.Labels) {
+			if !reflect.DeepEqual(old.Labels, nu.Labels) ||
+				!reflect.DeepEqual(userAnnotations(old.Annotations), userAnnotations(nu.Annotations)) ||
+				!reflect.DeepEqual(old.Spec.Taints, nu.Spec.Taints) ||
+				!reflect.DeepEqual(nodeConditionStatuses(old), nodeConditionStatuses(nu)) {
 				queue.Enqueue(op.nodeQueue.GetQueue(), newObj)
 			}
 		},
@@ -39,6 +42,16 @@ func (op *Operator) initNodeWatcher() {
 	op.nodeLister = op.kubeInformerFactory.Core().V1().Nodes().Lister()
 }
 
+// nodeConditionStatuses returns the status of each condition of a node. Unlike the conditions,
+// it does not change with every heartbeat of the node.
+func nodeConditionStatuses(node *core.Node) map[core.NodeConditionType]core.ConditionStatus {
+	statuses := make(map[core.NodeConditionType]core.ConditionStatus, len(node.Status.Conditions))
+	for _, cond := range node.Status.Conditions {
+		statuses[cond.Type] = cond.Status
+	}
+	return statuses
+}
+
 func (op *Operator) reconcileNode(key string) error {
 	obj, exists, err := op.nodeInformer.GetIndexer().GetByKey(key)
 	if err != nil {
@@ -75,7 +88,7 @@ func (op *Operator) ensureNode(node *core.Node) error {
 		oldAlerts.Insert(keys...)
 	}
 
-	newAlerts, err := findNodeAlert(op.kubeClient, op.naLister, node.ObjectMeta)
+	newAlerts, err := findNodeAlert(op.kubeClient, op.naLister, op.atLister, node)
 	if err != nil {
 		return err
 	}
@@ -84,6 +97,7 @@ func (op *Operator) ensureNode(node *core.Node) error {
 		alert := newAlerts[i]
 
 		err = op.nodeHost.Apply(alert, node)
+		op.targetErrors.set(alertStatusKey(api.ResourceKindNodeAlert, alert.Namespace, alert.Name), op.nodeHost.GetHost(alert.Namespace, node), err)
 		if err != nil {
 			op.recorder.Eventf(
 				alert.ObjectReference(),
@@ -93,6 +107,8 @@ func (op *Operator) ensureNode(node *core.Node) error {
 				node.Name, err,
 			)
 			errlist = append(errlist, err)
+		} else if err = op.enqueueDependentPods(alert, node.Name); err != nil {
+			errlist = append(errlist, err)
 		}
 
 		key, _ := cache.MetaNamespaceKeyFunc(alert)
@@ -138,9 +154,45 @@ func (op *Operator) ensureNode(node *core.Node) error {
 	if err != nil {
 		errlist = append(errlist, err)
 	}
+
+	for _, key := range append(newKeys, oldAlerts.List()...) {
+		if namespace, name, err := cache.SplitMetaNamespaceKey(key); err == nil {
+			op.enqueueAlertStatus(api.ResourceKindNodeAlert, namespace, name)
+		}
+	}
 	return utilerrors.NewAggregate(errlist)
 }
 
+// enqueueDependentPods enqueues the pods on a node which are checked by PodAlerts depending on
+// a NodeAlert, so that their Icinga dependencies are created once the NodeAlert is applied to the node.
+func (op *Operator) enqueueDependentPods(alert *api.NodeAlert, nodeName string) error {
+	podAlerts, err := op.paLister.PodAlerts(alert.Namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	dependent := false
+	for _, pa := range podAlerts {
+		if pa.Spec.DependsOn != nil && pa.Spec.DependsOn.NodeAlert == alert.Name {
+			dependent = true
+			break
+		}
+	}
+	if !dependent {
+		return nil
+	}
+
+	pods, err := op.podLister.Pods(alert.Namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == nodeName {
+			queue.Enqueue(op.podQueue.GetQueue(), pod)
+		}
+	}
+	return nil
+}
+
 func (op *Operator) forceDeleteIcingaObjectsForNode(name string) error {
 	namespaces, err := op.nsLister.List(labels.Everything())
 	if err != nil {
@@ -0,0 +1,283 @@
+package operator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/client/clientset/versioned/scheme"
+	"github.com/appscode/searchlight/pkg/icinga"
+	"github.com/appscode/searchlight/pkg/plugin"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/tools/cache"
+)
+
+const driftObjectCheckCommand = "checkcommand"
+
+// PlannedChange is a change of an Icinga object the operator would make.
+type PlannedChange struct {
+	Action string `json:"action"`
+	Object string `json:"object"`
+	// Name is the full Icinga name of the object, e.g. host!service for services
+	Name string `json:"name"`
+	// Alert is the alert the object is created or updated for
+	Alert  string `json:"alert,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// InvalidAlert is an alert which is not applied to Icinga, since it fails validation.
+type InvalidAlert struct {
+	Alert  string `json:"alert"`
+	Reason string `json:"reason"`
+}
+
+// Plan lists the changes which turn the Icinga objects and CheckCommands into the state
+// described by the alerts and SearchlightPlugins.
+type Plan struct {
+	Changes []PlannedChange `json:"changes"`
+	Invalid []InvalidAlert  `json:"invalid,omitempty"`
+}
+
+// Plan computes the changes the operator would make to Icinga, using the same matching as the
+// controllers and the drift reconciler. It neither changes Icinga nor patches any object.
+// Without an Icinga client, changes are planned against an empty Icinga.
+func (op *Operator) Plan() (*Plan, error) {
+	plugins, err := op.plannedPlugins()
+	if err != nil {
+		return nil, err
+	}
+	for _, wp := range plugins {
+		if wp.IsValid() == nil {
+			registerCheckCommand(wp)
+		}
+	}
+
+	var hosts []string
+	var services []icinga.Service
+	var notifications []icinga.Notification
+	if op.icingaClient != nil {
+		if hosts, err = op.inventory.Hosts(); err != nil {
+			return nil, err
+		}
+		if services, err = op.inventory.Services(); err != nil {
+			return nil, err
+		}
+		if notifications, err = op.inventory.Notifications(); err != nil {
+			return nil, err
+		}
+	}
+	state, err := op.desiredIcingaState()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Plan{Changes: []PlannedChange{}}
+	for _, c := range diffIcingaState(hosts, services, notifications, state) {
+		change := PlannedChange{Action: c.action, Object: c.object, Name: c.name(), Reason: c.reason}
+		if c.recreate {
+			change.Reason += ", service is recreated"
+		}
+		if c.target != nil {
+			change.Alert = alertName(c.target.alert)
+		}
+		p.Changes = append(p.Changes, change)
+	}
+
+	commands, err := op.planCheckCommands(plugins)
+	if err != nil {
+		return nil, err
+	}
+	p.Changes = append(p.Changes, commands...)
+
+	if p.Invalid, err = op.invalidAlerts(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func alertName(alert api.Alert) string {
+	ref := alert.ObjectReference()
+	return ref.Kind + " " + ref.Namespace + "/" + ref.Name
+}
+
+// plannedPlugins returns the SearchlightPlugins in the informer cache, with the builtin plugins
+// as the operator creates them on start.
+func (op *Operator) plannedPlugins() ([]*api.SearchlightPlugin, error) {
+	plugins, err := op.pluginLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string]*api.SearchlightPlugin{}
+	for _, wp := range plugins {
+		byName[wp.Name] = wp
+	}
+	for _, wp := range builtinSearchlightPlugins() {
+		if cur, ok := byName[wp.Name]; ok {
+			cur = cur.DeepCopy()
+			cur.Spec = wp.Spec
+			wp = cur
+		}
+		byName[wp.Name] = wp
+	}
+
+	result := make([]*api.SearchlightPlugin, 0, len(byName))
+	for _, wp := range byName {
+		result = append(result, wp)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// planCheckCommands compares the CheckCommands generated for SearchlightPlugins with the ones deployed
+// through the Icinga config package of Searchlight.
+func (op *Operator) planCheckCommands(plugins []*api.SearchlightPlugin) ([]PlannedChange, error) {
+	deployed := map[string]string{}
+	if op.icingaClient != nil {
+		var err error
+		if deployed, err = op.checkCommands.ActiveFiles(); err != nil {
+			return nil, err
+		}
+	}
+
+	var changes []PlannedChange
+	for _, wp := range plugins {
+		if err := wp.IsValid(); err != nil {
+			continue
+		}
+		change := PlannedChange{Object: driftObjectCheckCommand, Name: wp.Name, Alert: api.ResourceKindSearchlightPlugin + " " + wp.Name}
+		path := checkCommandPath(wp.Name)
+		cur, ok := deployed[path]
+		if !ok {
+			change.Action, change.Reason = driftActionCreate, "CheckCommand is missing"
+			changes = append(changes, change)
+			continue
+		}
+		delete(deployed, path)
+		if cur != plugin.GenerateCheckCommand(wp) {
+			change.Action, change.Reason = driftActionUpdate, "CheckCommand differs from the SearchlightPlugin"
+			changes = append(changes, change)
+		}
+	}
+
+	names := make([]string, 0, len(deployed))
+	for path := range deployed {
+		names = append(names, checkCommandName(path))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		changes = append(changes, PlannedChange{
+			Action: driftActionDelete, Object: driftObjectCheckCommand, Name: name,
+			Reason: "no SearchlightPlugin defines the CheckCommand",
+		})
+	}
+	return changes, nil
+}
+
+// invalidAlerts returns the alerts in the informer caches which fail validation.
+func (op *Operator) invalidAlerts() ([]InvalidAlert, error) {
+	var alerts []api.Alert
+	cas, err := op.caLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range cas {
+		alerts = append(alerts, a)
+	}
+	nas, err := op.naLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range nas {
+		alerts = append(alerts, a)
+	}
+	pas, err := op.paLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range pas {
+		alerts = append(alerts, a)
+	}
+	was, err := op.waLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range was {
+		alerts = append(alerts, a)
+	}
+	gas, err := op.gaLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range gas {
+		alerts = append(alerts, a)
+	}
+
+	var invalid []InvalidAlert
+	for _, a := range alerts {
+		if err := op.validate(a); err != nil {
+			invalid = append(invalid, InvalidAlert{Alert: alertName(a), Reason: err.Error()})
+		}
+	}
+	sort.Slice(invalid, func(i, j int) bool { return invalid[i].Alert < invalid[j].Alert })
+	return invalid, nil
+}
+
+// AddManifests adds the alerts, AlertTemplates and SearchlightPlugins in YAML or JSON manifests to
+// the informer caches, replacing the objects with the same name, so that a plan shows the changes
+// of applying them. Namespaced objects without a namespace are added to the given namespace.
+// It must be called after the caches are synced.
+func (op *Operator) AddManifests(r io.Reader, namespace string) error {
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		var raw runtime.RawExtension
+		if err := decoder.Decode(&raw); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(raw.Raw)) == 0 || bytes.Equal(bytes.TrimSpace(raw.Raw), []byte("null")) {
+			continue
+		}
+		obj, gvk, err := scheme.Codecs.UniversalDeserializer().Decode(raw.Raw, nil, nil)
+		if err != nil {
+			return err
+		}
+
+		var indexer cache.Indexer
+		namespaced := true
+		switch obj.(type) {
+		case *api.ClusterAlert:
+			indexer = op.caInformer.GetIndexer()
+		case *api.NodeAlert:
+			indexer = op.naInformer.GetIndexer()
+		case *api.PodAlert:
+			indexer = op.paInformer.GetIndexer()
+		case *api.WorkloadAlert:
+			indexer = op.waInformer.GetIndexer()
+		case *api.GlobalAlert:
+			indexer = op.gaInformer.GetIndexer()
+			namespaced = false
+		case *api.AlertTemplate:
+			indexer = op.atInformer.GetIndexer()
+		case *api.SearchlightPlugin:
+			indexer = op.pluginInformer.GetIndexer()
+			namespaced = false
+		default:
+			return errors.Errorf("unsupported kind %s", gvk.Kind)
+		}
+		if m, err := meta.Accessor(obj); err != nil {
+			return err
+		} else if namespaced && m.GetNamespace() == "" {
+			m.SetNamespace(namespace)
+		}
+		if err := indexer.Update(obj); err != nil {
+			return fmt.Errorf("failed to add %s: %v", gvk.Kind, err)
+		}
+	}
+}
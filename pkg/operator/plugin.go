@@ -1,40 +1,41 @@
 package operator
 
 import (
-	"bytes"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
+	"sync"
 
-	"github.com/appscode/go/ioutil"
 	"github.com/appscode/go/log"
 	utilerrors "github.com/appscode/go/util/errors"
 	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
 	"github.com/appscode/searchlight/client/clientset/versioned/typed/monitoring/v1alpha1/util"
+	"github.com/appscode/searchlight/pkg/eventer"
 	"github.com/appscode/searchlight/pkg/icinga"
 	"github.com/appscode/searchlight/pkg/plugin"
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
 	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/tools/remotecommand"
-	"kmodules.xyz/client-go/meta"
 	"kmodules.xyz/client-go/tools/queue"
 )
 
 func (op *Operator) initPluginWatcher() {
 	op.pluginInformer = op.monInformerFactory.Monitoring().V1alpha1().SearchlightPlugins().Informer()
-	op.pluginQueue = queue.New("SearchlightPlugin", op.MaxNumRequeues, op.NumThreads, op.reconcilePlugin)
+	op.pluginQueue = op.newQueue("SearchlightPlugin", op.reconcilePlugin)
 	op.pluginInformer.AddEventHandler(queue.NewEventHandler(op.pluginQueue.GetQueue(), func(oldObj, newObj interface{}) bool {
 		old := oldObj.(*api.SearchlightPlugin)
 		nu := newObj.(*api.SearchlightPlugin)
 		return !reflect.DeepEqual(old.Spec, nu.Spec)
 	}))
 	op.pluginLister = op.monInformerFactory.Monitoring().V1alpha1().SearchlightPlugins().Lister()
+	op.commandState = &checkCommandState{rejected: map[string]rejectedCheckCommand{}}
 }
 
 func (op *Operator) reconcilePlugin(key string) error {
@@ -59,11 +60,64 @@ func (op *Operator) reconcilePlugin(key string) error {
 	searchlightPlugin := obj.(*api.SearchlightPlugin).DeepCopy()
 	log.Infof("Sync/Add/Update for SearchlightPlugin %s\n", searchlightPlugin.GetName())
 
-	return op.ensureCheckCommand(searchlightPlugin)
+	err = op.ensureCheckCommand(searchlightPlugin)
+	if e2 := op.updatePluginStatus(searchlightPlugin, err); e2 != nil {
+		log.Errorf("failed to update status of SearchlightPlugin %s. Reason: %v", searchlightPlugin.Name, e2)
+	}
+	return err
+}
+
+// enqueueAlertPlugins enqueues the SearchlightPlugins used as check command by alerts or which paused
+// them, so that the alerts are listed in the status of the plugins. Names of built-in checks without a
+// SearchlightPlugin are skipped, as reconciling them would pause the alerts using them.
+func (op *Operator) enqueueAlertPlugins(objs ...interface{}) {
+	for _, obj := range objs {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		alert, ok := obj.(api.Alert)
+		if !ok {
+			continue
+		}
+		names := []string{alert.Command()}
+		if m, err := meta.Accessor(obj); err == nil {
+			if name := m.GetAnnotations()[api.AnnotationKeyPausedByPlugin]; name != "" {
+				names = append(names, name)
+			}
+		}
+		for _, name := range names {
+			if _, err := op.pluginLister.Get(name); err == nil {
+				op.pluginQueue.GetQueue().Add(name)
+			}
+		}
+	}
+}
+
+// alertPluginsChanged returns true if an update of an alert may change the alerts listed in the status
+// of SearchlightPlugins.
+func alertPluginsChanged(oldMeta, newMeta metav1.ObjectMeta, oldSpec, newSpec interface{}) bool {
+	return !reflect.DeepEqual(oldSpec, newSpec) ||
+		oldMeta.Annotations[api.AnnotationKeyPausedByPlugin] != newMeta.Annotations[api.AnnotationKeyPausedByPlugin]
 }
 
 func (op *Operator) ensureCheckCommand(wp *api.SearchlightPlugin) error {
+	if err := wp.IsValid(); err != nil {
+		return errors.Wrapf(err, "invalid SearchlightPlugin %s", wp.Name)
+	}
 
+	registerCheckCommand(wp)
+	if err := op.syncCheckCommands(); err != nil {
+		return err
+	}
+	if reason, rejected := op.commandState.rejection(wp.Name); rejected {
+		return errors.Errorf(`CheckCommand "%s" failed Icinga config validation: %s`, wp.Name, reason)
+	}
+	return nil
+}
+
+// registerCheckCommand registers the CheckCommand of a SearchlightPlugin for the alert kinds it supports,
+// so that alerts using it pass validation.
+func registerCheckCommand(wp *api.SearchlightPlugin) {
 	ic := api.IcingaCommand{
 		Name: wp.Name,
 		Vars: &api.PluginVars{
@@ -82,30 +136,36 @@ func (op *Operator) ensureCheckCommand(wp *api.SearchlightPlugin) error {
 			api.NodeCommands.Insert(wp.Name, ic)
 		} else if t == api.ResourceKindPodAlert {
 			api.PodCommands.Insert(wp.Name, ic)
+		} else if t == api.ResourceKindWorkloadAlert {
+			api.WorkloadCommands.Insert(wp.Name, ic)
 		}
 	}
-
-	return op.addPluginSupport(wp)
 }
 
 func (op *Operator) ensureCheckCommandDeleted(name string) error {
-	pod, err := op.GetIcingaPod()
-	if err != nil {
-		return errors.WithMessage(err, "failed to get Icinga2 Pod name.")
-	}
-
-	var errs []error
+	// Pause the alerts using this plugin, marking them so that they are listed in the status of the
+	// plugin if it is created again. Alerts paused by the user are left alone.
+	var (
+		errs []error
+		err  error
+	)
 	{
 		// Pause all ClusterAlerts for this plugin
 		err = cache.ListAll(op.caInformer.GetIndexer(), labels.Everything(), func(obj interface{}) {
-			_, _, err = util.PatchClusterAlert(op.extClient.MonitoringV1alpha1(), obj.(*api.ClusterAlert), func(alert *api.ClusterAlert) *api.ClusterAlert {
-				pause := alert.Spec.Check == name
-				alert.Spec.Paused = pause
-				return alert
+			alert := obj.(*api.ClusterAlert)
+			if alert.Spec.Check != name || alert.Spec.Paused {
+				return
+			}
+			_, _, err := util.PatchClusterAlert(op.extClient.MonitoringV1alpha1(), alert, func(in *api.ClusterAlert) *api.ClusterAlert {
+				in.Spec.Paused = true
+				setPausedByPlugin(&in.ObjectMeta, name)
+				return in
 			})
 			if err != nil {
 				errs = append(errs, err)
+				return
 			}
+			op.recordAlertPaused(alert, name)
 		})
 		if err != nil {
 			errs = append(errs, err)
@@ -114,14 +174,20 @@ func (op *Operator) ensureCheckCommandDeleted(name string) error {
 	{
 		// Pause all PodAlerts for this plugin
 		err = cache.ListAll(op.paInformer.GetIndexer(), labels.Everything(), func(obj interface{}) {
-			_, _, err = util.PatchPodAlert(op.extClient.MonitoringV1alpha1(), obj.(*api.PodAlert), func(alert *api.PodAlert) *api.PodAlert {
-				pause := alert.Spec.Check == name
-				alert.Spec.Paused = pause
-				return alert
+			alert := obj.(*api.PodAlert)
+			if alert.Spec.Check != name || alert.Spec.Paused {
+				return
+			}
+			_, _, err := util.PatchPodAlert(op.extClient.MonitoringV1alpha1(), alert, func(in *api.PodAlert) *api.PodAlert {
+				in.Spec.Paused = true
+				setPausedByPlugin(&in.ObjectMeta, name)
+				return in
 			})
 			if err != nil {
 				errs = append(errs, err)
+				return
 			}
+			op.recordAlertPaused(alert, name)
 		})
 		if err != nil {
 			errs = append(errs, err)
@@ -130,14 +196,64 @@ func (op *Operator) ensureCheckCommandDeleted(name string) error {
 	{
 		// Pause all NodeAlerts for this plugin
 		err = cache.ListAll(op.naInformer.GetIndexer(), labels.Everything(), func(obj interface{}) {
-			_, _, err = util.PatchNodeAlert(op.extClient.MonitoringV1alpha1(), obj.(*api.NodeAlert), func(alert *api.NodeAlert) *api.NodeAlert {
-				pause := alert.Spec.Check == name
-				alert.Spec.Paused = pause
-				return alert
+			alert := obj.(*api.NodeAlert)
+			if alert.Spec.Check != name || alert.Spec.Paused {
+				return
+			}
+			_, _, err := util.PatchNodeAlert(op.extClient.MonitoringV1alpha1(), alert, func(in *api.NodeAlert) *api.NodeAlert {
+				in.Spec.Paused = true
+				setPausedByPlugin(&in.ObjectMeta, name)
+				return in
 			})
 			if err != nil {
 				errs = append(errs, err)
+				return
 			}
+			op.recordAlertPaused(alert, name)
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	{
+		// Pause all WorkloadAlerts for this plugin
+		err = cache.ListAll(op.waInformer.GetIndexer(), labels.Everything(), func(obj interface{}) {
+			alert := obj.(*api.WorkloadAlert)
+			if alert.Spec.Check != name || alert.Spec.Paused {
+				return
+			}
+			_, _, err := util.PatchWorkloadAlert(op.extClient.MonitoringV1alpha1(), alert, func(in *api.WorkloadAlert) *api.WorkloadAlert {
+				in.Spec.Paused = true
+				setPausedByPlugin(&in.ObjectMeta, name)
+				return in
+			})
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			op.recordAlertPaused(alert, name)
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	{
+		// Pause all GlobalAlerts for this plugin
+		err = cache.ListAll(op.gaInformer.GetIndexer(), labels.Everything(), func(obj interface{}) {
+			alert := obj.(*api.GlobalAlert)
+			if alert.Spec.Check != name || alert.Spec.Paused {
+				return
+			}
+			_, _, err := util.PatchGlobalAlert(op.extClient.MonitoringV1alpha1(), alert, func(in *api.GlobalAlert) *api.GlobalAlert {
+				in.Spec.Paused = true
+				setPausedByPlugin(&in.ObjectMeta, name)
+				return in
+			})
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			op.recordAlertPaused(alert, name)
 		})
 		if err != nil {
 			errs = append(errs, err)
@@ -159,116 +275,205 @@ func (op *Operator) ensureCheckCommandDeleted(name string) error {
 	if err := icinga.NewPodHost(op.icingaClient, "").DeleteChecks(name); err != nil {
 		return err
 	}
+	if err := icinga.NewWorkloadHost(op.icingaClient, "").DeleteChecks(name); err != nil {
+		return err
+	}
+	if err := icinga.NewGlobalHost(op.icingaClient, "").DeleteChecks(name); err != nil {
+		return err
+	}
 
 	// Delete IcingaCommand definition from Maps
 	api.ClusterCommands.Delete(name)
 	api.NodeCommands.Delete(name)
 	api.PodCommands.Delete(name)
+	api.WorkloadCommands.Delete(name)
 
-	// Remove CheckCommand config file from custom.d folder
+	// CheckCommands written to the custom.d folder by earlier versions stay loaded until Icinga restarts
 	path := filepath.Join(op.ConfigRoot, "custom.d", fmt.Sprintf("%s.conf", name))
-	if err := os.Remove(path); err != nil {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
 		return err
 	}
 
-	// Restart Icinga2
-	if err := op.restartIcinga2(pod); err != nil {
-		return err
-	}
+	return op.syncCheckCommands()
+}
 
-	return nil
+func setPausedByPlugin(meta *metav1.ObjectMeta, plugin string) {
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[api.AnnotationKeyPausedByPlugin] = plugin
 }
 
-func (op *Operator) addPluginSupport(wp *api.SearchlightPlugin) error {
+func (op *Operator) recordAlertPaused(alert api.Alert, plugin string) {
+	op.recorder.Eventf(
+		alert.ObjectReference(),
+		core.EventTypeWarning,
+		eventer.EventReasonAlertPaused,
+		`Paused, since SearchlightPlugin "%s" was deleted`,
+		plugin,
+	)
+}
 
-	checkCommandString := plugin.GenerateCheckCommand(wp)
+const checkCommandPackage = "searchlight"
 
-	pod, err := op.GetIcingaPod()
-	if err != nil {
-		return errors.WithMessage(err, "failed to get Icinga2 Pod name.")
-	}
+// checkCommandState tracks the CheckCommands deployed through the Icinga config package of Searchlight.
+type checkCommandState struct {
+	mu sync.Mutex
+	// deployed are the files of the active stage, nil if unknown
+	deployed map[string]string
+	// rejected are the files which failed Icinga config validation
+	rejected map[string]rejectedCheckCommand
+}
 
-	if err := ioutil.EnsureDirectory(filepath.Join(op.ConfigRoot, "custom.d")); err != nil {
-		return err
-	}
+type rejectedCheckCommand struct {
+	data   string
+	reason string
+}
 
-	path := filepath.Join(op.ConfigRoot, "custom.d", fmt.Sprintf("%s.conf", wp.Name))
+// rejection returns the Icinga config validation errors of the CheckCommand of a SearchlightPlugin, if
+// it was rejected.
+func (st *checkCommandState) rejection(name string) (string, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	r, ok := st.rejected[checkCommandPath(name)]
+	return r.reason, ok
+}
 
-	if !ioutil.WriteString(path, checkCommandString) {
-		return fmt.Errorf(`failed to write CheckCommand "%s" in %s`, wp.Name, path)
-	}
+func checkCommandPath(name string) string {
+	return "conf.d/" + name + ".conf"
+}
 
-	return op.restartIcinga2(pod)
+func checkCommandName(path string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(path, "conf.d/"), ".conf")
 }
 
-func (op *Operator) restartIcinga2(pod *core.Pod) error {
-	podExecOptions := &core.PodExecOptions{
-		Container: "icinga",
-		Command:   []string{"sh", "-c", "kill -9 $(cat /run/icinga2/icinga2.pid)"},
-		Stdout:    true,
-		Stderr:    true,
+// syncCheckCommands deploys the CheckCommands of all valid SearchlightPlugins as a new stage of the Icinga
+// config package of Searchlight, unless they are deployed already. Icinga reloads once the stage passed
+// config validation, without restarting. CheckCommands which fail validation are reported on their
+// SearchlightPlugin and stay at their deployed version, so that other SearchlightPlugins are still deployed.
+func (op *Operator) syncCheckCommands() error {
+	st := op.commandState
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if st.deployed == nil {
+		deployed, err := op.checkCommands.ActiveFiles()
+		if err != nil {
+			return err
+		}
+		st.deployed = deployed
 	}
 
-	_, err := op.executeCommand(pod, podExecOptions)
+	plugins, err := op.pluginLister.List(labels.Everything())
 	if err != nil {
 		return err
 	}
-
-	return nil
-}
-
-func (op *Operator) GetIcingaPod() (*core.Pod, error) {
-	namespace := meta.Namespace()
-	podName, err := os.Hostname()
-	if err != nil {
-		return nil, err
+	files := map[string]string{}
+	for _, wp := range plugins {
+		if wp.IsValid() != nil {
+			continue
+		}
+		path := checkCommandPath(wp.Name)
+		files[path] = plugin.GenerateCheckCommand(wp)
+		if rejected, ok := st.rejected[path]; ok {
+			if rejected.data != files[path] {
+				delete(st.rejected, path)
+			} else if deployed, ok := st.deployed[path]; ok {
+				files[path] = deployed
+			} else {
+				delete(files, path)
+			}
+		}
+	}
+	if reflect.DeepEqual(files, st.deployed) {
+		return nil
 	}
 
-	return &core.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      podName,
-			Namespace: namespace,
-		},
-	}, nil
+	err = op.deployCheckCommands(files)
+	verr, ok := errors.Cause(err).(*icinga.ValidationError)
+	if !ok {
+		return err
+	}
+	var rejected []string
+	for path, data := range files {
+		if !verr.Mentions(path) {
+			continue
+		}
+		st.rejected[path] = rejectedCheckCommand{data: data, reason: verr.Errors()}
+		if deployed, ok := st.deployed[path]; ok {
+			files[path] = deployed
+		} else {
+			delete(files, path)
+		}
+		rejected = append(rejected, checkCommandName(path))
+	}
+	if len(rejected) == 0 {
+		return err
+	}
+	for _, name := range rejected {
+		if wp, e2 := op.pluginLister.Get(name); e2 == nil {
+			op.recorder.Eventf(
+				wp,
+				core.EventTypeWarning,
+				eventer.EventReasonFailedToSync,
+				`CheckCommand "%s" failed Icinga config validation: %s`,
+				name, verr.Errors(),
+			)
+		}
+	}
+	if reflect.DeepEqual(files, st.deployed) {
+		return nil
+	}
+	return op.deployCheckCommands(files)
 }
 
-func (op *Operator) executeCommand(pod *core.Pod, podExecOptions *core.PodExecOptions) (string, error) {
-	var (
-		execOut bytes.Buffer
-		execErr bytes.Buffer
-	)
-
-	req := op.kubeClient.CoreV1().RESTClient().Post().
-		Resource("pods").
-		Name(pod.Name).
-		Namespace(pod.Namespace).
-		SubResource("exec")
-
-	req.VersionedParams(podExecOptions, scheme.ParameterCodec)
-
-	exec, err := remotecommand.NewSPDYExecutor(op.clientConfig, "POST", req.URL())
-	if err != nil {
-		return "", err
+// deployCheckCommands deploys CheckCommand files and records them as deployed. CheckCommands written to
+// the custom.d folder by earlier versions are removed first, since Icinga rejects duplicate objects. They
+// are restored if deployment fails.
+func (op *Operator) deployCheckCommands(files map[string]string) error {
+	st := op.commandState
+
+	legacy := map[string][]byte{}
+	restore := func() {
+		for path, data := range legacy {
+			if err := ioutil.WriteFile(path, data, 0644); err != nil {
+				log.Errorf("failed to restore CheckCommand %s. Reason: %v", path, err)
+			}
+		}
 	}
-
-	err = exec.Stream(remotecommand.StreamOptions{
-		Stdout: &execOut,
-		Stderr: &execErr,
-	})
-
-	if err != nil {
-		return "", err
+	for path := range files {
+		name := checkCommandName(path)
+		legacyPath := filepath.Join(op.ConfigRoot, "custom.d", name+".conf")
+		data, err := ioutil.ReadFile(legacyPath)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			restore()
+			return err
+		}
+		if err := os.Remove(legacyPath); err != nil {
+			restore()
+			return err
+		}
+		legacy[legacyPath] = data
 	}
 
-	if execErr.Len() > 0 {
-		return "", errors.New("failed to exec to restart Icinga2")
+	err := op.checkCommands.Deploy(files)
+	if err == nil {
+		st.deployed = files
+		return nil
 	}
-
-	return execOut.String(), nil
+	restore()
+	if _, ok := err.(*icinga.ValidationError); !ok {
+		// the active stage is unknown, e.g. after a timeout
+		st.deployed = nil
+	}
+	return err
 }
 
-func (op *Operator) createBuiltinSearchlightPlugin() error {
-	plugins := []*api.SearchlightPlugin{
+// builtinSearchlightPlugins returns the SearchlightPlugins created by the operator on start.
+func builtinSearchlightPlugins() []*api.SearchlightPlugin {
+	return []*api.SearchlightPlugin{
 		plugin.GetComponentStatusPlugin(),
 		plugin.GetJsonPathPlugin(),
 		plugin.GetNodeExistsPlugin(),
@@ -281,10 +486,14 @@ func (op *Operator) createBuiltinSearchlightPlugin() error {
 		plugin.GetPodStatusPlugin(),
 		plugin.GetPodVolumePlugin(),
 		plugin.GetPodExecPlugin(),
+		plugin.GetWorkloadStatusPlugin(),
+		plugin.GetWorkloadRestartsPlugin(),
 	}
+}
 
+func (op *Operator) createBuiltinSearchlightPlugin() error {
 	var errs []error
-	for _, p := range plugins {
+	for _, p := range builtinSearchlightPlugins() {
 		_, _, err := util.CreateOrPatchSearchlightPlugin(op.extClient.MonitoringV1alpha1(), p.ObjectMeta, func(sp *api.SearchlightPlugin) *api.SearchlightPlugin {
 			sp.Spec = p.Spec
 			return sp
@@ -0,0 +1,142 @@
+package operator
+
+import (
+	"reflect"
+	"sort"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/client/clientset/versioned/typed/monitoring/v1alpha1/util"
+	"github.com/appscode/searchlight/pkg/plugin"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// updatePluginStatus updates the status of a SearchlightPlugin with the result of syncing its CheckCommand
+// and the alerts using it.
+func (op *Operator) updatePluginStatus(wp *api.SearchlightPlugin, syncErr error) error {
+	status := wp.Status.DeepCopy()
+	status.ObservedGeneration = wp.Generation
+
+	if err := wp.IsValid(); err != nil {
+		status.CheckCommand = ""
+		status.SetCondition(api.SearchlightPluginCondition{
+			Type:    api.PluginConditionRegistered,
+			Status:  core.ConditionFalse,
+			Reason:  ReasonInvalidPlugin,
+			Message: err.Error(),
+		})
+		status.SetCondition(api.SearchlightPluginCondition{
+			Type:    api.PluginConditionIcingaSynced,
+			Status:  core.ConditionFalse,
+			Reason:  ReasonInvalidPlugin,
+			Message: "CheckCommand of an invalid SearchlightPlugin is not added to Icinga",
+		})
+	} else {
+		status.CheckCommand = plugin.GenerateCheckCommand(wp)
+		status.SetCondition(api.SearchlightPluginCondition{
+			Type:   api.PluginConditionRegistered,
+			Status: core.ConditionTrue,
+		})
+		if reason, rejected := op.commandState.rejection(wp.Name); rejected {
+			status.SetCondition(api.SearchlightPluginCondition{
+				Type:    api.PluginConditionIcingaSynced,
+				Status:  core.ConditionFalse,
+				Reason:  ReasonIcingaValidationFailed,
+				Message: reason,
+			})
+		} else if syncErr != nil {
+			status.SetCondition(api.SearchlightPluginCondition{
+				Type:    api.PluginConditionIcingaSynced,
+				Status:  core.ConditionFalse,
+				Reason:  ReasonSyncFailed,
+				Message: syncErr.Error(),
+			})
+		} else {
+			status.SetCondition(api.SearchlightPluginCondition{
+				Type:   api.PluginConditionIcingaSynced,
+				Status: core.ConditionTrue,
+				Reason: ReasonSynced,
+			})
+		}
+	}
+
+	var err error
+	if status.Alerts, status.PausedAlerts, err = op.pluginAlerts(wp.Name); err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(&wp.Status, status) {
+		return nil
+	}
+	_, err = util.UpdateSearchlightPluginStatus(op.extClient.MonitoringV1alpha1(), wp, func(in *api.SearchlightPluginStatus) *api.SearchlightPluginStatus {
+		return status
+	}, api.EnableStatusSubresource)
+	return err
+}
+
+// pluginAlerts returns the alerts using a SearchlightPlugin as check command, and the alerts still paused
+// since the SearchlightPlugin was deleted. Empty lists are returned as nil.
+func (op *Operator) pluginAlerts(name string) (using, paused *api.PluginAlerts, err error) {
+	using, paused = &api.PluginAlerts{}, &api.PluginAlerts{}
+	add := func(usingList, pausedList *[]string, meta metav1.ObjectMeta, check string, isPaused bool) {
+		key := meta.Name
+		if meta.Namespace != "" {
+			key = meta.Namespace + "/" + meta.Name
+		}
+		if check == name {
+			*usingList = append(*usingList, key)
+		}
+		if isPaused && meta.Annotations[api.AnnotationKeyPausedByPlugin] == name {
+			*pausedList = append(*pausedList, key)
+		}
+	}
+
+	cas, err := op.caLister.List(labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, a := range cas {
+		add(&using.ClusterAlerts, &paused.ClusterAlerts, a.ObjectMeta, a.Spec.Check, a.Spec.Paused)
+	}
+	nas, err := op.naLister.List(labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, a := range nas {
+		add(&using.NodeAlerts, &paused.NodeAlerts, a.ObjectMeta, a.Spec.Check, a.Spec.Paused)
+	}
+	pas, err := op.paLister.List(labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, a := range pas {
+		add(&using.PodAlerts, &paused.PodAlerts, a.ObjectMeta, a.Spec.Check, a.Spec.Paused)
+	}
+	was, err := op.waLister.List(labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, a := range was {
+		add(&using.WorkloadAlerts, &paused.WorkloadAlerts, a.ObjectMeta, a.Spec.Check, a.Spec.Paused)
+	}
+	gas, err := op.gaLister.List(labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, a := range gas {
+		add(&using.GlobalAlerts, &paused.GlobalAlerts, a.ObjectMeta, a.Spec.Check, a.Spec.Paused)
+	}
+
+	return sortPluginAlerts(using), sortPluginAlerts(paused), nil
+}
+
+func sortPluginAlerts(a *api.PluginAlerts) *api.PluginAlerts {
+	if a.Len() == 0 {
+		return nil
+	}
+	for _, list := range [][]string{a.ClusterAlerts, a.NodeAlerts, a.PodAlerts, a.WorkloadAlerts, a.GlobalAlerts} {
+		sort.Strings(list)
+	}
+	return a
+}
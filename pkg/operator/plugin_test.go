@@ -0,0 +1,358 @@
+package operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	mon_listers "github.com/appscode/searchlight/client/listers/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/pkg/icinga"
+	"github.com/appscode/searchlight/pkg/plugin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"kmodules.xyz/client-go/tools/queue"
+)
+
+// fakeConfigPackages serves the Icinga config package API for the package of Searchlight. Stages whose
+// files contain the rejected string fail config validation, with the offending files named in the log.
+type fakeConfigPackages struct {
+	mu       sync.Mutex
+	rejected string
+	// fail makes creating stages fail with an internal error
+	fail    bool
+	created bool
+	active  string
+	stages  map[string]map[string]string
+	// deployments counts the stages uploaded
+	deployments int
+}
+
+func newFakeConfigPackages(rejected string) *fakeConfigPackages {
+	return &fakeConfigPackages{rejected: rejected, stages: map[string]map[string]string{}}
+}
+
+func (f *fakeConfigPackages) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/v1/config/"), "/", 4)
+	switch {
+	case parts[0] == "packages" && r.Method == http.MethodGet:
+		results := []interface{}{}
+		if f.created {
+			var stages []string
+			for stage := range f.stages {
+				stages = append(stages, stage)
+			}
+			sort.Strings(stages)
+			results = append(results, map[string]interface{}{"name": checkCommandPackage, "active-stage": f.active, "stages": stages})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	case parts[0] == "packages" && r.Method == http.MethodPost:
+		f.created = true
+		w.Write([]byte(`{"results": []}`))
+	case parts[0] == "stages" && r.Method == http.MethodPost:
+		if f.fail {
+			http.Error(w, `{"error": 500}`, http.StatusInternalServerError)
+			return
+		}
+		var body struct {
+			Files map[string]string `json:"files"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.deployments++
+		stage := fmt.Sprintf("stage-%d", f.deployments)
+		files := map[string]string{"status": "0"}
+		var log []string
+		for path, data := range body.Files {
+			files[path] = data
+			if strings.Contains(data, f.rejected) {
+				log = append(log,
+					"[2018-06-01 12:00:00 +0000] critical/config: Error: Validation failed for object 'invalid'",
+					"Location: in /var/lib/icinga2/api/packages/searchlight/"+stage+"/"+path+": 3:3-3:10")
+			}
+		}
+		if len(log) > 0 {
+			files["status"] = "1"
+			files["startup.log"] = strings.Join(log, "\n")
+		} else {
+			f.active = stage
+		}
+		f.stages[stage] = files
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": []interface{}{map[string]string{"stage": stage}}})
+	case parts[0] == "stages" && r.Method == http.MethodGet && len(parts) == 3:
+		results := []interface{}{}
+		for path := range f.stages[parts[2]] {
+			results = append(results, map[string]string{"name": path, "type": "file"})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	case parts[0] == "stages" && r.Method == http.MethodDelete && len(parts) == 3:
+		delete(f.stages, parts[2])
+		w.Write([]byte(`{"results": []}`))
+	case parts[0] == "files" && len(parts) == 4:
+		data, ok := f.stages[parts[2]][parts[3]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(data))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// activeFiles returns the CheckCommand files of the active stage.
+func (f *fakeConfigPackages) activeFiles() map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	files := map[string]string{}
+	for path, data := range f.stages[f.active] {
+		if strings.HasPrefix(path, "conf.d/") {
+			files[path] = data
+		}
+	}
+	return files
+}
+
+func newCheckCommandTestOperator(t *testing.T, server *httptest.Server, plugins ...*api.SearchlightPlugin) (*Operator, cache.Indexer) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, wp := range plugins {
+		indexer.Add(wp)
+	}
+	root, err := ioutil.TempDir("", "searchlight")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "custom.d"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	op := &Operator{
+		Config:        Config{ConfigRoot: root},
+		pluginLister:  mon_listers.NewSearchlightPluginLister(indexer),
+		checkCommands: icinga.NewConfigPackageManager(icinga.NewClient(icinga.Config{Endpoint: server.URL + "/v1"}), checkCommandPackage),
+		commandState:  &checkCommandState{rejected: map[string]rejectedCheckCommand{}},
+		recorder:      record.NewFakeRecorder(10),
+	}
+	return op, indexer
+}
+
+func invalidPlugin(name string) *api.SearchlightPlugin {
+	wp := plugin.GetPodExistsPlugin()
+	wp.Name = name
+	wp.Spec.Command = "hyperalert check_invalid"
+	return wp
+}
+
+func TestSyncCheckCommands(t *testing.T) {
+	fake := newFakeConfigPackages("check_invalid")
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	nodeExists, podExists := plugin.GetNodeExistsPlugin(), plugin.GetPodExistsPlugin()
+	op, indexer := newCheckCommandTestOperator(t, server, nodeExists, podExists)
+	defer os.RemoveAll(op.ConfigRoot)
+	expected := map[string]string{
+		checkCommandPath(nodeExists.Name): plugin.GenerateCheckCommand(nodeExists),
+		checkCommandPath(podExists.Name):  plugin.GenerateCheckCommand(podExists),
+	}
+
+	if err := op.syncCheckCommands(); err != nil {
+		t.Fatal(err)
+	}
+	if files := fake.activeFiles(); !reflect.DeepEqual(files, expected) {
+		t.Fatalf("expected active files %v, got %v", expected, files)
+	}
+	if err := op.syncCheckCommands(); err != nil {
+		t.Fatal(err)
+	}
+	if fake.deployments != 1 {
+		t.Errorf("expected unchanged CheckCommands not to be deployed again, got %d deployments", fake.deployments)
+	}
+
+	// a new CheckCommand which fails validation isn't deployed, the others are
+	indexer.Add(invalidPlugin("invalid"))
+	if err := op.syncCheckCommands(); err != nil {
+		t.Fatal(err)
+	}
+	if files := fake.activeFiles(); !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected active files %v, got %v", expected, files)
+	}
+	if reason, ok := op.commandState.rejection("invalid"); !ok || !strings.Contains(reason, "critical/config") {
+		t.Errorf("expected CheckCommand invalid to be rejected with the validation errors, got %q", reason)
+	}
+	if _, ok := op.commandState.rejection(podExists.Name); ok {
+		t.Errorf("expected CheckCommand %s not to be rejected", podExists.Name)
+	}
+	select {
+	case e := <-op.recorder.(*record.FakeRecorder).Events:
+		if !strings.Contains(e, "FailedToSync") {
+			t.Errorf("expected FailedToSync event, got %s", e)
+		}
+	default:
+		t.Error("expected an event for the rejected CheckCommand")
+	}
+
+	// a rejected CheckCommand isn't deployed again until it changes
+	deployments := fake.deployments
+	if err := op.syncCheckCommands(); err != nil {
+		t.Fatal(err)
+	}
+	if fake.deployments != deployments {
+		t.Errorf("expected unchanged rejected CheckCommand not to be deployed again, got %d deployments", fake.deployments-deployments)
+	}
+
+	// an update of a deployed CheckCommand which fails validation keeps its deployed version
+	indexer.Update(invalidPlugin(podExists.Name))
+	if err := op.syncCheckCommands(); err != nil {
+		t.Fatal(err)
+	}
+	if files := fake.activeFiles(); !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected active files %v, got %v", expected, files)
+	}
+	if _, ok := op.commandState.rejection(podExists.Name); !ok {
+		t.Errorf("expected update of CheckCommand %s to be rejected", podExists.Name)
+	}
+
+	// a fixed CheckCommand is deployed
+	indexer.Update(podExists)
+	fixed := invalidPlugin("invalid")
+	fixed.Spec.Command = "hyperalert check_pod_exists"
+	indexer.Update(fixed)
+	if err := op.syncCheckCommands(); err != nil {
+		t.Fatal(err)
+	}
+	expected[checkCommandPath(fixed.Name)] = plugin.GenerateCheckCommand(fixed)
+	if files := fake.activeFiles(); !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected active files %v, got %v", expected, files)
+	}
+	if _, ok := op.commandState.rejection(fixed.Name); ok {
+		t.Error("expected fixed CheckCommand not to be rejected anymore")
+	}
+}
+
+func TestDeployCheckCommandsLegacy(t *testing.T) {
+	fake := newFakeConfigPackages("check_invalid")
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	podExists := plugin.GetPodExistsPlugin()
+	op, _ := newCheckCommandTestOperator(t, server, podExists)
+	defer os.RemoveAll(op.ConfigRoot)
+	legacyPath := filepath.Join(op.ConfigRoot, "custom.d", podExists.Name+".conf")
+	if err := ioutil.WriteFile(legacyPath, []byte("legacy"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{checkCommandPath(podExists.Name): plugin.GenerateCheckCommand(podExists)}
+
+	cases := []struct {
+		name      string
+		fail      bool
+		rejected  string
+		expectErr bool
+		restored  bool
+		deployed  map[string]string
+	}{
+		{
+			name:      "failed",
+			fail:      true,
+			expectErr: true,
+			restored:  true,
+		},
+		{
+			name:      "rejected",
+			rejected:  "check_pod_exists",
+			expectErr: true,
+			restored:  true,
+			deployed:  map[string]string{},
+		},
+		{
+			name:     "deployed",
+			rejected: "check_invalid",
+			deployed: files,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			fake.mu.Lock()
+			fake.fail, fake.rejected = c.fail, c.rejected
+			fake.mu.Unlock()
+			op.commandState.deployed = map[string]string{}
+
+			err := op.deployCheckCommands(files)
+			if (err != nil) != c.expectErr {
+				t.Errorf("expected error %v, got %v", c.expectErr, err)
+			}
+			data, err := ioutil.ReadFile(legacyPath)
+			if restored := err == nil && string(data) == "legacy"; restored != c.restored {
+				t.Errorf("expected legacy CheckCommand restored %v, got content %q, error %v", c.restored, data, err)
+			}
+			if !reflect.DeepEqual(op.commandState.deployed, c.deployed) {
+				t.Errorf("expected deployed files %v, got %v", c.deployed, op.commandState.deployed)
+			}
+		})
+	}
+}
+
+func TestEnqueueAlertPlugins(t *testing.T) {
+	podExists := plugin.GetPodExistsPlugin()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	indexer.Add(podExists)
+
+	cases := []struct {
+		name     string
+		alert    interface{}
+		expected []string
+	}{
+		{
+			name:     "plugin",
+			alert:    &api.PodAlert{Spec: api.PodAlertSpec{Check: podExists.Name}},
+			expected: []string{podExists.Name},
+		},
+		{
+			name:  "built-in check",
+			alert: &api.PodAlert{Spec: api.PodAlertSpec{Check: "pod-status"}},
+		},
+		{
+			name: "paused by deleted plugin",
+			alert: cache.DeletedFinalStateUnknown{Obj: &api.NodeAlert{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{api.AnnotationKeyPausedByPlugin: podExists.Name}},
+				Spec:       api.NodeAlertSpec{Check: "node-status", Paused: true},
+			}},
+			expected: []string{podExists.Name},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			op := &Operator{
+				pluginLister: mon_listers.NewSearchlightPluginLister(indexer),
+				pluginQueue:  queue.New("SearchlightPlugin", 0, 1, nil),
+			}
+			op.enqueueAlertPlugins(c.alert)
+
+			q := op.pluginQueue.GetQueue()
+			var got []string
+			for q.Len() > 0 {
+				key, _ := q.Get()
+				got = append(got, key.(string))
+				q.Done(key)
+			}
+			if !reflect.DeepEqual(got, c.expected) {
+				t.Errorf("expected enqueued plugins %v, got %v", c.expected, got)
+			}
+		})
+	}
+}
@@ -15,18 +15,26 @@ import (
 
 func (op *Operator) initPodAlertWatcher() {
 	op.paInformer = op.monInformerFactory.Monitoring().V1alpha1().PodAlerts().Informer()
-	op.paQueue = queue.New("PodAlert", op.MaxNumRequeues, op.NumThreads, op.reconcilePodAlert)
+	op.paQueue = op.newQueue("PodAlert", op.reconcilePodAlert)
 	op.paInformer.AddEventHandler(&cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			alert := obj.(*api.PodAlert)
-			if op.isValid(alert) {
+			if alert.DeletionTimestamp != nil || op.isValid(alert) {
 				queue.Enqueue(op.paQueue.GetQueue(), obj)
 			}
+			op.enqueueAlertPlugins(obj)
 		},
 		UpdateFunc: func(oldObj interface{}, newObj interface{}) {
 			old := oldObj.(*api.PodAlert)
 			nu := newObj.(*api.PodAlert)
 
+			if alertPluginsChanged(old.ObjectMeta, nu.ObjectMeta, old.Spec, nu.Spec) {
+				op.enqueueAlertPlugins(old, nu)
+			}
+			if nu.DeletionTimestamp != nil {
+				queue.Enqueue(op.paQueue.GetQueue(), nu)
+				return
+			}
 			if reflect.DeepEqual(old.Spec, nu.Spec) {
 				return
 			}
@@ -36,6 +44,7 @@ func (op *Operator) initPodAlertWatcher() {
 		},
 		DeleteFunc: func(obj interface{}) {
 			queue.Enqueue(op.paQueue.GetQueue(), obj)
+			op.enqueueAlertPlugins(obj)
 		},
 	})
 	op.paLister = op.monInformerFactory.Monitoring().V1alpha1().PodAlerts().Lister()
@@ -59,10 +68,14 @@ func (op *Operator) reconcilePodAlert(key string) error {
 	}
 
 	alert := obj.(*api.PodAlert).DeepCopy()
+	if deleting, err := op.ensureAlertFinalizer(alert); deleting || err != nil {
+		return err
+	}
 	log.Infof("Sync/Add/Update for PodAlert %s\n", alert.GetName())
 
 	op.ensurePodAlert(alert)
 	op.ensurePodAlertDeleted(alert.Namespace, alert.Name)
+	op.enqueueAlertStatus(api.ResourceKindPodAlert, alert.Namespace, alert.Name)
 	return nil
 }
 
@@ -11,6 +11,7 @@ import (
 	"github.com/appscode/searchlight/pkg/icinga"
 	"github.com/golang/glog"
 	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	core_util "kmodules.xyz/client-go/core/v1"
@@ -19,7 +20,7 @@ import (
 
 func (op *Operator) initPodWatcher() {
 	op.podInformer = op.kubeInformerFactory.Core().V1().Pods().Informer()
-	op.podQueue = queue.New("Pod", op.MaxNumRequeues, op.NumThreads, op.reconcilePod)
+	op.podQueue = op.newQueue("Pod", op.reconcilePod)
 	op.podInformer.AddEventHandler(&cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			pod := obj.(*core.Pod)
@@ -32,7 +33,11 @@ func (op *Operator) initPodWatcher() {
 		UpdateFunc: func(oldObj interface{}, newObj interface{}) {
 			old := oldObj.(*core.Pod)
 			nu := newObj.(*core.Pod)
-			if !reflect.DeepEqual(old.Labels, nu.Labels) || old.Status.PodIP != nu.Status.PodIP {
+			// Annotations may be used in templated vars of alerts
+			if !reflect.DeepEqual(old.Labels, nu.Labels) ||
+				!reflect.DeepEqual(userAnnotations(old.Annotations), userAnnotations(nu.Annotations)) ||
+				old.Status.PodIP != nu.Status.PodIP ||
+				old.Spec.NodeName != nu.Spec.NodeName {
 				queue.Enqueue(op.podQueue.GetQueue(), newObj)
 			}
 		},
@@ -57,11 +62,19 @@ func (op *Operator) reconcilePod(key string) error {
 		if err != nil {
 			return err
 		}
-		return op.podHost.ForceDeleteIcingaHost(icinga.IcingaHost{
+		err = op.podHost.ForceDeleteIcingaHost(icinga.IcingaHost{
 			Type:           icinga.TypePod,
 			AlertNamespace: namespace,
 			ObjectName:     name,
 		})
+		if err != nil {
+			return err
+		}
+		return op.globalHost.ForceDeleteIcingaHost(icinga.IcingaHost{
+			Type:           icinga.TypeGlobal,
+			AlertNamespace: namespace,
+			ObjectName:     name,
+		})
 	}
 
 	log.Infof("Sync/Add/Update for Pod %s\n", key)
@@ -82,18 +95,22 @@ func (op *Operator) ensurePod(pod *core.Pod) error {
 		oldAlerts.Insert(names...)
 	}
 
-	newAlerts, err := findPodAlert(op.kubeClient, op.paLister, pod.ObjectMeta)
+	newAlerts, err := findPodAlert(op.kubeClient, op.paLister, op.atLister, pod.ObjectMeta)
 	if err != nil {
 		return err
 	}
+	newAlerts = append(newAlerts, op.findAnnotationAlerts(pod)...)
 	newNames := make([]string, len(newAlerts))
 	for i := range newAlerts {
 		alert := newAlerts[i]
 
 		err = op.podHost.Apply(alert, pod)
+		if !api.IsAnnotationAlert(alert.Name) {
+			op.targetErrors.set(alertStatusKey(api.ResourceKindPodAlert, alert.Namespace, alert.Name), op.podHost.GetHost(alert.Namespace, pod), err)
+		}
 		if err != nil {
 			op.recorder.Eventf(
-				alert.ObjectReference(),
+				podAlertEventObject(alert, pod),
 				core.EventTypeWarning,
 				eventer.EventReasonFailedToSync,
 				`failed to  apply to pod %s/%s. Reason: %s`,
@@ -111,7 +128,15 @@ func (op *Operator) ensurePod(pod *core.Pod) error {
 	for _, name := range oldAlerts.List() {
 		err = op.podHost.Delete(pod.Namespace, name, pod)
 		if err != nil {
-			if alert, e2 := op.paLister.PodAlerts(pod.Namespace).Get(name); e2 == nil {
+			if api.IsAnnotationAlert(name) {
+				op.recorder.Eventf(
+					pod,
+					core.EventTypeWarning,
+					eventer.EventReasonFailedToDelete,
+					`failed to delete alert %s. Reason: %s`,
+					name, err,
+				)
+			} else if alert, e2 := op.paLister.PodAlerts(pod.Namespace).Get(name); e2 == nil {
 				op.recorder.Eventf(
 					alert.ObjectReference(),
 					core.EventTypeWarning,
@@ -124,6 +149,13 @@ func (op *Operator) ensurePod(pod *core.Pod) error {
 		}
 	}
 
+	op.enqueuePodRollout(pod, newAlerts)
+
+	newGlobalNames, oldGlobalNames, err := op.ensurePodGlobalAlerts(pod)
+	if err != nil {
+		errlist = append(errlist, err)
+	}
+
 	_, _, err = core_util.PatchPod(op.kubeClient, pod, func(in *core.Pod) *core.Pod {
 		if in.Annotations == nil {
 			in.Annotations = make(map[string]string, 0)
@@ -133,10 +165,87 @@ func (op *Operator) ensurePod(pod *core.Pod) error {
 		} else {
 			delete(in.Annotations, api.AnnotationKeyAlerts)
 		}
+		if len(newGlobalNames) > 0 {
+			in.Annotations[api.AnnotationKeyGlobalAlerts] = strings.Join(newGlobalNames, ",")
+		} else {
+			delete(in.Annotations, api.AnnotationKeyGlobalAlerts)
+		}
 		return in
 	})
 	if err != nil {
 		errlist = append(errlist, err)
 	}
+
+	for _, name := range append(newNames, oldAlerts.List()...) {
+		if !api.IsAnnotationAlert(name) {
+			op.enqueueAlertStatus(api.ResourceKindPodAlert, pod.Namespace, name)
+		}
+	}
+	for _, name := range append(newGlobalNames, oldGlobalNames...) {
+		op.enqueueAlertStatus(api.ResourceKindGlobalAlert, "", name)
+	}
 	return utilerrors.NewAggregate(errlist)
 }
+
+// ensurePodGlobalAlerts applies the GlobalAlerts selecting a pod and removes the ones which
+// no longer select it. It returns the names of applied and removed GlobalAlerts.
+func (op *Operator) ensurePodGlobalAlerts(pod *core.Pod) ([]string, []string, error) {
+	var errlist []error
+
+	oldAlerts := sets.NewString()
+	if val, ok := pod.Annotations[api.AnnotationKeyGlobalAlerts]; ok {
+		names := strings.Split(val, ",")
+		oldAlerts.Insert(names...)
+	}
+
+	var newAlerts []*api.GlobalAlert
+	ns, err := op.nsLister.Get(pod.Namespace)
+	if err == nil {
+		newAlerts, err = findGlobalAlert(op.kubeClient, op.gaLister, ns, pod.ObjectMeta)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else if !kerr.IsNotFound(err) {
+		return nil, nil, err
+	}
+
+	newNames := make([]string, len(newAlerts))
+	for i := range newAlerts {
+		alert := newAlerts[i]
+
+		err = op.globalHost.Apply(alert, pod)
+		op.targetErrors.set(alertStatusKey(api.ResourceKindGlobalAlert, "", alert.Name), op.globalHost.GetHost(pod), err)
+		if err != nil {
+			op.recorder.Eventf(
+				alert.ObjectReference(),
+				core.EventTypeWarning,
+				eventer.EventReasonFailedToSync,
+				`failed to  apply to pod %s/%s. Reason: %s`,
+				pod.Namespace, pod.Name, err,
+			)
+			errlist = append(errlist, err)
+		}
+
+		newNames[i] = alert.Name
+		if oldAlerts.Has(alert.Name) {
+			oldAlerts.Delete(alert.Name)
+		}
+	}
+
+	for _, name := range oldAlerts.List() {
+		err = op.globalHost.Delete(name, pod)
+		if err != nil {
+			if alert, e2 := op.gaLister.Get(name); e2 == nil {
+				op.recorder.Eventf(
+					alert.ObjectReference(),
+					core.EventTypeWarning,
+					eventer.EventReasonFailedToDelete,
+					`failed to  delete for pod %s/%s. Reason: %s`,
+					pod.Namespace, pod.Name, err,
+				)
+			}
+			errlist = append(errlist, err)
+		}
+	}
+	return newNames, oldAlerts.List(), utilerrors.NewAggregate(errlist)
+}
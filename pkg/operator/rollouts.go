@@ -0,0 +1,349 @@
+package operator
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/appscode/go/log"
+	utilerrors "github.com/appscode/go/util/errors"
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/pkg/eventer"
+	"github.com/golang/glog"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// rolloutStarts remembers when the rollouts in progress started, so that downtimes scheduled for
+// pods created later in a rollout end at the same deadline. After a restart, the start of a rollout
+// is recovered from the downtimes scheduled for it.
+type rolloutStarts struct {
+	mu sync.Mutex
+	m  map[string]time.Time
+}
+
+func newRolloutStarts() *rolloutStarts {
+	return &rolloutStarts{m: map[string]time.Time{}}
+}
+
+func (r *rolloutStarts) get(key string) (time.Time, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := r.m[key]
+	return t, ok
+}
+
+func (r *rolloutStarts) set(key string, t time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.m[key] = t
+}
+
+func (r *rolloutStarts) delete(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.m, key)
+}
+
+func rolloutKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+func rolloutTag(kind, namespace, name string) string {
+	return fmt.Sprintf("rollout/%s/%s/%s:", strings.ToLower(kind), namespace, name)
+}
+
+func (op *Operator) initRolloutWatcher() {
+	op.rolloutQueue = op.newQueue("Rollout", op.reconcileRollout)
+	op.rollouts = newRolloutStarts()
+}
+
+// enqueueRollout enqueues a Deployment or StatefulSet whose rollout state may have changed.
+func (op *Operator) enqueueRollout(kind, namespace, name string) {
+	op.rolloutQueue.GetQueue().Add(rolloutKey(kind, namespace, name))
+}
+
+// enqueuePodRollout enqueues the Deployment or StatefulSet of a pod, if the alerts applied to it
+// suppress problems during rollouts. Downtimes are scheduled for pods created during a rollout
+// once their Icinga Services exist.
+func (op *Operator) enqueuePodRollout(pod *core.Pod, alerts []*api.PodAlert) {
+	suppressed := false
+	for _, alert := range alerts {
+		if alert.Spec.SuppressDuringRollout != nil {
+			suppressed = true
+			break
+		}
+	}
+	if !suppressed {
+		return
+	}
+	if kind, name, ok := op.podWorkload(pod); ok {
+		op.enqueueRollout(kind, pod.Namespace, name)
+	}
+}
+
+// podWorkload returns the kind and name of the Deployment or StatefulSet controlling a pod.
+func (op *Operator) podWorkload(pod *core.Pod) (string, string, bool) {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return "", "", false
+	}
+	switch ref.Kind {
+	case api.WorkloadKindStatefulSet:
+		return api.WorkloadKindStatefulSet, ref.Name, true
+	case "ReplicaSet":
+		rs, err := op.rsLister.ReplicaSets(pod.Namespace).Get(ref.Name)
+		if err != nil {
+			return "", "", false
+		}
+		if owner := metav1.GetControllerOf(rs); owner != nil && owner.Kind == api.WorkloadKindDeployment {
+			return api.WorkloadKindDeployment, owner.Name, true
+		}
+	}
+	return "", "", false
+}
+
+// watchesRollout reports whether a Deployment or StatefulSet may need downtimes for its pods, because
+// PodAlerts which may apply to its pods suppress problems during rollouts, or whether it has a rollout
+// in progress whose downtimes may need to be removed. Other workloads are not reconciled, so that
+// rollouts don't cost Icinga API requests unless problems are suppressed.
+func (op *Operator) watchesRollout(kind string, obj interface{}) bool {
+	var template *core.PodTemplateSpec
+	switch w := obj.(type) {
+	case *apps.Deployment:
+		template = &w.Spec.Template
+	case *apps.StatefulSet:
+		template = &w.Spec.Template
+	default:
+		return false
+	}
+	m := obj.(metav1.Object)
+	if _, found := op.rollouts.get(rolloutKey(kind, m.GetNamespace(), m.GetName())); found {
+		return true
+	}
+
+	alerts, err := op.paLister.PodAlerts(m.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		return true
+	}
+	for _, alert := range alerts {
+		if alert.Spec.SuppressDuringRollout == nil {
+			continue
+		}
+		// PodAlerts selecting a pod by name may select any pod of the workload
+		if alert.Spec.Selector == nil || podControlledBy(&core.Pod{ObjectMeta: template.ObjectMeta}, alert.Spec.Selector) {
+			return true
+		}
+	}
+	annotationAlerts, _ := api.AnnotationAlerts(&core.Pod{ObjectMeta: template.ObjectMeta})
+	for _, alert := range annotationAlerts {
+		if alert.Spec.SuppressDuringRollout != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func podControlledBy(pod *core.Pod, selector *metav1.LabelSelector) bool {
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	return err == nil && sel.Matches(labels.Set(pod.Labels))
+}
+
+// deploymentRollout reports whether a Deployment is updating its pods to the latest template,
+// and whether its updated pods are not all available yet. Rollouts which exceeded their
+// progress deadline are failed, so problems of their pods are not suppressed anymore.
+func deploymentRollout(d *apps.Deployment) (updating, settling bool) {
+	for _, c := range d.Status.Conditions {
+		if c.Type == apps.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+			return false, false
+		}
+	}
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	updating = d.Generation > d.Status.ObservedGeneration ||
+		d.Status.UpdatedReplicas < replicas ||
+		d.Status.Replicas > d.Status.UpdatedReplicas
+	settling = d.Status.AvailableReplicas < d.Status.UpdatedReplicas
+	return
+}
+
+// statefulSetRollout reports whether a StatefulSet is updating its pods to the latest revision,
+// and whether its pods are not all ready yet.
+func statefulSetRollout(s *apps.StatefulSet) (updating, settling bool) {
+	replicas := int32(1)
+	if s.Spec.Replicas != nil {
+		replicas = *s.Spec.Replicas
+	}
+	updating = s.Generation > s.Status.ObservedGeneration
+	if s.Spec.UpdateStrategy.Type == apps.RollingUpdateStatefulSetStrategyType {
+		if ru := s.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil {
+			updating = updating || s.Status.UpdatedReplicas < replicas-*ru.Partition
+		} else {
+			updating = updating || s.Status.UpdateRevision != s.Status.CurrentRevision
+		}
+	}
+	settling = s.Status.ReadyReplicas < replicas
+	return
+}
+
+// isRollingOut reports whether a Deployment or StatefulSet may need downtimes for its pods.
+func isRollingOut(obj interface{}) bool {
+	switch w := obj.(type) {
+	case *apps.Deployment:
+		updating, settling := deploymentRollout(w)
+		return updating || settling
+	case *apps.StatefulSet:
+		updating, settling := statefulSetRollout(w)
+		return updating || settling
+	}
+	return false
+}
+
+// reconcileRollout schedules Icinga downtimes for the services of PodAlerts with suppressDuringRollout
+// on the pods of a Deployment or StatefulSet while it rolls out, and removes them once it is done.
+// A rollout starts when the workload updates its pods; pods which merely become unavailable
+// don't start one, so that problems of a stable workload are never suppressed.
+func (op *Operator) reconcileRollout(key string) error {
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) != 3 {
+		glog.Errorf("invalid rollout key %s", key)
+		return nil
+	}
+	kind, namespace, name := parts[0], parts[1], parts[2]
+
+	var updating, settling bool
+	var selector *metav1.LabelSelector
+	var err error
+	switch kind {
+	case api.WorkloadKindDeployment:
+		var d *apps.Deployment
+		if d, err = op.deployLister.Deployments(namespace).Get(name); err == nil {
+			updating, settling = deploymentRollout(d)
+			selector = d.Spec.Selector
+		}
+	case api.WorkloadKindStatefulSet:
+		var s *apps.StatefulSet
+		if s, err = op.stsLister.StatefulSets(namespace).Get(name); err == nil {
+			updating, settling = statefulSetRollout(s)
+			selector = s.Spec.Selector
+		}
+	default:
+		glog.Errorf("invalid rollout key %s", key)
+		return nil
+	}
+	if err != nil && !kerr.IsNotFound(err) {
+		return err
+	}
+
+	tag := rolloutTag(kind, namespace, name)
+	existing, err := op.downtimes.List(tag)
+	if err != nil {
+		return err
+	}
+	start, known := op.rollouts.get(key)
+	for _, dt := range existing {
+		if !known || dt.StartTime.Before(start) {
+			start, known = dt.StartTime, true
+		}
+	}
+
+	if !updating && !(known && settling) {
+		if known {
+			log.Infof("%s %s/%s finished rolling out\n", kind, namespace, name)
+		}
+		op.rollouts.delete(key)
+		var errlist []error
+		for _, dt := range existing {
+			if err := op.downtimes.Remove(dt.Name); err != nil {
+				errlist = append(errlist, err)
+			}
+		}
+		return utilerrors.NewAggregate(errlist)
+	}
+
+	now := time.Now()
+	if !known {
+		log.Infof("%s %s/%s started rolling out\n", kind, namespace, name)
+		start = now
+	}
+	op.rollouts.set(key, start)
+
+	scheduled := sets.NewString()
+	for _, dt := range existing {
+		scheduled.Insert(dt.HostName + "!" + dt.ServiceName)
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return err
+	}
+	pods, err := op.podLister.Pods(namespace).List(sel)
+	if err != nil {
+		return err
+	}
+
+	var errlist []error
+	for _, pod := range pods {
+		if k, n, ok := op.podWorkload(pod); !ok || k != kind || n != name {
+			continue
+		}
+		host, _ := op.podHost.GetHost(pod.Namespace, pod).Name()
+		for _, alert := range op.appliedPodAlerts(pod) {
+			if alert.Spec.SuppressDuringRollout == nil || alert.Spec.Paused || scheduled.Has(host+"!"+alert.Name) {
+				continue
+			}
+			end := start.Add(alert.Spec.SuppressDuringRollout.GetDeadline())
+			if !end.After(now) {
+				continue
+			}
+			comment := fmt.Sprintf("%s %s/%s is rolling out", kind, namespace, name)
+			if _, err := op.downtimes.Schedule(host, alert.Name, now, end, tag, comment); err != nil {
+				errlist = append(errlist, err)
+				continue
+			}
+			op.recorder.Eventf(
+				podAlertEventObject(alert, pod),
+				core.EventTypeNormal,
+				eventer.EventReasonRolloutSuppressed,
+				`Suppressed problems of pod %s/%s until %s, while %s %s is rolling out`,
+				pod.Namespace, pod.Name, end.Format(time.RFC3339), kind, name,
+			)
+		}
+	}
+	return utilerrors.NewAggregate(errlist)
+}
+
+// appliedPodAlerts returns the PodAlerts recorded as applied to a pod, merged with their AlertTemplates.
+func (op *Operator) appliedPodAlerts(pod *core.Pod) []*api.PodAlert {
+	val, ok := pod.Annotations[api.AnnotationKeyAlerts]
+	if !ok {
+		return nil
+	}
+
+	var alerts []*api.PodAlert
+	for _, name := range strings.Split(val, ",") {
+		var alert *api.PodAlert
+		var err error
+		if api.IsAnnotationAlert(name) {
+			alert, err = api.GetAnnotationAlert(pod, name)
+		} else {
+			alert, err = op.paLister.PodAlerts(pod.Namespace).Get(name)
+		}
+		if err != nil {
+			continue
+		}
+		merged, err := withTemplate(op.atLister, alert)
+		if err != nil {
+			continue
+		}
+		alerts = append(alerts, merged.(*api.PodAlert))
+	}
+	return alerts
+}
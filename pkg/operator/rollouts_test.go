@@ -0,0 +1,192 @@
+package operator
+
+import (
+	"testing"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	mon_listers "github.com/appscode/searchlight/client/listers/monitoring/v1alpha1"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apps_listers "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func controlledBy(kind, name string) []metav1.OwnerReference {
+	controller := true
+	return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &controller}}
+}
+
+func newRolloutTestOperator(objects ...runtime.Object) *Operator {
+	rsIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	paIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, obj := range objects {
+		switch obj.(type) {
+		case *apps.ReplicaSet:
+			rsIndexer.Add(obj)
+		case *api.PodAlert:
+			paIndexer.Add(obj)
+		}
+	}
+	return &Operator{
+		rsLister: apps_listers.NewReplicaSetLister(rsIndexer),
+		paLister: mon_listers.NewPodAlertLister(paIndexer),
+		rollouts: newRolloutStarts(),
+	}
+}
+
+func TestPodWorkload(t *testing.T) {
+	op := newRolloutTestOperator(
+		&apps.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "web-api-5d8f9", Namespace: "demo", OwnerReferences: controlledBy("Deployment", "web-api")}},
+		&apps.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "web-7c6d4", Namespace: "demo", OwnerReferences: controlledBy("Deployment", "web")}},
+		&apps.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "standalone", Namespace: "demo"}},
+	)
+
+	cases := []struct {
+		name  string
+		owner []metav1.OwnerReference
+		kind  string
+		wname string
+		ok    bool
+	}{
+		// a ReplicaSet named after another Deployment with the same prefix
+		{name: "deployment with prefix", owner: controlledBy("ReplicaSet", "web-api-5d8f9"), kind: api.WorkloadKindDeployment, wname: "web-api", ok: true},
+		{name: "deployment", owner: controlledBy("ReplicaSet", "web-7c6d4"), kind: api.WorkloadKindDeployment, wname: "web", ok: true},
+		{name: "standalone replicaset", owner: controlledBy("ReplicaSet", "standalone")},
+		{name: "missing replicaset", owner: controlledBy("ReplicaSet", "web-api-0000")},
+		{name: "statefulset", owner: controlledBy("StatefulSet", "db"), kind: api.WorkloadKindStatefulSet, wname: "db", ok: true},
+		{name: "daemonset", owner: controlledBy("DaemonSet", "agent")},
+		{name: "bare pod"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			pod := &core.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "demo", OwnerReferences: c.owner}}
+			kind, name, ok := op.podWorkload(pod)
+			if kind != c.kind || name != c.wname || ok != c.ok {
+				t.Errorf("expected (%q, %q, %v), got (%q, %q, %v)", c.kind, c.wname, c.ok, kind, name, ok)
+			}
+		})
+	}
+}
+
+func TestWatchesRollout(t *testing.T) {
+	suppressing := func(name string, selector map[string]string) *api.PodAlert {
+		alert := &api.PodAlert{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "demo"},
+			Spec:       api.PodAlertSpec{Check: api.CheckPodStatus, SuppressDuringRollout: &api.RolloutSuppression{}},
+		}
+		if selector != nil {
+			alert.Spec.Selector = &metav1.LabelSelector{MatchLabels: selector}
+		} else {
+			podName := "web-0"
+			alert.Spec.PodName = &podName
+		}
+		return alert
+	}
+	deployment := func(labels, annotations map[string]string) *apps.Deployment {
+		d := &apps.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "demo"}}
+		d.Spec.Template.Labels = labels
+		d.Spec.Template.Annotations = annotations
+		return d
+	}
+	notSuppressing := suppressing("status", map[string]string{"app": "web"})
+	notSuppressing.Spec.SuppressDuringRollout = nil
+
+	cases := []struct {
+		name     string
+		alerts   []runtime.Object
+		workload interface{}
+		started  bool
+		watches  bool
+	}{
+		{name: "no alerts", workload: deployment(map[string]string{"app": "web"}, nil)},
+		{name: "alert without suppression", alerts: []runtime.Object{notSuppressing}, workload: deployment(map[string]string{"app": "web"}, nil)},
+		{name: "alert selecting other pods", alerts: []runtime.Object{suppressing("db", map[string]string{"app": "db"})}, workload: deployment(map[string]string{"app": "web"}, nil)},
+		{name: "alert selecting pods", alerts: []runtime.Object{suppressing("web", map[string]string{"app": "web"})}, workload: deployment(map[string]string{"app": "web"}, nil), watches: true},
+		{name: "alert selecting a pod by name", alerts: []runtime.Object{suppressing("web", nil)}, workload: deployment(map[string]string{"app": "web"}, nil), watches: true},
+		{
+			name:     "check in pod annotations",
+			workload: deployment(nil, map[string]string{api.AnnotationKeyCheckPrefix + "status": `{"check": "pod-status", "suppressDuringRollout": {"deadline": "5m"}}`}),
+			watches:  true,
+		},
+		{name: "rollout in progress", workload: deployment(map[string]string{"app": "web"}, nil), started: true, watches: true},
+		{name: "daemonset", alerts: []runtime.Object{suppressing("web", map[string]string{"app": "web"})}, workload: &apps.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "demo"}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			op := newRolloutTestOperator(c.alerts...)
+			if c.started {
+				op.rollouts.set(rolloutKey(api.WorkloadKindDeployment, "demo", "web"), metav1.Now().Time)
+			}
+			if watches := op.watchesRollout(api.WorkloadKindDeployment, c.workload); watches != c.watches {
+				t.Errorf("expected %v, got %v", c.watches, watches)
+			}
+		})
+	}
+}
+
+func TestDeploymentRollout(t *testing.T) {
+	replicas := int32(3)
+	cases := []struct {
+		name               string
+		generation         int64
+		status             apps.DeploymentStatus
+		updating, settling bool
+	}{
+		{name: "stable", generation: 1, status: apps.DeploymentStatus{ObservedGeneration: 1, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}},
+		{name: "not observed", generation: 2, status: apps.DeploymentStatus{ObservedGeneration: 1, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}, updating: true},
+		{name: "updating", generation: 2, status: apps.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 2, AvailableReplicas: 1}, updating: true, settling: true},
+		{name: "old pods terminating", generation: 2, status: apps.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 3, AvailableReplicas: 3}, updating: true},
+		{name: "settling", generation: 2, status: apps.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 2}, settling: true},
+		{
+			name:       "progress deadline exceeded",
+			generation: 2,
+			status: apps.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 2, AvailableReplicas: 3,
+				Conditions: []apps.DeploymentCondition{{Type: apps.DeploymentProgressing, Reason: "ProgressDeadlineExceeded"}},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := &apps.Deployment{ObjectMeta: metav1.ObjectMeta{Generation: c.generation}, Spec: apps.DeploymentSpec{Replicas: &replicas}, Status: c.status}
+			if updating, settling := deploymentRollout(d); updating != c.updating || settling != c.settling {
+				t.Errorf("expected (%v, %v), got (%v, %v)", c.updating, c.settling, updating, settling)
+			}
+		})
+	}
+}
+
+func TestStatefulSetRollout(t *testing.T) {
+	replicas, partition := int32(3), int32(1)
+	cases := []struct {
+		name               string
+		partition          *int32
+		status             apps.StatefulSetStatus
+		updating, settling bool
+	}{
+		{name: "stable", status: apps.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 3, CurrentRevision: "a", UpdateRevision: "a"}},
+		{name: "updating", status: apps.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 2, CurrentRevision: "a", UpdateRevision: "b"}, updating: true, settling: true},
+		{name: "partition updated", partition: &partition, status: apps.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 3, UpdatedReplicas: 2, CurrentRevision: "a", UpdateRevision: "b"}},
+		{name: "partition updating", partition: &partition, status: apps.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 3, UpdatedReplicas: 1, CurrentRevision: "a", UpdateRevision: "b"}, updating: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := &apps.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+				Spec: apps.StatefulSetSpec{
+					Replicas: &replicas,
+					UpdateStrategy: apps.StatefulSetUpdateStrategy{
+						Type:          apps.RollingUpdateStatefulSetStrategyType,
+						RollingUpdate: &apps.RollingUpdateStatefulSetStrategy{Partition: c.partition},
+					},
+				},
+				Status: c.status,
+			}
+			if updating, settling := statefulSetRollout(s); updating != c.updating || settling != c.settling {
+				t.Errorf("expected (%v, %v), got (%v, %v)", c.updating, c.settling, updating, settling)
+			}
+		})
+	}
+}
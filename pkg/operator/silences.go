@@ -0,0 +1,273 @@
+package operator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/appscode/go/log"
+	utilerrors "github.com/appscode/go/util/errors"
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/client/clientset/versioned/typed/monitoring/v1alpha1/util"
+	"github.com/appscode/searchlight/pkg/eventer"
+	"github.com/appscode/searchlight/pkg/icinga"
+	"github.com/golang/glog"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"kmodules.xyz/client-go/tools/queue"
+)
+
+func (op *Operator) initSilenceWatcher() {
+	op.silenceInformer = op.monInformerFactory.Monitoring().V1alpha1().Silences().Informer()
+	op.silenceQueue = op.newQueue("Silence", op.reconcileSilence)
+	op.silenceInformer.AddEventHandler(&cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			queue.Enqueue(op.silenceQueue.GetQueue(), obj)
+		},
+		UpdateFunc: func(oldObj interface{}, newObj interface{}) {
+			old := oldObj.(*api.Silence)
+			nu := newObj.(*api.Silence)
+
+			// Periodic resyncs are processed too, so that downtimes are scheduled
+			// for Icinga services created after the Silence.
+			if old.ResourceVersion != nu.ResourceVersion && reflect.DeepEqual(old.Spec, nu.Spec) {
+				return
+			}
+			queue.Enqueue(op.silenceQueue.GetQueue(), nu)
+		},
+		DeleteFunc: func(obj interface{}) {
+			queue.Enqueue(op.silenceQueue.GetQueue(), obj)
+		},
+	})
+	op.silenceLister = op.monInformerFactory.Monitoring().V1alpha1().Silences().Lister()
+}
+
+func (op *Operator) reconcileSilence(key string) error {
+	obj, exists, err := op.silenceInformer.GetIndexer().GetByKey(key)
+	if err != nil {
+		glog.Errorf("Fetching object with key %s from store failed with %v", key, err)
+		return err
+	}
+
+	if !exists {
+		log.Warningf("Silence %s does not exist anymore\n", key)
+
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			return err
+		}
+		return op.ensureSilenceDeleted(namespace, name)
+	}
+
+	silence := obj.(*api.Silence).DeepCopy()
+	log.Infof("Sync/Add/Update for Silence %s\n", key)
+
+	return op.ensureSilence(key, silence)
+}
+
+func silenceTag(namespace, name string) string {
+	return fmt.Sprintf("silence/%s/%s:", namespace, name)
+}
+
+func (op *Operator) ensureSilence(key string, silence *api.Silence) error {
+	tag := silenceTag(silence.Namespace, silence.Name)
+	existing, err := op.downtimes.List(tag)
+	if err != nil {
+		return err
+	}
+
+	status := silence.Status.DeepCopy()
+	status.ObservedGeneration = silence.Generation
+	status.Active = false
+	status.WindowStart = nil
+	status.WindowEnd = nil
+	status.DowntimeIDs = nil
+	status.Message = ""
+
+	var errlist []error
+	keep := sets.NewString()
+
+	now := time.Now()
+	start, end, err := silence.Window(now)
+	if err == nil {
+		err = silence.IsValid()
+	}
+	if err != nil {
+		status.Message = err.Error()
+	} else if end.After(now) {
+		status.Active = !now.Before(start)
+		ws, we := metav1.NewTime(start), metav1.NewTime(end)
+		status.WindowStart, status.WindowEnd = &ws, &we
+
+		services, err := op.silencedServices(silence)
+		if err != nil {
+			return err
+		}
+
+		scheduled := map[string]icinga.Downtime{}
+		for _, dt := range existing {
+			if dt.StartTime.Unix() == start.Unix() && dt.EndTime.Unix() == end.Unix() {
+				scheduled[dt.HostName+"!"+dt.ServiceName] = dt
+			}
+		}
+		for _, svc := range services {
+			if dt, ok := scheduled[svc]; ok {
+				keep.Insert(dt.Name)
+				continue
+			}
+			parts := strings.SplitN(svc, "!", 2)
+			name, err := op.downtimes.Schedule(parts[0], parts[1], start, end, tag, silence.Spec.Comment)
+			if err != nil {
+				errlist = append(errlist, err)
+				continue
+			}
+			keep.Insert(name)
+		}
+
+		// Reconcile again when the window starts or ends
+		if status.Active {
+			op.silenceQueue.GetQueue().AddAfter(key, end.Sub(now))
+		} else {
+			op.silenceQueue.GetQueue().AddAfter(key, start.Sub(now))
+		}
+	}
+
+	// Remove downtimes of other windows and of services which no longer match
+	for _, dt := range existing {
+		if !keep.Has(dt.Name) {
+			if err := op.downtimes.Remove(dt.Name); err != nil {
+				errlist = append(errlist, err)
+			}
+		}
+	}
+
+	status.DowntimeIDs = keep.List()
+	if len(errlist) > 0 {
+		status.Message = utilerrors.NewAggregate(errlist).Error()
+		op.recorder.Eventf(
+			silence.ObjectReference(),
+			core.EventTypeWarning,
+			eventer.EventReasonFailedToSync,
+			`failed to schedule downtimes. Reason: %s`,
+			status.Message,
+		)
+	}
+	if !reflect.DeepEqual(&silence.Status, status) {
+		_, err = util.UpdateSilenceStatus(op.extClient.MonitoringV1alpha1(), silence, func(in *api.SilenceStatus) *api.SilenceStatus {
+			return status
+		}, api.EnableStatusSubresource)
+		if err != nil {
+			errlist = append(errlist, err)
+		}
+	}
+	return utilerrors.NewAggregate(errlist)
+}
+
+func (op *Operator) ensureSilenceDeleted(namespace, name string) error {
+	existing, err := op.downtimes.List(silenceTag(namespace, name))
+	if err != nil {
+		return err
+	}
+	var errlist []error
+	for _, dt := range existing {
+		if err := op.downtimes.Remove(dt.Name); err != nil {
+			errlist = append(errlist, err)
+		}
+	}
+	return utilerrors.NewAggregate(errlist)
+}
+
+// silencedServices returns the Icinga services matched by a Silence, as host!service names.
+func (op *Operator) silencedServices(silence *api.Silence) ([]string, error) {
+	m := silence.Spec.Matcher
+	sel := labels.Everything()
+	if m.AlertSelector != nil {
+		var err error
+		if sel, err = metav1.LabelSelectorAsSelector(m.AlertSelector); err != nil {
+			return nil, err
+		}
+	}
+	names := sets.NewString(m.AlertNames...)
+	target := "*"
+	if m.Target != nil {
+		target = m.Target.Name
+	}
+
+	// Icinga service name and host name pattern of each matched alert
+	type match struct {
+		alert       string
+		hostPattern string
+	}
+	var matches []match
+	add := func(kind string, meta metav1.ObjectMeta, hostPattern string) {
+		if m.AlertKind != "" && m.AlertKind != kind {
+			return
+		}
+		if names.Len() > 0 && !names.Has(meta.Name) {
+			return
+		}
+		if !sel.Matches(labels.Set(meta.Labels)) {
+			return
+		}
+		matches = append(matches, match{alert: meta.Name, hostPattern: hostPattern})
+	}
+
+	ns := silence.Namespace
+	pas, err := op.paLister.PodAlerts(ns).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, alert := range pas {
+		add(api.ResourceKindPodAlert, alert.ObjectMeta, fmt.Sprintf("%s@%s@%s", ns, icinga.TypePod, target))
+	}
+	nas, err := op.naLister.NodeAlerts(ns).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, alert := range nas {
+		add(api.ResourceKindNodeAlert, alert.ObjectMeta, fmt.Sprintf("%s@%s@%s", ns, icinga.TypeNode, target))
+	}
+	was, err := op.waLister.WorkloadAlerts(ns).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, alert := range was {
+		add(api.ResourceKindWorkloadAlert, alert.ObjectMeta, fmt.Sprintf("%s@%s@*@%s", ns, icinga.TypeWorkload, target))
+	}
+	// GlobalAlerts are cluster scoped, so only their services of pods in the namespace of the Silence are silenced
+	gas, err := op.gaLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, alert := range gas {
+		add(api.ResourceKindGlobalAlert, alert.ObjectMeta, fmt.Sprintf("%s@%s@%s", ns, icinga.TypeGlobal, target))
+	}
+	// ClusterAlerts have no target objects
+	if m.Target == nil {
+		cas, err := op.caLister.ClusterAlerts(ns).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, alert := range cas {
+			add(api.ResourceKindClusterAlert, alert.ObjectMeta, fmt.Sprintf("%s@%s", ns, icinga.TypeCluster))
+		}
+	}
+
+	var services []string
+	for _, mt := range matches {
+		hosts, err := op.downtimes.ListServiceHosts(mt.hostPattern, mt.alert)
+		if err != nil {
+			return nil, err
+		}
+		for _, host := range hosts {
+			services = append(services, host+"!"+mt.alert)
+		}
+	}
+	sort.Strings(services)
+	return services, nil
+}
@@ -0,0 +1,218 @@
+package operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	mon_fake "github.com/appscode/searchlight/client/clientset/versioned/fake"
+	mon_listers "github.com/appscode/searchlight/client/listers/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/pkg/icinga"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"kmodules.xyz/client-go/tools/queue"
+)
+
+type fakeDowntime struct {
+	host, svc  string
+	start, end int64
+}
+
+// fakeDowntimes serves the Icinga API requests made by icinga.DowntimeManager. Services are named {host}!{service}.
+type fakeDowntimes struct {
+	mu        sync.Mutex
+	services  []string
+	downtimes map[string]fakeDowntime
+	scheduled int
+}
+
+func (f *fakeDowntimes) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	body, _ := ioutil.ReadAll(r.Body)
+	var query struct {
+		FilterVars map[string]string `json:"filter_vars"`
+		StartTime  int64             `json:"start_time"`
+		EndTime    int64             `json:"end_time"`
+	}
+	if err := json.Unmarshal(body, &query); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	results := []interface{}{}
+	switch r.URL.Path {
+	case "/v1/objects/services":
+		for _, svc := range f.services {
+			parts := strings.Split(svc, "!")
+			if ok, _ := path.Match(query.FilterVars["pattern"], parts[0]); ok && parts[1] == query.FilterVars["svc"] {
+				results = append(results, map[string]interface{}{"attrs": map[string]string{"host_name": parts[0]}})
+			}
+		}
+	case "/v1/objects/downtimes":
+		for name, dt := range f.downtimes {
+			results = append(results, map[string]interface{}{
+				"name":  name,
+				"attrs": map[string]interface{}{"host_name": dt.host, "service_name": dt.svc, "start_time": dt.start, "end_time": dt.end},
+			})
+		}
+	case "/v1/actions/schedule-downtime":
+		f.scheduled++
+		name := fmt.Sprintf("%s!%s!downtime-%d", query.FilterVars["hostName"], query.FilterVars["svc"], f.scheduled)
+		f.downtimes[name] = fakeDowntime{host: query.FilterVars["hostName"], svc: query.FilterVars["svc"], start: query.StartTime, end: query.EndTime}
+		results = append(results, map[string]interface{}{"code": 200, "name": name})
+	case "/v1/actions/remove-downtime":
+		delete(f.downtimes, query.FilterVars["name"])
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+}
+
+// windows returns the services with downtimes, with the start and end of their downtime.
+func (f *fakeDowntimes) windows() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var windows []string
+	for _, dt := range f.downtimes {
+		windows = append(windows, fmt.Sprintf("%s!%s %d-%d", dt.host, dt.svc, dt.start, dt.end))
+	}
+	sort.Strings(windows)
+	return windows
+}
+
+func TestEnsureSilence(t *testing.T) {
+	enabled := api.EnableStatusSubresource
+	api.EnableStatusSubresource = true
+	defer func() { api.EnableStatusSubresource = enabled }()
+
+	now := time.Now().Truncate(time.Second)
+	window := func(start, end time.Duration) (*metav1.Time, *metav1.Time) {
+		s, e := metav1.NewTime(now.Add(start)), metav1.NewTime(now.Add(end))
+		return &s, &e
+	}
+	downtime := func(svc string, start, end time.Duration) string {
+		return fmt.Sprintf("%s %d-%d", svc, now.Add(start).Unix(), now.Add(end).Unix())
+	}
+	services := []string{
+		"demo@pod@web-0!pod-status",
+		"demo@pod@web-1!pod-status",
+		"demo@global@web-0!pod-exists",
+		"prod@global@web-0!pod-exists",
+	}
+
+	cases := []struct {
+		name     string
+		window   [2]time.Duration
+		matcher  api.SilenceMatcher
+		existing map[string]fakeDowntime
+		active   bool
+		expired  bool
+		expected []string
+	}{
+		{
+			name:   "upcoming",
+			window: [2]time.Duration{time.Hour, 2 * time.Hour},
+			expected: []string{
+				downtime("demo@global@web-0!pod-exists", time.Hour, 2*time.Hour),
+				downtime("demo@pod@web-0!pod-status", time.Hour, 2*time.Hour),
+				downtime("demo@pod@web-1!pod-status", time.Hour, 2*time.Hour),
+			},
+		},
+		{
+			name:    "active window of a target",
+			window:  [2]time.Duration{-time.Hour, time.Hour},
+			matcher: api.SilenceMatcher{AlertKind: api.ResourceKindPodAlert, Target: &api.SilenceTarget{Name: "web-1"}},
+			existing: map[string]fakeDowntime{
+				// scheduled for the current window
+				"web-1": {host: "demo@pod@web-1", svc: "pod-status", start: now.Add(-time.Hour).Unix(), end: now.Add(time.Hour).Unix()},
+				// scheduled for a window which was changed
+				"web-0": {host: "demo@pod@web-0", svc: "pod-status", start: now.Add(-2 * time.Hour).Unix(), end: now.Add(time.Hour).Unix()},
+			},
+			active:   true,
+			expected: []string{downtime("demo@pod@web-1!pod-status", -time.Hour, time.Hour)},
+		},
+		{
+			name:     "global alerts in the namespace of the silence",
+			window:   [2]time.Duration{time.Hour, 2 * time.Hour},
+			matcher:  api.SilenceMatcher{AlertKind: api.ResourceKindGlobalAlert},
+			expected: []string{downtime("demo@global@web-0!pod-exists", time.Hour, 2*time.Hour)},
+		},
+		{
+			name:   "expired",
+			window: [2]time.Duration{-2 * time.Hour, -time.Hour},
+			existing: map[string]fakeDowntime{
+				"web-0": {host: "demo@pod@web-0", svc: "pod-status", start: now.Add(-2 * time.Hour).Unix(), end: now.Add(-time.Hour).Unix()},
+			},
+			expired: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			fake := &fakeDowntimes{services: services, downtimes: map[string]fakeDowntime{}}
+			for name, dt := range c.existing {
+				fake.downtimes[silenceTag("demo", "maintenance")+name] = dt
+			}
+			server := httptest.NewServer(fake)
+			defer server.Close()
+
+			startsAt, endsAt := window(c.window[0], c.window[1])
+			silence := &api.Silence{
+				ObjectMeta: metav1.ObjectMeta{Name: "maintenance", Namespace: "demo"},
+				Spec:       api.SilenceSpec{StartsAt: startsAt, EndsAt: endsAt, Matcher: c.matcher},
+			}
+			pas := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			pas.Add(&api.PodAlert{ObjectMeta: metav1.ObjectMeta{Name: "pod-status", Namespace: "demo"}})
+			gas := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			gas.Add(&api.GlobalAlert{ObjectMeta: metav1.ObjectMeta{Name: "pod-exists"}})
+			empty := func() cache.Indexer {
+				return cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			}
+			op := &Operator{
+				extClient:    mon_fake.NewSimpleClientset(silence),
+				downtimes:    icinga.NewDowntimeManager(icinga.NewClient(icinga.Config{Endpoint: server.URL + "/v1"})),
+				paLister:     mon_listers.NewPodAlertLister(pas),
+				naLister:     mon_listers.NewNodeAlertLister(empty()),
+				waLister:     mon_listers.NewWorkloadAlertLister(empty()),
+				caLister:     mon_listers.NewClusterAlertLister(empty()),
+				gaLister:     mon_listers.NewGlobalAlertLister(gas),
+				silenceQueue: queue.New("Silence", 0, 1, nil),
+				recorder:     record.NewFakeRecorder(10),
+			}
+			defer op.silenceQueue.GetQueue().ShutDown()
+
+			if err := op.ensureSilence("demo/maintenance", silence); err != nil {
+				t.Fatal(err)
+			}
+			if windows := fake.windows(); !reflect.DeepEqual(windows, c.expected) {
+				t.Errorf("expected downtimes %v, got %v", c.expected, windows)
+			}
+			cur, err := op.extClient.MonitoringV1alpha1().Silences("demo").Get("maintenance", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cur.Status.Active != c.active {
+				t.Errorf("expected active %v, got %v", c.active, cur.Status.Active)
+			}
+			if expired := cur.Status.WindowStart == nil; expired != c.expired {
+				t.Errorf("expected expired %v, got window %v - %v", c.expired, cur.Status.WindowStart, cur.Status.WindowEnd)
+			}
+			if len(cur.Status.DowntimeIDs) != len(c.expected) {
+				t.Errorf("expected %d downtime IDs, got %v", len(c.expected), cur.Status.DowntimeIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,402 @@
+package operator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/appscode/go/log"
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/client/clientset/versioned/typed/monitoring/v1alpha1/util"
+	"github.com/appscode/searchlight/pkg/icinga"
+	"github.com/golang/glog"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	ReasonInvalidAlert = "InvalidAlert"
+	ReasonAlertPaused  = "AlertPaused"
+	ReasonSyncFailed   = "SyncFailed"
+	ReasonSynced       = "Synced"
+
+	ReasonInvalidPlugin          = "InvalidPlugin"
+	ReasonIcingaValidationFailed = "IcingaValidationFailed"
+)
+
+// targetErrors remembers the error returned by the last attempt to apply an alert to each of its
+// Icinga hosts, so that it can be reported in the alert status.
+type targetErrors struct {
+	mu sync.Mutex
+	m  map[string]map[string]string
+}
+
+func newTargetErrors() *targetErrors {
+	return &targetErrors{m: map[string]map[string]string{}}
+}
+
+func (t *targetErrors) set(key string, kh icinga.IcingaHost, err error) {
+	host, _ := kh.Name()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err == nil {
+		delete(t.m[key], host)
+		return
+	}
+	if t.m[key] == nil {
+		t.m[key] = map[string]string{}
+	}
+	t.m[key][host] = err.Error()
+}
+
+// get returns the errors recorded for the given hosts and forgets the rest.
+func (t *targetErrors) get(key string, hosts []string) map[string]string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	out := map[string]string{}
+	for _, host := range hosts {
+		if msg, ok := t.m[key][host]; ok {
+			out[host] = msg
+		}
+	}
+	if len(out) == 0 {
+		delete(t.m, key)
+	} else {
+		t.m[key] = out
+	}
+	return out
+}
+
+func alertStatusKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+func (op *Operator) initAlertStatusWatcher() {
+	op.statusQueue = op.newQueue("AlertStatus", op.reconcileAlertStatus)
+	op.targetErrors = newTargetErrors()
+}
+
+func (op *Operator) enqueueAlertStatus(kind, namespace, name string) {
+	op.statusQueue.GetQueue().Add(alertStatusKey(kind, namespace, name))
+}
+
+// refreshAlertStatus enqueues all alerts, so that states of their Icinga services are periodically
+// copied into their status.
+func (op *Operator) refreshAlertStatus() {
+	if pas, err := op.paLister.List(labels.Everything()); err == nil {
+		for _, alert := range pas {
+			op.enqueueAlertStatus(api.ResourceKindPodAlert, alert.Namespace, alert.Name)
+		}
+	}
+	if nas, err := op.naLister.List(labels.Everything()); err == nil {
+		for _, alert := range nas {
+			op.enqueueAlertStatus(api.ResourceKindNodeAlert, alert.Namespace, alert.Name)
+		}
+	}
+	if cas, err := op.caLister.List(labels.Everything()); err == nil {
+		for _, alert := range cas {
+			op.enqueueAlertStatus(api.ResourceKindClusterAlert, alert.Namespace, alert.Name)
+		}
+	}
+	if was, err := op.waLister.List(labels.Everything()); err == nil {
+		for _, alert := range was {
+			op.enqueueAlertStatus(api.ResourceKindWorkloadAlert, alert.Namespace, alert.Name)
+		}
+	}
+	if gas, err := op.gaLister.List(labels.Everything()); err == nil {
+		for _, alert := range gas {
+			op.enqueueAlertStatus(api.ResourceKindGlobalAlert, "", alert.Name)
+		}
+	}
+}
+
+func (op *Operator) reconcileAlertStatus(key string) error {
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) != 3 {
+		glog.Errorf("invalid alert status key %s", key)
+		return nil
+	}
+	kind, namespace, name := parts[0], parts[1], parts[2]
+
+	var err error
+	switch kind {
+	case api.ResourceKindPodAlert:
+		var alert *api.PodAlert
+		if alert, err = op.paLister.PodAlerts(namespace).Get(name); err == nil {
+			err = op.syncPodAlertStatus(alert.DeepCopy())
+		}
+	case api.ResourceKindNodeAlert:
+		var alert *api.NodeAlert
+		if alert, err = op.naLister.NodeAlerts(namespace).Get(name); err == nil {
+			err = op.syncNodeAlertStatus(alert.DeepCopy())
+		}
+	case api.ResourceKindClusterAlert:
+		var alert *api.ClusterAlert
+		if alert, err = op.caLister.ClusterAlerts(namespace).Get(name); err == nil {
+			err = op.syncClusterAlertStatus(alert.DeepCopy())
+		}
+	case api.ResourceKindWorkloadAlert:
+		var alert *api.WorkloadAlert
+		if alert, err = op.waLister.WorkloadAlerts(namespace).Get(name); err == nil {
+			err = op.syncWorkloadAlertStatus(alert.DeepCopy())
+		}
+	case api.ResourceKindGlobalAlert:
+		var alert *api.GlobalAlert
+		if alert, err = op.gaLister.Get(name); err == nil {
+			err = op.syncGlobalAlertStatus(alert.DeepCopy())
+		}
+	default:
+		glog.Errorf("unknown alert kind %s in key %s", kind, key)
+		return nil
+	}
+	if kerr.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		log.Errorf("failed to update status of %s. reason: %s", key, err)
+	}
+	return err
+}
+
+func (op *Operator) syncPodAlertStatus(alert *api.PodAlert) error {
+	pods, err := op.podLister.Pods(alert.Namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	var kids []icinga.IcingaHost
+	for _, pod := range pods {
+		if alertAppliedToPod(pod.Annotations, alert.Name) {
+			kids = append(kids, icinga.IcingaHost{
+				Type:           icinga.TypePod,
+				AlertNamespace: alert.Namespace,
+				ObjectName:     pod.Name,
+			})
+		}
+	}
+	states, err := op.podHost.GetServiceStates(alert.Name, kids...)
+	if err != nil {
+		return err
+	}
+
+	key := alertStatusKey(api.ResourceKindPodAlert, alert.Namespace, alert.Name)
+	status := op.newAlertStatus(key, alert, alert.Generation, alert.Spec.Paused, alert.Status, kids, states)
+	if reflect.DeepEqual(&alert.Status, status) {
+		return nil
+	}
+	_, err = util.UpdatePodAlertStatus(op.extClient.MonitoringV1alpha1(), alert, func(in *api.AlertStatus) *api.AlertStatus {
+		return status
+	}, api.EnableStatusSubresource)
+	return err
+}
+
+func (op *Operator) syncNodeAlertStatus(alert *api.NodeAlert) error {
+	nodes, err := op.nodeLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	alertKey, err := cache.MetaNamespaceKeyFunc(alert)
+	if err != nil {
+		return err
+	}
+	var kids []icinga.IcingaHost
+	for _, node := range nodes {
+		if alertAppliedToNode(node.Annotations, alertKey) {
+			kids = append(kids, icinga.IcingaHost{
+				Type:           icinga.TypeNode,
+				AlertNamespace: alert.Namespace,
+				ObjectName:     node.Name,
+			})
+		}
+	}
+	states, err := op.nodeHost.GetServiceStates(alert.Name, kids...)
+	if err != nil {
+		return err
+	}
+
+	key := alertStatusKey(api.ResourceKindNodeAlert, alert.Namespace, alert.Name)
+	status := op.newAlertStatus(key, alert, alert.Generation, alert.Spec.Paused, alert.Status, kids, states)
+	if reflect.DeepEqual(&alert.Status, status) {
+		return nil
+	}
+	_, err = util.UpdateNodeAlertStatus(op.extClient.MonitoringV1alpha1(), alert, func(in *api.AlertStatus) *api.AlertStatus {
+		return status
+	}, api.EnableStatusSubresource)
+	return err
+}
+
+func (op *Operator) syncClusterAlertStatus(alert *api.ClusterAlert) error {
+	kids := []icinga.IcingaHost{
+		{
+			Type:           icinga.TypeCluster,
+			AlertNamespace: alert.Namespace,
+		},
+	}
+	states, err := op.clusterHost.GetServiceStates(alert.Name, kids...)
+	if err != nil {
+		return err
+	}
+
+	key := alertStatusKey(api.ResourceKindClusterAlert, alert.Namespace, alert.Name)
+	status := op.newAlertStatus(key, alert, alert.Generation, alert.Spec.Paused, alert.Status, kids, states)
+	if reflect.DeepEqual(&alert.Status, status) {
+		return nil
+	}
+	_, err = util.UpdateClusterAlertStatus(op.extClient.MonitoringV1alpha1(), alert, func(in *api.AlertStatus) *api.AlertStatus {
+		return status
+	}, api.EnableStatusSubresource)
+	return err
+}
+
+func (op *Operator) syncWorkloadAlertStatus(alert *api.WorkloadAlert) error {
+	kids, err := op.workloadAlertTargets(alert)
+	if err != nil {
+		return err
+	}
+	states, err := op.workloadHost.GetServiceStates(alert.Name, kids...)
+	if err != nil {
+		return err
+	}
+
+	key := alertStatusKey(api.ResourceKindWorkloadAlert, alert.Namespace, alert.Name)
+	status := op.newAlertStatus(key, alert, alert.Generation, alert.Spec.Paused, alert.Status, kids, states)
+	if reflect.DeepEqual(&alert.Status, status) {
+		return nil
+	}
+	_, err = util.UpdateWorkloadAlertStatus(op.extClient.MonitoringV1alpha1(), alert, func(in *api.AlertStatus) *api.AlertStatus {
+		return status
+	}, api.EnableStatusSubresource)
+	return err
+}
+
+func (op *Operator) syncGlobalAlertStatus(alert *api.GlobalAlert) error {
+	pods, err := op.podLister.Pods(core.NamespaceAll).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	var kids []icinga.IcingaHost
+	for _, pod := range pods {
+		if alertAppliedToPodGlobally(pod.Annotations, alert.Name) {
+			kids = append(kids, op.globalHost.GetHost(pod))
+		}
+	}
+	states, err := op.globalHost.GetServiceStates(alert.Name, kids...)
+	if err != nil {
+		return err
+	}
+
+	key := alertStatusKey(api.ResourceKindGlobalAlert, "", alert.Name)
+	status := op.newAlertStatus(key, alert, alert.Generation, alert.Spec.Paused, alert.Status, kids, states)
+	if reflect.DeepEqual(&alert.Status, status) {
+		return nil
+	}
+	_, err = util.UpdateGlobalAlertStatus(op.extClient.MonitoringV1alpha1(), alert, func(in *api.AlertStatus) *api.AlertStatus {
+		return status
+	}, api.EnableStatusSubresource)
+	return err
+}
+
+func (op *Operator) newAlertStatus(
+	key string,
+	alert api.Alert,
+	generation int64,
+	paused bool,
+	cur api.AlertStatus,
+	kids []icinga.IcingaHost,
+	states map[string]icinga.ServiceState,
+) *api.AlertStatus {
+	status := cur.DeepCopy()
+	status.ObservedGeneration = generation
+
+	hosts := make([]string, len(kids))
+	for i, kh := range kids {
+		hosts[i], _ = kh.Name()
+	}
+	errs := op.targetErrors.get(key, hosts)
+
+	// status is compared with the current one, so it is built as decoded from the API server:
+	// without empty lists and with times truncated to seconds
+	status.Targets = nil
+	for i, kh := range kids {
+		target := api.AlertTarget{
+			Name:       kh.ObjectName,
+			IcingaHost: hosts[i],
+			Message:    errs[hosts[i]],
+		}
+		if kh.Type == icinga.TypePod || kh.Type == icinga.TypeWorkload || kh.Type == icinga.TypeGlobal {
+			target.Namespace = kh.AlertNamespace
+		}
+		if st, ok := states[hosts[i]]; ok {
+			target.State = st.State.String()
+			if !st.LastCheck.IsZero() {
+				t := metav1.NewTime(st.LastCheck.Truncate(time.Second))
+				target.LastCheckTime = &t
+			}
+		}
+		status.Targets = append(status.Targets, target)
+	}
+	sort.Slice(status.Targets, func(i, j int) bool {
+		return status.Targets[i].IcingaHost < status.Targets[j].IcingaHost
+	})
+
+	validErr := op.validate(alert)
+	if validErr != nil {
+		status.SetCondition(api.AlertCondition{
+			Type:    api.AlertConditionValid,
+			Status:  core.ConditionFalse,
+			Reason:  ReasonInvalidAlert,
+			Message: validErr.Error(),
+		})
+	} else {
+		status.SetCondition(api.AlertCondition{
+			Type:   api.AlertConditionValid,
+			Status: core.ConditionTrue,
+		})
+	}
+
+	if paused {
+		status.SetCondition(api.AlertCondition{
+			Type:    api.AlertConditionPaused,
+			Status:  core.ConditionTrue,
+			Reason:  ReasonAlertPaused,
+			Message: "Icinga services of this alert have been removed",
+		})
+	} else {
+		status.SetCondition(api.AlertCondition{
+			Type:   api.AlertConditionPaused,
+			Status: core.ConditionFalse,
+		})
+	}
+
+	switch {
+	case validErr != nil:
+		status.SetCondition(api.AlertCondition{
+			Type:    api.AlertConditionSynced,
+			Status:  core.ConditionFalse,
+			Reason:  ReasonInvalidAlert,
+			Message: "alert is not applied until it is valid",
+		})
+	case len(errs) > 0:
+		status.SetCondition(api.AlertCondition{
+			Type:    api.AlertConditionSynced,
+			Status:  core.ConditionFalse,
+			Reason:  ReasonSyncFailed,
+			Message: fmt.Sprintf("failed to apply to %d of %d targets", len(errs), len(kids)),
+		})
+	default:
+		status.SetCondition(api.AlertCondition{
+			Type:   api.AlertConditionSynced,
+			Status: core.ConditionTrue,
+			Reason: ReasonSynced,
+		})
+	}
+	return status
+}
@@ -0,0 +1,57 @@
+package operator
+
+import (
+	"testing"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	mon_fake "github.com/appscode/searchlight/client/clientset/versioned/fake"
+	"github.com/appscode/searchlight/pkg/icinga"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	core_listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestSyncAlertStatusSkipsUnchanged(t *testing.T) {
+	// the status subresource is updated without comparing the status
+	enabled := api.EnableStatusSubresource
+	api.EnableStatusSubresource = true
+	defer func() { api.EnableStatusSubresource = enabled }()
+
+	alert := &api.PodAlert{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-status-demo", Namespace: "demo", Generation: 2},
+		Spec: api.PodAlertSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}},
+			Check:    "pod-status",
+		},
+	}
+	extClient := mon_fake.NewSimpleClientset(alert)
+	op := &Operator{
+		kubeClient:   fake.NewSimpleClientset(),
+		extClient:    extClient,
+		podHost:      icinga.NewPodHost(nil, ""),
+		podLister:    core_listers.NewPodLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})),
+		targetErrors: newTargetErrors(),
+	}
+
+	if err := op.syncPodAlertStatus(alert.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+	synced, err := extClient.MonitoringV1alpha1().PodAlerts(alert.Namespace).Get(alert.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if synced.Status.ObservedGeneration != alert.Generation || len(synced.Status.Conditions) == 0 {
+		t.Fatalf("expected status of generation %d with conditions, got %+v", alert.Generation, synced.Status)
+	}
+
+	extClient.ClearActions()
+	if err := op.syncPodAlertStatus(synced.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+	for _, action := range extClient.Actions() {
+		if action.GetVerb() != "get" && action.GetVerb() != "list" {
+			t.Errorf("expected unchanged status not to be written, got %s %s", action.GetVerb(), action.GetSubresource())
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package operator
+
+import (
+	"reflect"
+
+	"github.com/appscode/go/log"
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/pkg/eventer"
+	"github.com/golang/glog"
+	core "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"kmodules.xyz/client-go/tools/queue"
+)
+
+func (op *Operator) initTimePeriodWatcher() {
+	op.tpInformer = op.monInformerFactory.Monitoring().V1alpha1().TimePeriods().Informer()
+	op.tpQueue = op.newQueue("TimePeriod", op.reconcileTimePeriod)
+	op.tpInformer.AddEventHandler(&cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			queue.Enqueue(op.tpQueue.GetQueue(), obj)
+		},
+		UpdateFunc: func(oldObj interface{}, newObj interface{}) {
+			old := oldObj.(*api.TimePeriod)
+			nu := newObj.(*api.TimePeriod)
+
+			// Periodic resyncs are processed too, since Icinga ranges are converted
+			// to UTC and change with daylight saving time.
+			if old.ResourceVersion != nu.ResourceVersion && reflect.DeepEqual(old.Spec, nu.Spec) {
+				return
+			}
+			queue.Enqueue(op.tpQueue.GetQueue(), nu)
+		},
+		DeleteFunc: func(obj interface{}) {
+			queue.Enqueue(op.tpQueue.GetQueue(), obj)
+		},
+	})
+	op.tpLister = op.monInformerFactory.Monitoring().V1alpha1().TimePeriods().Lister()
+}
+
+func (op *Operator) reconcileTimePeriod(key string) error {
+	obj, exists, err := op.tpInformer.GetIndexer().GetByKey(key)
+	if err != nil {
+		glog.Errorf("Fetching object with key %s from store failed with %v", key, err)
+		return err
+	}
+
+	if !exists {
+		log.Warningf("TimePeriod %s does not exist anymore\n", key)
+
+		_, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			return err
+		}
+		return op.timePeriods.Delete(name)
+	}
+
+	tp := obj.(*api.TimePeriod).DeepCopy()
+	log.Infof("Sync/Add/Update for TimePeriod %s\n", key)
+
+	if err := op.timePeriods.Apply(tp); err != nil {
+		op.recorder.Eventf(
+			tp.ObjectReference(),
+			core.EventTypeWarning,
+			eventer.EventReasonFailedToSync,
+			`failed to sync Icinga TimePeriod. Reason: %v`,
+			err,
+		)
+		return err
+	}
+	return nil
+}
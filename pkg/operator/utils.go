@@ -4,16 +4,18 @@ import (
 	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
 	mon_listers "github.com/appscode/searchlight/client/listers/monitoring/v1alpha1"
 	"github.com/appscode/searchlight/pkg/eventer"
+	"github.com/pkg/errors"
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 )
 
 func (op *Operator) isValid(alert api.Alert) bool {
 	// Validate IcingaCommand & it's variables.
 	// And also check supported IcingaState
-	err := alert.IsValid(op.kubeClient)
+	err := op.validate(alert)
 	if err != nil {
 		op.recorder.Eventf(
 			alert.ObjectReference(),
@@ -22,11 +24,35 @@ func (op *Operator) isValid(alert api.Alert) bool {
 			`Reason: %v`,
 			err,
 		)
+		op.enqueueAlertStatus(alert.ObjectReference().Kind, alert.GetNamespace(), alert.GetName())
 	}
 	return err == nil
 }
 
-func findPodAlert(kc kubernetes.Interface, lister mon_listers.PodAlertLister, obj metav1.ObjectMeta) ([]*api.PodAlert, error) {
+// validate validates an alert merged with its AlertTemplate.
+func (op *Operator) validate(alert api.Alert) error {
+	merged, err := withTemplate(op.atLister, alert)
+	if err != nil {
+		return err
+	}
+	return merged.IsValid(op.kubeClient)
+}
+
+// withTemplate returns a copy of an alert merged with the AlertTemplate it refers to.
+func withTemplate(lister mon_listers.AlertTemplateLister, alert api.Alert) (api.Alert, error) {
+	name := api.GetTemplateRef(alert)
+	if name == "" {
+		return alert, nil
+	}
+	t, err := lister.AlertTemplates(alert.GetNamespace()).Get(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get AlertTemplate %s/%s", alert.GetNamespace(), name)
+	}
+	return api.WithTemplate(alert, t), nil
+}
+
+// findPodAlert returns the valid PodAlerts which select the given pod, merged with their AlertTemplates.
+func findPodAlert(kc kubernetes.Interface, lister mon_listers.PodAlertLister, atLister mon_listers.AlertTemplateLister, obj metav1.ObjectMeta) ([]*api.PodAlert, error) {
 	alerts, err := lister.PodAlerts(obj.Namespace).List(labels.Everything())
 	if err != nil {
 		return nil, err
@@ -34,7 +60,15 @@ func findPodAlert(kc kubernetes.Interface, lister mon_listers.PodAlertLister, ob
 
 	result := make([]*api.PodAlert, 0)
 	for i := range alerts {
-		alert := alerts[i]
+		// alerts being deleted are removed by their finalizer
+		if alerts[i].DeletionTimestamp != nil {
+			continue
+		}
+		merged, err := withTemplate(atLister, alerts[i])
+		if err != nil {
+			continue
+		}
+		alert := merged.(*api.PodAlert)
 		if err := alert.IsValid(kc); err != nil {
 			continue
 		}
@@ -54,25 +88,60 @@ func findPodAlert(kc kubernetes.Interface, lister mon_listers.PodAlertLister, ob
 	return result, nil
 }
 
-func findNodeAlert(kc kubernetes.Interface, lister mon_listers.NodeAlertLister, obj metav1.ObjectMeta) ([]*api.NodeAlert, error) {
-	alerts, err := lister.NodeAlerts(obj.Namespace).List(labels.Everything())
+// findAnnotationAlerts returns the valid PodAlerts declared in the annotations of a pod, merged with
+// their AlertTemplates. Invalid ones are reported as events of the pod.
+func (op *Operator) findAnnotationAlerts(pod *core.Pod) []*api.PodAlert {
+	alerts, err := api.AnnotationAlerts(pod)
+	if err != nil {
+		op.recorder.Eventf(pod, core.EventTypeWarning, eventer.EventReasonAlertInvalid, `Reason: %v`, err)
+	}
+
+	result := make([]*api.PodAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		merged, err := withTemplate(op.atLister, alert)
+		if err == nil {
+			err = merged.IsValid(op.kubeClient)
+		}
+		if err != nil {
+			op.recorder.Eventf(pod, core.EventTypeWarning, eventer.EventReasonAlertInvalid, `Alert %s. Reason: %v`, alert.Name, err)
+			continue
+		}
+		result = append(result, merged.(*api.PodAlert))
+	}
+	return result
+}
+
+// podAlertEventObject returns the object to record events of a PodAlert applied to a pod on.
+// Alerts declared in pod annotations have no object of their own.
+func podAlertEventObject(alert *api.PodAlert, pod *core.Pod) runtime.Object {
+	if api.IsAnnotationAlert(alert.Name) {
+		return pod
+	}
+	return alert.ObjectReference()
+}
+
+// findGlobalAlert returns the valid GlobalAlerts which select the given pod and its namespace.
+func findGlobalAlert(kc kubernetes.Interface, lister mon_listers.GlobalAlertLister, ns *core.Namespace, obj metav1.ObjectMeta) ([]*api.GlobalAlert, error) {
+	alerts, err := lister.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
 
-	result := make([]*api.NodeAlert, 0)
+	result := make([]*api.GlobalAlert, 0)
 	for i := range alerts {
 		alert := alerts[i]
+		if alert.DeletionTimestamp != nil {
+			continue
+		}
 		if err := alert.IsValid(kc); err != nil {
 			continue
 		}
 
-		if alert.Spec.NodeName != nil {
-			if *alert.Spec.NodeName == obj.Name {
-				result = append(result, alert)
-			}
-		} else {
-			selector := labels.SelectorFromSet(alert.Spec.Selector)
+		nsSelector, err := alert.NamespaceSelector()
+		if err != nil || !nsSelector.Matches(labels.Set(ns.Labels)) {
+			continue
+		}
+		if selector, err := metav1.LabelSelectorAsSelector(alert.Spec.Selector); err == nil {
 			if selector.Matches(labels.Set(obj.Labels)) {
 				result = append(result, alert)
 			}
@@ -80,3 +149,44 @@ func findNodeAlert(kc kubernetes.Interface, lister mon_listers.NodeAlertLister,
 	}
 	return result, nil
 }
+
+// findNodeAlert returns the valid NodeAlerts which select the given node, merged with their AlertTemplates.
+func findNodeAlert(kc kubernetes.Interface, lister mon_listers.NodeAlertLister, atLister mon_listers.AlertTemplateLister, node *core.Node) ([]*api.NodeAlert, error) {
+	alerts, err := lister.NodeAlerts(node.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api.NodeAlert, 0)
+	for i := range alerts {
+		// alerts being deleted are removed by their finalizer
+		if alerts[i].DeletionTimestamp != nil {
+			continue
+		}
+		merged, err := withTemplate(atLister, alerts[i])
+		if err != nil {
+			continue
+		}
+		alert := merged.(*api.NodeAlert)
+		if err := alert.IsValid(kc); err != nil {
+			continue
+		}
+
+		if ok, err := alert.MatchesNode(node); err == nil && ok {
+			result = append(result, alert)
+		}
+	}
+	return result, nil
+}
+
+// userAnnotations returns the annotations of an object except the ones maintained by Searchlight operator,
+// so that updates of the latter don't trigger reconciliation.
+func userAnnotations(in map[string]string) map[string]string {
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		if k != api.AnnotationKeyAlerts && k != api.AnnotationKeyGlobalAlerts {
+			out[k] = v
+		}
+	}
+	return out
+}
@@ -0,0 +1,234 @@
+package operator
+
+import (
+	"reflect"
+
+	"github.com/appscode/go/log"
+	utilerrors "github.com/appscode/go/util/errors"
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/pkg/eventer"
+	"github.com/appscode/searchlight/pkg/icinga"
+	"github.com/golang/glog"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"kmodules.xyz/client-go/tools/queue"
+)
+
+func (op *Operator) initWorkloadAlertWatcher() {
+	op.waInformer = op.monInformerFactory.Monitoring().V1alpha1().WorkloadAlerts().Informer()
+	op.waQueue = op.newQueue("WorkloadAlert", op.reconcileWorkloadAlert)
+	op.waInformer.AddEventHandler(&cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			alert := obj.(*api.WorkloadAlert)
+			if alert.DeletionTimestamp != nil || op.isValid(alert) {
+				queue.Enqueue(op.waQueue.GetQueue(), obj)
+			}
+			op.enqueueAlertPlugins(obj)
+		},
+		UpdateFunc: func(oldObj interface{}, newObj interface{}) {
+			old := oldObj.(*api.WorkloadAlert)
+			nu := newObj.(*api.WorkloadAlert)
+
+			if alertPluginsChanged(old.ObjectMeta, nu.ObjectMeta, old.Spec, nu.Spec) {
+				op.enqueueAlertPlugins(old, nu)
+			}
+			if nu.DeletionTimestamp != nil {
+				queue.Enqueue(op.waQueue.GetQueue(), nu)
+				return
+			}
+			if reflect.DeepEqual(old.Spec, nu.Spec) {
+				return
+			}
+			// Services for workloads of the old spec are removed by reconcile,
+			// so an alert which became invalid is enqueued too.
+			op.isValid(nu)
+			queue.Enqueue(op.waQueue.GetQueue(), nu)
+		},
+		DeleteFunc: func(obj interface{}) {
+			queue.Enqueue(op.waQueue.GetQueue(), obj)
+			op.enqueueAlertPlugins(obj)
+		},
+	})
+	op.waLister = op.monInformerFactory.Monitoring().V1alpha1().WorkloadAlerts().Lister()
+}
+
+func (op *Operator) reconcileWorkloadAlert(key string) error {
+	obj, exists, err := op.waInformer.GetIndexer().GetByKey(key)
+	if err != nil {
+		glog.Errorf("Fetching object with key %s from store failed with %v", key, err)
+		return err
+	}
+
+	if !exists {
+		log.Warningf("WorkloadAlert %s does not exist anymore\n", key)
+
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			return err
+		}
+		return op.ensureWorkloadAlertDeleted(namespace, name, sets.NewString())
+	}
+
+	alert := obj.(*api.WorkloadAlert).DeepCopy()
+	if deleting, err := op.ensureAlertFinalizer(alert); deleting || err != nil {
+		return err
+	}
+	log.Infof("Sync/Add/Update for WorkloadAlert %s\n", alert.GetName())
+
+	err = op.ensureWorkloadAlert(alert)
+	op.enqueueAlertStatus(api.ResourceKindWorkloadAlert, alert.Namespace, alert.Name)
+	return err
+}
+
+func (op *Operator) ensureWorkloadAlert(alert *api.WorkloadAlert) error {
+	names, err := op.findWorkloads(alert)
+	if err != nil {
+		return err
+	}
+
+	var errlist []error
+	hosts := sets.NewString()
+	for _, name := range names {
+		kh := op.workloadHost.GetHost(alert.Namespace, alert.Spec.TargetRef.Kind, name)
+		host, _ := kh.Name()
+		hosts.Insert(host)
+
+		err = op.workloadHost.Apply(alert, name)
+		op.targetErrors.set(alertStatusKey(api.ResourceKindWorkloadAlert, alert.Namespace, alert.Name), kh, err)
+		if err != nil {
+			op.recorder.Eventf(
+				alert.ObjectReference(),
+				core.EventTypeWarning,
+				eventer.EventReasonFailedToSync,
+				`failed to apply to %s %s/%s. Reason: %v`,
+				alert.Spec.TargetRef.Kind, alert.Namespace, name, err,
+			)
+			errlist = append(errlist, err)
+		}
+	}
+
+	if err := op.ensureWorkloadAlertDeleted(alert.Namespace, alert.Name, hosts); err != nil {
+		errlist = append(errlist, err)
+	}
+	return utilerrors.NewAggregate(errlist)
+}
+
+// ensureWorkloadAlertDeleted removes Icinga Services of the named WorkloadAlert from
+// all workload hosts except the ones listed in keep.
+func (op *Operator) ensureWorkloadAlertDeleted(alertNamespace, alertName string, keep sets.String) error {
+	khs, err := op.workloadHost.ListHosts(alertNamespace, alertName)
+	if err != nil {
+		return err
+	}
+
+	var errlist []error
+	for _, kh := range khs {
+		host, _ := kh.Name()
+		if keep.Has(host) {
+			continue
+		}
+		if err := op.workloadHost.Delete(alertName, kh); err != nil {
+			if alert, e2 := op.waLister.WorkloadAlerts(alertNamespace).Get(alertName); e2 == nil {
+				op.recorder.Eventf(
+					alert.ObjectReference(),
+					core.EventTypeWarning,
+					eventer.EventReasonFailedToDelete,
+					`failed to delete for %s %s/%s. Reason: %s`,
+					kh.WorkloadKind, alertNamespace, kh.ObjectName, err,
+				)
+			}
+			errlist = append(errlist, err)
+		}
+	}
+	return utilerrors.NewAggregate(errlist)
+}
+
+// findWorkloads returns names of the workloads targeted by a valid WorkloadAlert.
+func (op *Operator) findWorkloads(alert *api.WorkloadAlert) ([]string, error) {
+	if err := alert.IsValid(op.kubeClient); err != nil {
+		return nil, nil
+	}
+	ref := alert.Spec.TargetRef
+
+	if ref.Name != nil {
+		var err error
+		switch ref.Kind {
+		case api.WorkloadKindDeployment:
+			_, err = op.deployLister.Deployments(alert.Namespace).Get(*ref.Name)
+		case api.WorkloadKindStatefulSet:
+			_, err = op.stsLister.StatefulSets(alert.Namespace).Get(*ref.Name)
+		case api.WorkloadKindDaemonSet:
+			_, err = op.dsLister.DaemonSets(alert.Namespace).Get(*ref.Name)
+		}
+		if kerr.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return []string{*ref.Name}, nil
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(ref.Selector)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	switch ref.Kind {
+	case api.WorkloadKindDeployment:
+		objs, err := op.deployLister.Deployments(alert.Namespace).List(sel)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objs {
+			names = append(names, obj.Name)
+		}
+	case api.WorkloadKindStatefulSet:
+		objs, err := op.stsLister.StatefulSets(alert.Namespace).List(sel)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objs {
+			names = append(names, obj.Name)
+		}
+	case api.WorkloadKindDaemonSet:
+		objs, err := op.dsLister.DaemonSets(alert.Namespace).List(sel)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objs {
+			names = append(names, obj.Name)
+		}
+	}
+	return names, nil
+}
+
+// workloadAlertTargets returns Icinga hosts of the workloads targeted by a WorkloadAlert.
+func (op *Operator) workloadAlertTargets(alert *api.WorkloadAlert) ([]icinga.IcingaHost, error) {
+	names, err := op.findWorkloads(alert)
+	if err != nil {
+		return nil, err
+	}
+	kids := make([]icinga.IcingaHost, 0, len(names))
+	for _, name := range names {
+		kids = append(kids, op.workloadHost.GetHost(alert.Namespace, alert.Spec.TargetRef.Kind, name))
+	}
+	return kids, nil
+}
+
+// enqueueWorkloadAlerts enqueues the WorkloadAlerts in a namespace targeting the given kind of workloads.
+func (op *Operator) enqueueWorkloadAlerts(kind, namespace string) {
+	alerts, err := op.waLister.WorkloadAlerts(namespace).List(labels.Everything())
+	if err != nil {
+		log.Errorln(err)
+		return
+	}
+	for _, alert := range alerts {
+		if alert.Spec.TargetRef.Kind == kind {
+			queue.Enqueue(op.waQueue.GetQueue(), alert)
+		}
+	}
+}
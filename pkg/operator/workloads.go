@@ -0,0 +1,69 @@
+package operator
+
+import (
+	"reflect"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/cache"
+)
+
+func (op *Operator) initWorkloadWatcher() {
+	op.deployInformer = op.kubeInformerFactory.Apps().V1().Deployments().Informer()
+	op.deployInformer.AddEventHandler(op.workloadEventHandler(api.WorkloadKindDeployment))
+	op.deployLister = op.kubeInformerFactory.Apps().V1().Deployments().Lister()
+
+	op.stsInformer = op.kubeInformerFactory.Apps().V1().StatefulSets().Informer()
+	op.stsInformer.AddEventHandler(op.workloadEventHandler(api.WorkloadKindStatefulSet))
+	op.stsLister = op.kubeInformerFactory.Apps().V1().StatefulSets().Lister()
+
+	op.dsInformer = op.kubeInformerFactory.Apps().V1().DaemonSets().Informer()
+	op.dsInformer.AddEventHandler(op.workloadEventHandler(api.WorkloadKindDaemonSet))
+	op.dsLister = op.kubeInformerFactory.Apps().V1().DaemonSets().Lister()
+
+	// ReplicaSets resolve the Deployments of pods
+	op.rsLister = op.kubeInformerFactory.Apps().V1().ReplicaSets().Lister()
+}
+
+// workloadEventHandler requeues WorkloadAlerts when a workload of the given kind is added,
+// deleted or relabeled, since that may change the set of workloads they target. Rollouts of
+// Deployments and StatefulSets are enqueued too, so that problems of their pods can be suppressed.
+func (op *Operator) workloadEventHandler(kind string) cache.ResourceEventHandler {
+	return &cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if m, err := meta.Accessor(obj); err == nil {
+				op.enqueueWorkloadAlerts(kind, m.GetNamespace())
+				if isRollingOut(obj) && op.watchesRollout(kind, obj) {
+					op.enqueueRollout(kind, m.GetNamespace(), m.GetName())
+				}
+			}
+		},
+		UpdateFunc: func(oldObj interface{}, newObj interface{}) {
+			old, err := meta.Accessor(oldObj)
+			if err != nil {
+				return
+			}
+			nu, err := meta.Accessor(newObj)
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(old.GetLabels(), nu.GetLabels()) {
+				op.enqueueWorkloadAlerts(kind, nu.GetNamespace())
+			}
+			if (isRollingOut(oldObj) || isRollingOut(newObj)) && op.watchesRollout(kind, newObj) {
+				op.enqueueRollout(kind, nu.GetNamespace(), nu.GetName())
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if m, err := meta.Accessor(obj); err == nil {
+				op.enqueueWorkloadAlerts(kind, m.GetNamespace())
+				if op.watchesRollout(kind, obj) {
+					op.enqueueRollout(kind, m.GetNamespace(), m.GetName())
+				}
+			}
+		},
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/plugins"
 	"github.com/appscode/searchlight/plugins/check_webhook"
 )
 
@@ -16,7 +17,11 @@ var checkCommandTemplate = `object CheckCommand "%s" {
   arguments = {
 	%s
   }
-}`
+
+  env = {
+	"%s" = "$host.name$"
+  }
+%s}`
 
 func GenerateCheckCommand(plugin *api.SearchlightPlugin) string {
 	type arg struct {
@@ -94,5 +99,28 @@ func GenerateCheckCommand(plugin *api.SearchlightPlugin) string {
 		}
 	}
 
-	return fmt.Sprintf(checkCommandTemplate, plugin.Name, command, strings.Join(flagList, "\n\t"))
+	// Defaults of vars, used if neither service nor host sets them
+	var defaults string
+	if plugin.Spec.Arguments.Vars != nil {
+		keys := make([]string, 0, len(plugin.Spec.Arguments.Vars.Fields))
+		for key, field := range plugin.Spec.Arguments.Vars.Fields {
+			if field.Default != "" {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			defaults += fmt.Sprintf("  vars.%s = %s\n", key, quote(plugin.Spec.Arguments.Vars.Fields[key].Default))
+		}
+	}
+	if defaults != "" {
+		defaults = "\n" + defaults
+	}
+
+	return fmt.Sprintf(checkCommandTemplate, plugin.Name, command, strings.Join(flagList, "\n\t"), plugins.EnvIcingaHost, defaults)
+}
+
+// quote returns s as an Icinga 2 string literal.
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "$", "$$").Replace(s) + `"`
 }
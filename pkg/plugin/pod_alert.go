@@ -1,6 +1,7 @@
 package plugin
 
 import (
+	"github.com/appscode/go/types"
 	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -44,10 +45,14 @@ func GetPodVolumePlugin() *api.SearchlightPlugin {
 							Type: api.VarTypeString,
 						},
 						"warning": {
-							Type: api.VarTypeNumber,
+							Type:    api.VarTypeNumber,
+							Minimum: types.Float64P(0),
+							Maximum: types.Float64P(100),
 						},
 						"critical": {
-							Type: api.VarTypeNumber,
+							Type:    api.VarTypeNumber,
+							Minimum: types.Float64P(0),
+							Maximum: types.Float64P(100),
 						},
 					},
 					Required: []string{"volumeName"},
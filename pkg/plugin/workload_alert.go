@@ -0,0 +1,63 @@
+package plugin
+
+import (
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func GetWorkloadStatusPlugin() *api.SearchlightPlugin {
+	return &api.SearchlightPlugin{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "workload-status",
+		},
+		TypeMeta: PluginTypeMeta,
+		Spec: api.SearchlightPluginSpec{
+			Command:    "hyperalert check_workload_status",
+			AlertKinds: []string{api.ResourceKindWorkloadAlert},
+			Arguments: api.PluginArguments{
+				Vars: &api.PluginVars{
+					Fields: map[string]api.PluginVarField{
+						"minAvailable": {
+							Type: api.VarTypeInteger,
+						},
+					},
+				},
+				Host: map[string]string{
+					"host": "name",
+					"v":    "vars.verbosity",
+				},
+			},
+			States: []string{stateOK, stateWarning, stateCritical, stateUnknown},
+		},
+	}
+}
+
+func GetWorkloadRestartsPlugin() *api.SearchlightPlugin {
+	return &api.SearchlightPlugin{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "workload-restarts",
+		},
+		TypeMeta: PluginTypeMeta,
+		Spec: api.SearchlightPluginSpec{
+			Command:    "hyperalert check_workload_restarts",
+			AlertKinds: []string{api.ResourceKindWorkloadAlert},
+			Arguments: api.PluginArguments{
+				Vars: &api.PluginVars{
+					Fields: map[string]api.PluginVarField{
+						"warning": {
+							Type: api.VarTypeInteger,
+						},
+						"critical": {
+							Type: api.VarTypeInteger,
+						},
+					},
+				},
+				Host: map[string]string{
+					"host": "name",
+					"v":    "vars.verbosity",
+				},
+			},
+			States: []string{stateOK, stateWarning, stateCritical, stateUnknown},
+		},
+	}
+}
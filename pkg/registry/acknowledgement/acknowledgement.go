@@ -3,7 +3,6 @@ package acknowledgement
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/appscode/go/log"
 	"github.com/appscode/searchlight/apis/incidents"
@@ -71,7 +70,8 @@ func (r *REST) Create(ctx context.Context, obj runtime.Object, _ rest.ValidateOb
 
 	mp := make(map[string]interface{})
 	mp["type"] = "Service"
-	mp["filter"] = fmt.Sprintf(`service.name == "%s" && host.name == "%s"`, service, host)
+	mp["filter"] = "service.name == svc && host.name == hostName"
+	mp["filter_vars"] = map[string]string{"svc": service, "hostName": host}
 	mp["comment"] = req.Request.Comment
 	mp["notify"] = !req.Request.SkipNotify
 	if user, ok := apirequest.UserFrom(ctx); ok {
@@ -126,7 +126,8 @@ func (r *REST) Delete(ctx context.Context, name string, options *metav1.DeleteOp
 
 	mp := make(map[string]interface{})
 	mp["type"] = "Service"
-	mp["filter"] = fmt.Sprintf(`service.name == "%s" && host.name == "%s"`, service, host)
+	mp["filter"] = "service.name == svc && host.name == hostName"
+	mp["filter_vars"] = map[string]string{"svc": service, "hostName": host}
 	ack, err := json.Marshal(mp)
 	if err != nil {
 		return nil, false, err
@@ -185,6 +186,12 @@ func (r *REST) getIcingaObjects(namespace, name string) (host string, service st
 			return "", "", errors.Errorf("incident %s/%s is missing label %s", namespace, name, monitoring.LabelKeyObjectName)
 		}
 	}
+	if icingaHost.Type == icinga.TypeWorkload {
+		icingaHost.WorkloadKind, ok = incident.Labels[monitoring.LabelKeyObjectKind]
+		if !ok {
+			return "", "", errors.Errorf("incident %s/%s is missing label %s", namespace, name, monitoring.LabelKeyObjectKind)
+		}
+	}
 	host, err = icingaHost.Name()
 	return
 }
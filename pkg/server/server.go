@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/appscode/go/log"
 	"github.com/appscode/searchlight/apis/incidents"
 	"github.com/appscode/searchlight/apis/incidents/install"
 	"github.com/appscode/searchlight/apis/incidents/v1alpha1"
@@ -57,7 +58,11 @@ type SearchlightServer struct {
 }
 
 func (op *SearchlightServer) Run(stopCh <-chan struct{}) error {
-	go op.Operator.Run(stopCh)
+	go func() {
+		if err := op.Operator.Run(stopCh); err != nil {
+			log.Fatalln(err)
+		}
+	}()
 	return op.GenericAPIServer.PrepareRun().Run(stopCh)
 }
 
@@ -72,7 +72,7 @@ func (o *options) complete(cmd *cobra.Command) error {
 }
 
 func (o *options) validate() error {
-	if o.host.Type != icinga.TypePod {
+	if o.host.Type != icinga.TypePod && o.host.Type != icinga.TypeGlobal {
 		return errors.New("invalid icinga host type")
 	}
 	return nil
@@ -146,6 +146,17 @@ var _ = Describe("check_pod_status", func() {
 				err = opts.validate()
 				Expect(err).ShouldNot(HaveOccurred())
 			})
+			It("with global host", func() {
+				opts := options{}
+				cmd.Flags().Set(plugins.FlagHost, "demo@global@name")
+				err := opts.complete(cmd)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(opts.podName).Should(BeIdenticalTo("name"))
+				Expect(opts.namespace).Should(BeIdenticalTo("demo"))
+				Expect(opts.host.Type).Should(BeIdenticalTo("global"))
+				err = opts.validate()
+				Expect(err).ShouldNot(HaveOccurred())
+			})
 		})
 	})
 })
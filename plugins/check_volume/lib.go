@@ -180,7 +180,7 @@ func (o *options) validate() error {
 			return errors.New("invalid icinga host type")
 		}
 	} else {
-		if o.host.Type != icinga.TypePod {
+		if o.host.Type != icinga.TypePod && o.host.Type != icinga.TypeGlobal {
 			return errors.New("invalid icinga host type")
 		}
 	}
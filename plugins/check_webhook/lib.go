@@ -6,12 +6,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/appscode/go/flags"
-	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
 	"github.com/appscode/searchlight/client/clientset/versioned/typed/monitoring/v1alpha1"
 	cs "github.com/appscode/searchlight/client/clientset/versioned/typed/monitoring/v1alpha1"
 	"github.com/appscode/searchlight/pkg/icinga"
@@ -110,34 +108,21 @@ func (p *plugin) Check() (icinga.State, interface{}) {
 				return stateUnknown, fmt.Errorf(`var "%s" is not registered in SearchlightPlugin`, p.key)
 			}
 
-			switch item.Type {
-			case api.VarTypeInteger:
-				val, err := strconv.ParseInt(p.val, 10, 64)
-				if err != nil {
-					return icinga.Unknown, fmt.Errorf(`failed to parse value for key "%s" to Int64`, p.key)
-				}
-				data[p.key] = val
-			case api.VarTypeNumber:
-				val, err := strconv.ParseFloat(p.val, 64)
-				if err != nil {
-					return icinga.Unknown, fmt.Errorf(`failed to parse value for key "%s" to Float64`, p.key)
-				}
-				data[p.key] = val
-			case api.VarTypeString:
-				data[p.key] = p.val
-			case api.VarTypeBoolean:
-				val, err := strconv.ParseBool(p.val)
-				if err != nil {
-					return icinga.Unknown, fmt.Errorf(`failed to parse value for key "%s" to Bool`, p.key)
-				}
-				data[p.key] = val
-			case api.VarTypeDuration:
-				duration, err := time.ParseDuration(p.val)
-				if err != nil {
-					return icinga.Unknown, fmt.Errorf(`failed to parse value for key "%s" to Duration`, p.key)
-				}
-				data[p.key] = int64(duration.Nanoseconds() / 1000000)
+			val, err := item.Parse(p.val)
+			if err != nil {
+				return icinga.Unknown, fmt.Errorf(`failed to parse value for key "%s": %v`, p.key, err)
+			}
+			data[p.key] = toJSONValue(val)
+		}
+		for key, item := range vars.Fields {
+			if _, found := data[key]; found || item.Default == "" {
+				continue
+			}
+			val, err := item.Parse(item.Default)
+			if err != nil {
+				return icinga.Unknown, fmt.Errorf(`failed to parse default value for key "%s": %v`, key, err)
 			}
+			data[key] = toJSONValue(val)
 		}
 	} else {
 		for _, p := range opts.params {
@@ -178,6 +163,23 @@ func (p *plugin) Check() (icinga.State, interface{}) {
 	return icinga.State(*respData.Code), respData.Message
 }
 
+// toJSONValue converts durations, also inside lists and maps, to milliseconds.
+func toJSONValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case time.Duration:
+		return int64(v.Nanoseconds() / 1000000)
+	case []interface{}:
+		for i := range v {
+			v[i] = toJSONValue(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = toJSONValue(v[k])
+		}
+	}
+	return val
+}
+
 func NewCmd() *cobra.Command {
 	opts := options{
 		params: make([]param, totalFlag),
@@ -0,0 +1,171 @@
+package check_workload_restarts
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/appscode/go/flags"
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/pkg/icinga"
+	"github.com/appscode/searchlight/plugins"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"kmodules.xyz/client-go/tools/clientcmd"
+)
+
+type plugin struct {
+	client  kubernetes.Interface
+	options options
+}
+
+var _ plugins.PluginInterface = &plugin{}
+
+func newPlugin(client kubernetes.Interface, opts options) *plugin {
+	return &plugin{client, opts}
+}
+
+func newPluginFromConfig(opts options) (*plugin, error) {
+	client, err := clientcmd.ClientFromContext(opts.kubeconfigPath, opts.contextName)
+	if err != nil {
+		return nil, err
+	}
+	return newPlugin(client, opts), nil
+}
+
+type options struct {
+	kubeconfigPath string
+	contextName    string
+	// options
+	namespace string
+	kind      string
+	name      string
+	warning   int
+	critical  int
+	// IcingaHost
+	host *icinga.IcingaHost
+}
+
+func (o *options) complete(cmd *cobra.Command) error {
+	hostname, err := cmd.Flags().GetString(plugins.FlagHost)
+	if err != nil {
+		return err
+	}
+	o.host, err = icinga.ParseHost(hostname)
+	if err != nil {
+		return errors.New("invalid icinga host.name")
+	}
+	o.namespace = o.host.AlertNamespace
+	o.kind = o.host.WorkloadKind
+	o.name = o.host.ObjectName
+
+	o.kubeconfigPath, err = cmd.Flags().GetString(plugins.FlagKubeConfig)
+	if err != nil {
+		return err
+	}
+	o.contextName, err = cmd.Flags().GetString(plugins.FlagKubeConfigContext)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o *options) validate() error {
+	if o.host.Type != icinga.TypeWorkload {
+		return errors.New("invalid icinga host type")
+	}
+	if o.warning > o.critical {
+		return errors.New("warning can't be greater than critical")
+	}
+	return nil
+}
+
+func (p *plugin) getSelector() (*metav1.LabelSelector, error) {
+	opts := p.options
+
+	switch opts.kind {
+	case api.WorkloadKindDeployment:
+		obj, err := p.client.AppsV1().Deployments(opts.namespace).Get(opts.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return obj.Spec.Selector, nil
+	case api.WorkloadKindStatefulSet:
+		obj, err := p.client.AppsV1().StatefulSets(opts.namespace).Get(opts.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return obj.Spec.Selector, nil
+	case api.WorkloadKindDaemonSet:
+		obj, err := p.client.AppsV1().DaemonSets(opts.namespace).Get(opts.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return obj.Spec.Selector, nil
+	}
+	return nil, fmt.Errorf("unknown workload kind %s", opts.kind)
+}
+
+func (p *plugin) Check() (icinga.State, interface{}) {
+	opts := p.options
+
+	ls, err := p.getSelector()
+	if err != nil {
+		return icinga.Unknown, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(ls)
+	if err != nil {
+		return icinga.Unknown, err
+	}
+	podList, err := p.client.CoreV1().Pods(opts.namespace).List(metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return icinga.Unknown, err
+	}
+
+	var restarts int32
+	for _, pod := range podList.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			restarts += status.RestartCount
+		}
+	}
+
+	message := fmt.Sprintf("containers of %d pod(s) restarted %d time(s)", len(podList.Items), restarts)
+	if int(restarts) >= opts.critical {
+		return icinga.Critical, message
+	}
+	if int(restarts) >= opts.warning {
+		return icinga.Warning, message
+	}
+	return icinga.OK, message
+}
+
+func NewCmd() *cobra.Command {
+	var opts options
+	c := &cobra.Command{
+		Use:   "check_workload_restarts",
+		Short: "Check container restarts of pods of a Kubernetes Deployment, StatefulSet or DaemonSet",
+
+		Run: func(cmd *cobra.Command, args []string) {
+			flags.EnsureRequiredFlags(cmd, plugins.FlagHost)
+
+			if err := opts.complete(cmd); err != nil {
+				icinga.Output(icinga.Unknown, err)
+			}
+			if err := opts.validate(); err != nil {
+				icinga.Output(icinga.Unknown, err)
+			}
+			plugin, err := newPluginFromConfig(opts)
+			if err != nil {
+				icinga.Output(icinga.Unknown, err)
+			}
+			icinga.Output(plugin.Check())
+		},
+	}
+
+	c.Flags().StringP(plugins.FlagHost, "H", "", "Icinga host name")
+	c.Flags().IntVarP(&opts.warning, "warning", "w", 3, "Warning if total container restarts reach this count")
+	c.Flags().IntVarP(&opts.critical, "critical", "c", 10, "Critical if total container restarts reach this count")
+	return c
+}
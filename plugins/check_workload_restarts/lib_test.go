@@ -0,0 +1,139 @@
+package check_workload_restarts
+
+import (
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/pkg/icinga"
+	"github.com/appscode/searchlight/plugins"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("check_workload_restarts", func() {
+	var sts *apps.StatefulSet
+	var pods []*core.Pod
+	var opts options
+
+	BeforeEach(func() {
+		sts = &apps.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web",
+				Namespace: "demo",
+			},
+			Spec: apps.StatefulSetSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"app": "web",
+					},
+				},
+			},
+		}
+		pods = nil
+		for _, name := range []string{"web-0", "web-1"} {
+			pods = append(pods, &core.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "demo",
+					Labels: map[string]string{
+						"app": "web",
+					},
+				},
+				Status: core.PodStatus{
+					ContainerStatuses: []core.ContainerStatus{
+						{Name: "web"},
+					},
+				},
+			})
+		}
+		opts = options{
+			namespace: "demo",
+			kind:      api.WorkloadKindStatefulSet,
+			name:      sts.Name,
+			warning:   2,
+			critical:  4,
+		}
+	})
+
+	JustBeforeEach(func() {
+		_, err := cs.AppsV1().StatefulSets(sts.Namespace).Create(sts)
+		Expect(err).ShouldNot(HaveOccurred())
+		for _, pod := range pods {
+			_, err := cs.CoreV1().Pods(pod.Namespace).Create(pod)
+			Expect(err).ShouldNot(HaveOccurred())
+		}
+	})
+
+	AfterEach(func() {
+		cs.AppsV1().StatefulSets(sts.Namespace).Delete(sts.Name, &metav1.DeleteOptions{})
+		for _, pod := range pods {
+			cs.CoreV1().Pods(pod.Namespace).Delete(pod.Name, &metav1.DeleteOptions{})
+		}
+	})
+
+	Context("without restarts", func() {
+		It("should be OK", func() {
+			state, _ := newPlugin(cs, opts).Check()
+			Expect(state).Should(BeIdenticalTo(icinga.OK))
+		})
+	})
+	Context("with restarts reaching warning", func() {
+		BeforeEach(func() {
+			pods[0].Status.ContainerStatuses[0].RestartCount = 1
+			pods[1].Status.ContainerStatuses[0].RestartCount = 1
+		})
+		It("should be Warning", func() {
+			state, _ := newPlugin(cs, opts).Check()
+			Expect(state).Should(BeIdenticalTo(icinga.Warning))
+		})
+	})
+	Context("with restarts reaching critical", func() {
+		BeforeEach(func() {
+			pods[0].Status.ContainerStatuses[0].RestartCount = 4
+		})
+		It("should be Critical", func() {
+			state, _ := newPlugin(cs, opts).Check()
+			Expect(state).Should(BeIdenticalTo(icinga.Critical))
+		})
+	})
+	Context("with restarts of unrelated pods", func() {
+		BeforeEach(func() {
+			pods[1].Labels = map[string]string{"app": "other"}
+			pods[1].Status.ContainerStatuses[0].RestartCount = 10
+		})
+		It("should be OK", func() {
+			state, _ := newPlugin(cs, opts).Check()
+			Expect(state).Should(BeIdenticalTo(icinga.OK))
+		})
+	})
+	Describe("test options", func() {
+		var (
+			cmd *cobra.Command
+		)
+
+		JustBeforeEach(func() {
+			cmd = new(cobra.Command)
+			cmd.Flags().String(plugins.FlagHost, "demo@workload@deployment@nginx", "")
+			cmd.Flags().String(plugins.FlagKubeConfig, "", "")
+			cmd.Flags().String(plugins.FlagKubeConfigContext, "", "")
+		})
+		It("valid", func() {
+			opts := options{warning: 3, critical: 10}
+			err := opts.complete(cmd)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opts.kind).Should(BeIdenticalTo(api.WorkloadKindDeployment))
+			Expect(opts.name).Should(BeIdenticalTo("nginx"))
+			err = opts.validate()
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+		It("warning greater than critical", func() {
+			opts := options{warning: 10, critical: 3}
+			err := opts.complete(cmd)
+			Expect(err).ShouldNot(HaveOccurred())
+			err = opts.validate()
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
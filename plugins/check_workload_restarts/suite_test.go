@@ -0,0 +1,33 @@
+package check_workload_restarts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/appscode/searchlight/client/clientset/versioned/scheme"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/kubernetes/fake"
+	clientSetScheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+var (
+	cs *fake.Clientset
+)
+
+const (
+	TIMEOUT = 2 * time.Minute
+)
+
+func TestPlugin_Check(t *testing.T) {
+	RegisterFailHandler(Fail)
+	SetDefaultEventuallyTimeout(TIMEOUT)
+	RunSpecsWithDefaultAndCustomReporters(t, "check_workload_restarts Suite", []Reporter{})
+}
+
+var _ = BeforeSuite(func() {
+	scheme.AddToScheme(clientSetScheme.Scheme)
+	cs = fake.NewSimpleClientset()
+})
+
+var _ = AfterSuite(func() {})
@@ -0,0 +1,206 @@
+package check_workload_status
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/appscode/go/flags"
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/pkg/icinga"
+	"github.com/appscode/searchlight/plugins"
+	"github.com/spf13/cobra"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"kmodules.xyz/client-go/tools/clientcmd"
+)
+
+const (
+	// reasonProgressDeadlineExceeded is added in a deployment when its newest replica set fails to show any progress
+	// within the given deadline (progressDeadlineSeconds).
+	reasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+)
+
+type plugin struct {
+	client  kubernetes.Interface
+	options options
+}
+
+var _ plugins.PluginInterface = &plugin{}
+
+func newPlugin(client kubernetes.Interface, opts options) *plugin {
+	return &plugin{client, opts}
+}
+
+func newPluginFromConfig(opts options) (*plugin, error) {
+	client, err := clientcmd.ClientFromContext(opts.kubeconfigPath, opts.contextName)
+	if err != nil {
+		return nil, err
+	}
+	return newPlugin(client, opts), nil
+}
+
+type options struct {
+	kubeconfigPath string
+	contextName    string
+	// options
+	namespace         string
+	kind              string
+	name              string
+	minAvailable      int
+	isMinAvailableSet bool
+	// IcingaHost
+	host *icinga.IcingaHost
+}
+
+func (o *options) complete(cmd *cobra.Command) error {
+	hostname, err := cmd.Flags().GetString(plugins.FlagHost)
+	if err != nil {
+		return err
+	}
+	o.host, err = icinga.ParseHost(hostname)
+	if err != nil {
+		return errors.New("invalid icinga host.name")
+	}
+	o.namespace = o.host.AlertNamespace
+	o.kind = o.host.WorkloadKind
+	o.name = o.host.ObjectName
+	o.isMinAvailableSet = cmd.Flag(flagMinAvailable).Changed
+
+	o.kubeconfigPath, err = cmd.Flags().GetString(plugins.FlagKubeConfig)
+	if err != nil {
+		return err
+	}
+	o.contextName, err = cmd.Flags().GetString(plugins.FlagKubeConfigContext)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o *options) validate() error {
+	if o.host.Type != icinga.TypeWorkload {
+		return errors.New("invalid icinga host type")
+	}
+	if o.isMinAvailableSet && o.minAvailable < 0 {
+		return errors.New("minAvailable can't be negative")
+	}
+	return nil
+}
+
+type workloadStatus struct {
+	desired   int32
+	available int32
+	// stuck is set if a rollout of the workload is not progressing
+	stuck   bool
+	message string
+}
+
+func (p *plugin) getStatus() (*workloadStatus, error) {
+	opts := p.options
+
+	switch opts.kind {
+	case api.WorkloadKindDeployment:
+		obj, err := p.client.AppsV1().Deployments(opts.namespace).Get(opts.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		status := &workloadStatus{
+			desired:   replicas(obj.Spec.Replicas),
+			available: obj.Status.AvailableReplicas,
+		}
+		for _, cond := range obj.Status.Conditions {
+			if cond.Type == apps.DeploymentProgressing &&
+				cond.Status == core.ConditionFalse &&
+				cond.Reason == reasonProgressDeadlineExceeded {
+				status.stuck = true
+				status.message = cond.Message
+			}
+		}
+		return status, nil
+	case api.WorkloadKindStatefulSet:
+		obj, err := p.client.AppsV1().StatefulSets(opts.namespace).Get(opts.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return &workloadStatus{
+			desired:   replicas(obj.Spec.Replicas),
+			available: obj.Status.ReadyReplicas,
+		}, nil
+	case api.WorkloadKindDaemonSet:
+		obj, err := p.client.AppsV1().DaemonSets(opts.namespace).Get(opts.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return &workloadStatus{
+			desired:   obj.Status.DesiredNumberScheduled,
+			available: obj.Status.NumberAvailable,
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown workload kind %s", opts.kind)
+}
+
+func replicas(r *int32) int32 {
+	if r == nil {
+		return 1
+	}
+	return *r
+}
+
+func (p *plugin) Check() (icinga.State, interface{}) {
+	opts := p.options
+
+	status, err := p.getStatus()
+	if err != nil {
+		return icinga.Unknown, err
+	}
+
+	if status.stuck {
+		return icinga.Critical, fmt.Sprintf("%s %s rollout is stuck: %s", opts.kind, opts.name, status.message)
+	}
+
+	minAvailable := int32(opts.minAvailable)
+	if !opts.isMinAvailableSet && status.desired > 0 {
+		minAvailable = 1
+	}
+	if status.available < minAvailable {
+		return icinga.Critical, fmt.Sprintf("%d of %d replicas are available, at least %d required", status.available, status.desired, minAvailable)
+	}
+	if status.available < status.desired {
+		return icinga.Warning, fmt.Sprintf("%d of %d replicas are available", status.available, status.desired)
+	}
+	return icinga.OK, fmt.Sprintf("%d of %d replicas are available", status.available, status.desired)
+}
+
+const (
+	flagMinAvailable = "minAvailable"
+)
+
+func NewCmd() *cobra.Command {
+	var opts options
+	c := &cobra.Command{
+		Use:   "check_workload_status",
+		Short: "Check available replicas and rollout of a Kubernetes Deployment, StatefulSet or DaemonSet",
+
+		Run: func(cmd *cobra.Command, args []string) {
+			flags.EnsureRequiredFlags(cmd, plugins.FlagHost)
+
+			if err := opts.complete(cmd); err != nil {
+				icinga.Output(icinga.Unknown, err)
+			}
+			if err := opts.validate(); err != nil {
+				icinga.Output(icinga.Unknown, err)
+			}
+			plugin, err := newPluginFromConfig(opts)
+			if err != nil {
+				icinga.Output(icinga.Unknown, err)
+			}
+			icinga.Output(plugin.Check())
+		},
+	}
+
+	c.Flags().StringP(plugins.FlagHost, "H", "", "Icinga host name")
+	c.Flags().IntVar(&opts.minAvailable, flagMinAvailable, 0, "Minimum number of available replicas. Defaults to 1 if the workload has desired replicas.")
+	return c
+}
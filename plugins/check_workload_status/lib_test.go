@@ -0,0 +1,167 @@
+package check_workload_status
+
+import (
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/pkg/icinga"
+	"github.com/appscode/searchlight/plugins"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("check_workload_status", func() {
+	var deployment *apps.Deployment
+	var opts options
+
+	BeforeEach(func() {
+		replicas := int32(3)
+		deployment = &apps.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "deployment",
+				Namespace: "demo",
+			},
+			Spec: apps.DeploymentSpec{
+				Replicas: &replicas,
+			},
+		}
+		opts = options{
+			namespace: "demo",
+			kind:      api.WorkloadKindDeployment,
+			name:      deployment.Name,
+		}
+	})
+
+	AfterEach(func() {
+		cs.AppsV1().Deployments(deployment.Namespace).Delete(deployment.Name, &metav1.DeleteOptions{})
+	})
+
+	Describe("when all replicas are available", func() {
+		It("should be OK", func() {
+			deployment.Status.AvailableReplicas = 3
+			_, err := cs.AppsV1().Deployments(deployment.Namespace).Create(deployment)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			state, _ := newPlugin(cs, opts).Check()
+			Expect(state).Should(BeIdenticalTo(icinga.OK))
+		})
+	})
+	Describe("when some replicas are available", func() {
+		It("should be Warning", func() {
+			deployment.Status.AvailableReplicas = 2
+			_, err := cs.AppsV1().Deployments(deployment.Namespace).Create(deployment)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			state, _ := newPlugin(cs, opts).Check()
+			Expect(state).Should(BeIdenticalTo(icinga.Warning))
+		})
+		It("should be Critical below minAvailable", func() {
+			deployment.Status.AvailableReplicas = 2
+			_, err := cs.AppsV1().Deployments(deployment.Namespace).Create(deployment)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			opts.minAvailable = 3
+			opts.isMinAvailableSet = true
+			state, _ := newPlugin(cs, opts).Check()
+			Expect(state).Should(BeIdenticalTo(icinga.Critical))
+		})
+	})
+	Describe("when no replica is available", func() {
+		It("should be Critical", func() {
+			_, err := cs.AppsV1().Deployments(deployment.Namespace).Create(deployment)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			state, _ := newPlugin(cs, opts).Check()
+			Expect(state).Should(BeIdenticalTo(icinga.Critical))
+		})
+	})
+	Describe("when rollout is stuck", func() {
+		It("should be Critical", func() {
+			deployment.Status.AvailableReplicas = 3
+			deployment.Status.Conditions = []apps.DeploymentCondition{
+				{
+					Type:   apps.DeploymentProgressing,
+					Status: core.ConditionFalse,
+					Reason: reasonProgressDeadlineExceeded,
+				},
+			}
+			_, err := cs.AppsV1().Deployments(deployment.Namespace).Create(deployment)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			state, _ := newPlugin(cs, opts).Check()
+			Expect(state).Should(BeIdenticalTo(icinga.Critical))
+		})
+	})
+	Describe("when workload is a DaemonSet", func() {
+		var ds *apps.DaemonSet
+		BeforeEach(func() {
+			ds = &apps.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "daemonset",
+					Namespace: "demo",
+				},
+				Status: apps.DaemonSetStatus{
+					DesiredNumberScheduled: 2,
+					NumberAvailable:        2,
+				},
+			}
+			opts.kind = api.WorkloadKindDaemonSet
+			opts.name = ds.Name
+		})
+		AfterEach(func() {
+			cs.AppsV1().DaemonSets(ds.Namespace).Delete(ds.Name, &metav1.DeleteOptions{})
+		})
+		It("should be OK", func() {
+			_, err := cs.AppsV1().DaemonSets(ds.Namespace).Create(ds)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			state, _ := newPlugin(cs, opts).Check()
+			Expect(state).Should(BeIdenticalTo(icinga.OK))
+		})
+	})
+	Describe("when workload doesn't exist", func() {
+		It("should be Unknown", func() {
+			state, _ := newPlugin(cs, opts).Check()
+			Expect(state).Should(BeIdenticalTo(icinga.Unknown))
+		})
+	})
+	Describe("test options", func() {
+		var (
+			cmd *cobra.Command
+		)
+
+		JustBeforeEach(func() {
+			cmd = new(cobra.Command)
+			cmd.Flags().Int(flagMinAvailable, 0, "")
+			cmd.Flags().String(plugins.FlagHost, "demo@workload@statefulset@web", "")
+			cmd.Flags().String(plugins.FlagKubeConfig, "", "")
+			cmd.Flags().String(plugins.FlagKubeConfigContext, "", "")
+		})
+		Context("valid", func() {
+			It("-", func() {
+				opts := options{}
+				cmd.Flags().Set(flagMinAvailable, "2")
+				err := opts.complete(cmd)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(opts.isMinAvailableSet).Should(BeIdenticalTo(true))
+				Expect(opts.namespace).Should(BeIdenticalTo("demo"))
+				Expect(opts.kind).Should(BeIdenticalTo(api.WorkloadKindStatefulSet))
+				Expect(opts.name).Should(BeIdenticalTo("web"))
+				err = opts.validate()
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+		Context("invalid", func() {
+			It("invalid host type", func() {
+				cmd.Flags().Set(plugins.FlagHost, "demo@cluster")
+				opts := options{}
+				err := opts.complete(cmd)
+				Expect(err).ShouldNot(HaveOccurred())
+				err = opts.validate()
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 
 	v "github.com/appscode/go/version"
 	"github.com/appscode/searchlight/client/clientset/versioned/scheme"
+	"github.com/appscode/searchlight/pkg/icinga"
 	"github.com/appscode/searchlight/plugins"
 	"github.com/appscode/searchlight/plugins/analytics_id"
 	"github.com/appscode/searchlight/plugins/check_ca_cert"
@@ -20,6 +21,8 @@ import (
 	"github.com/appscode/searchlight/plugins/check_pod_status"
 	"github.com/appscode/searchlight/plugins/check_volume"
 	"github.com/appscode/searchlight/plugins/check_webhook"
+	"github.com/appscode/searchlight/plugins/check_workload_restarts"
+	"github.com/appscode/searchlight/plugins/check_workload_status"
 	"github.com/appscode/searchlight/plugins/notifier"
 	"github.com/spf13/cobra"
 	clientsetscheme "k8s.io/client-go/kubernetes/scheme"
@@ -32,6 +35,9 @@ func NewCmd() *cobra.Command {
 		Short: "AppsCode Icinga2 plugin",
 		PersistentPreRun: func(c *cobra.Command, args []string) {
 			scheme.AddToScheme(clientsetscheme.Scheme)
+			if err := plugins.ResolveSecretVars(c); err != nil {
+				icinga.Output(icinga.Unknown, err)
+			}
 		},
 		Run: func(c *cobra.Command, args []string) {
 			c.Help()
@@ -61,6 +67,10 @@ func NewCmd() *cobra.Command {
 	cmd.AddCommand(check_pod_status.NewCmd())
 	cmd.AddCommand(check_pod_exec.NewCmd())
 
+	// CheckWorkload
+	cmd.AddCommand(check_workload_status.NewCmd())
+	cmd.AddCommand(check_workload_restarts.NewCmd())
+
 	// Combined
 	cmd.AddCommand(check_volume.NewCmd())
 
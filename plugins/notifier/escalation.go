@@ -0,0 +1,71 @@
+package notifier
+
+import (
+	"time"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+)
+
+// escalation is derived from the notification history of an incident.
+type escalation struct {
+	// Time of the first problem notification
+	start time.Time
+	// Time of the last problem notification
+	lastProblem time.Time
+	// Time of the first acknowledgement, if any
+	acknowledgedAt *time.Time
+}
+
+func (n *notifier) getEscalation(incident *api.Incident) escalation {
+	var e escalation
+	if incident != nil {
+		for _, item := range incident.Status.Notifications {
+			switch item.Type {
+			case api.NotificationProblem:
+				if e.start.IsZero() || item.FirstTimestamp.Time.Before(e.start) {
+					e.start = item.FirstTimestamp.Time
+				}
+				if item.LastTimestamp.Time.After(e.lastProblem) {
+					e.lastProblem = item.LastTimestamp.Time
+				}
+			case api.NotificationAcknowledgement:
+				if e.acknowledgedAt == nil || item.FirstTimestamp.Time.Before(*e.acknowledgedAt) {
+					t := item.FirstTimestamp.Time
+					e.acknowledgedAt = &t
+				}
+			}
+		}
+	}
+	if api.AlertType(n.options.notificationType) == api.NotificationProblem {
+		if e.start.IsZero() {
+			e.start = n.options.time
+		}
+		e.lastProblem = n.options.time
+	}
+	return e
+}
+
+// reached reports whether notifications are sent to a receiver.
+//
+// Receivers without escalateAfter are always notified. Problem notifications are sent to an
+// escalation step once its delay has passed, unless the incident is acknowledged. Other
+// notifications are sent to the escalation steps which were reached before the acknowledgement.
+func (e escalation) reached(receiver api.Receiver, notificationType api.IncidentNotificationType) bool {
+	if receiver.EscalateAfter == nil {
+		return true
+	}
+	if e.start.IsZero() {
+		return false
+	}
+
+	until := e.lastProblem
+	if e.acknowledgedAt != nil {
+		if notificationType == api.NotificationProblem {
+			return false
+		}
+		if e.acknowledgedAt.Before(until) {
+			until = *e.acknowledgedAt
+		}
+	}
+	return until.Sub(e.start) >= receiver.EscalateAfter.Duration
+}
@@ -0,0 +1,66 @@
+package notifier
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEscalation(t *testing.T) {
+	start := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	chat := api.Receiver{State: stateCritical, To: []string{"on-call"}, Notifier: "Slack"}
+	lead := api.Receiver{State: stateCritical, To: []string{"lead@example.com"}, Notifier: "Mailgun", EscalateAfter: &metav1.Duration{Duration: 15 * time.Minute}}
+	manager := api.Receiver{State: stateCritical, To: []string{"manager"}, Notifier: "Pushover", EscalateAfter: &metav1.Duration{Duration: time.Hour}}
+
+	problem := api.IncidentNotification{
+		Type:           api.NotificationProblem,
+		FirstTimestamp: metav1.NewTime(start),
+		LastTimestamp:  metav1.NewTime(start.Add(20 * time.Minute)),
+		LastState:      stateCritical,
+	}
+	ack := api.IncidentNotification{
+		Type:           api.NotificationAcknowledgement,
+		FirstTimestamp: metav1.NewTime(start.Add(25 * time.Minute)),
+		LastTimestamp:  metav1.NewTime(start.Add(25 * time.Minute)),
+		LastState:      stateCritical,
+	}
+
+	newNotifier := func(notificationType string, at time.Time) *notifier {
+		return &notifier{options: options{notificationType: notificationType, time: at}}
+	}
+
+	t.Run("first problem notification", func(t *testing.T) {
+		esc := newNotifier("PROBLEM", start).getEscalation(nil)
+		assert.True(t, esc.reached(chat, api.NotificationProblem))
+		assert.False(t, esc.reached(lead, api.NotificationProblem))
+		assert.False(t, esc.reached(manager, api.NotificationProblem))
+	})
+
+	t.Run("problem not acknowledged", func(t *testing.T) {
+		incident := &api.Incident{Status: api.IncidentStatus{Notifications: []api.IncidentNotification{problem}}}
+		esc := newNotifier("PROBLEM", start.Add(30*time.Minute)).getEscalation(incident)
+		assert.True(t, esc.reached(chat, api.NotificationProblem))
+		assert.True(t, esc.reached(lead, api.NotificationProblem))
+		assert.False(t, esc.reached(manager, api.NotificationProblem))
+	})
+
+	t.Run("problem acknowledged", func(t *testing.T) {
+		incident := &api.Incident{Status: api.IncidentStatus{Notifications: []api.IncidentNotification{problem, ack}}}
+		esc := newNotifier("PROBLEM", start.Add(2*time.Hour)).getEscalation(incident)
+		assert.True(t, esc.reached(chat, api.NotificationProblem))
+		assert.False(t, esc.reached(lead, api.NotificationProblem))
+		assert.False(t, esc.reached(manager, api.NotificationProblem))
+	})
+
+	t.Run("recovery after acknowledgement", func(t *testing.T) {
+		incident := &api.Incident{Status: api.IncidentStatus{Notifications: []api.IncidentNotification{problem, ack}}}
+		esc := newNotifier("RECOVERY", start.Add(2*time.Hour)).getEscalation(incident)
+		assert.True(t, esc.reached(chat, api.NotificationRecovery))
+		assert.True(t, esc.reached(lead, api.NotificationRecovery))
+		assert.False(t, esc.reached(manager, api.NotificationRecovery))
+	})
+}
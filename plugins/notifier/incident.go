@@ -2,6 +2,7 @@ package notifier
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
@@ -43,6 +44,9 @@ func (n *notifier) getLabel() map[string]string {
 		api.LabelKeyObjectName:       n.options.host.ObjectName,
 		api.LabelKeyProblemRecovered: "false",
 	}
+	if n.options.host.Type == icinga.TypeWorkload {
+		labelMap[api.LabelKeyObjectKind] = n.options.host.WorkloadKind
+	}
 
 	return labelMap
 }
@@ -52,10 +56,12 @@ func (n *notifier) generateIncidentName() (string, error) {
 	t := n.options.time.Format("20060102-1504")
 
 	switch host.Type {
-	case icinga.TypePod, icinga.TypeNode:
+	case icinga.TypePod, icinga.TypeNode, icinga.TypeGlobal:
 		return host.Type + "." + host.ObjectName + "." + n.options.alertName + "." + t, nil
 	case icinga.TypeCluster:
 		return host.Type + "." + n.options.alertName + "." + t, nil
+	case icinga.TypeWorkload:
+		return host.Type + "." + strings.ToLower(host.WorkloadKind) + "." + host.ObjectName + "." + n.options.alertName + "." + t, nil
 	}
 
 	return "", fmt.Errorf("unknown host type %s", host.Type)
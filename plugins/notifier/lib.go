@@ -25,12 +25,12 @@ import (
 )
 
 type notifier struct {
-	client    corev1.SecretInterface
+	client    corev1.CoreV1Interface
 	extClient cs.MonitoringV1alpha1Interface
 	options   options
 }
 
-func newPlugin(client corev1.SecretInterface, extClient cs.MonitoringV1alpha1Interface, opts options) *notifier {
+func newPlugin(client corev1.CoreV1Interface, extClient cs.MonitoringV1alpha1Interface, opts options) *notifier {
 	return &notifier{client, extClient, opts}
 }
 
@@ -50,7 +50,7 @@ func newPluginFromConfig(opts options) (*notifier, error) {
 		return nil, err
 	}
 
-	return newPlugin(client.CoreV1().Secrets(opts.host.AlertNamespace), extClient, opts), nil
+	return newPlugin(client.CoreV1(), extClient, opts), nil
 }
 
 type options struct {
@@ -128,32 +128,77 @@ type Secret struct {
 }
 
 func (n *notifier) getLoader(alert api.Alert) (envconfig.LoaderFunc, error) {
-	cfg, err := n.client.Get(alert.GetNotifierSecretName(), metav1.GetOptions{})
+	cfg, err := n.client.Secrets(alert.GetNotifierSecretNamespace()).Get(alert.GetNotifierSecretName(), metav1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
+	return api.SecretLoader(cfg), nil
+}
 
-	return func(key string) (value string, found bool) {
-		var bytes []byte
-		bytes, found = cfg.Data[key]
-		value = string(bytes)
-		return
-	}, nil
+// getNotifierConfigLoader returns the receiver with the notifier of its NotifierConfig and a loader
+// for the credentials of the NotifierConfig.
+func (n *notifier) getNotifierConfigLoader(receiver api.Receiver) (api.Receiver, envconfig.LoaderFunc, error) {
+	cfg, err := n.extClient.NotifierConfigs().Get(receiver.NotifierConfig, metav1.GetOptions{})
+	if err != nil {
+		return receiver, nil, err
+	}
+	secret, err := n.client.Secrets(cfg.Spec.SecretRef.Namespace).Get(cfg.Spec.SecretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return receiver, nil, err
+	}
+	receiver.Notifier = cfg.Spec.Notifier
+	return receiver, api.SecretLoader(secret), nil
 }
 
+// getAlert returns the alert of the notification merged with its AlertTemplate.
 func (n *notifier) getAlert() (api.Alert, error) {
+	alert, err := n.fetchAlert()
+	if err != nil {
+		return nil, err
+	}
+	if name := api.GetTemplateRef(alert); name != "" {
+		t, err := n.extClient.AlertTemplates(alert.GetNamespace()).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		alert = api.WithTemplate(alert, t)
+	}
+	return alert, nil
+}
+
+func (n *notifier) fetchAlert() (api.Alert, error) {
 	opts := n.options
 	switch opts.host.Type {
 	case icinga.TypePod:
+		if api.IsAnnotationAlert(opts.alertName) {
+			pod, err := n.client.Pods(opts.host.AlertNamespace).Get(opts.host.ObjectName, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return api.GetAnnotationAlert(pod, opts.alertName)
+		}
 		return n.extClient.PodAlerts(opts.host.AlertNamespace).Get(opts.alertName, metav1.GetOptions{})
 	case icinga.TypeNode:
 		return n.extClient.NodeAlerts(opts.host.AlertNamespace).Get(opts.alertName, metav1.GetOptions{})
 	case icinga.TypeCluster:
 		return n.extClient.ClusterAlerts(opts.host.AlertNamespace).Get(opts.alertName, metav1.GetOptions{})
+	case icinga.TypeWorkload:
+		return n.extClient.WorkloadAlerts(opts.host.AlertNamespace).Get(opts.alertName, metav1.GetOptions{})
+	case icinga.TypeGlobal:
+		return n.extClient.GlobalAlerts().Get(opts.alertName, metav1.GetOptions{})
 	}
 	return nil, fmt.Errorf("unknown host type %s", opts.host.Type)
 }
 
+// inTimePeriod reports whether the notification happened within the named TimePeriod.
+func (n *notifier) inTimePeriod(name string) (bool, error) {
+	tp, err := n.extClient.TimePeriods().Get(name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	return tp.IsInside(n.options.time)
+}
+
 func (n *notifier) sendToReceiver(alert api.Alert, receiver api.Receiver, loader envconfig.LoaderFunc) error {
 	notifyVia, err := unified.LoadVia(receiver.Notifier, loader)
 	if err != nil {
@@ -197,28 +242,60 @@ func (n *notifier) sendNotification() {
 		log.Fatalln(err)
 	}
 
-	loader, err := n.getLoader(alert)
+	var loader envconfig.LoaderFunc
+	if alert.GetNotifierSecretName() != "" {
+		if loader, err = n.getLoader(alert); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	incident, err := n.getIncident()
 	if err != nil {
-		log.Fatalln(err)
+		log.Errorln(err)
 	}
 
+	notificationType := api.AlertType(n.options.notificationType)
 	serviceState := n.options.serviceState
-	if api.AlertType(n.options.notificationType) == api.NotificationRecovery {
-		if incident, _ := n.getIncident(); incident != nil {
-			if lastNonOKState := n.getLastNonOKState(incident); lastNonOKState != "" {
-				serviceState = lastNonOKState
-			}
+	if notificationType == api.NotificationRecovery && incident != nil {
+		if lastNonOKState := n.getLastNonOKState(incident); lastNonOKState != "" {
+			serviceState = lastNonOKState
 		}
 	}
 
+	esc := n.getEscalation(incident)
 	receivers := alert.GetReceivers()
 
 	for _, receiver := range receivers {
 		if len(receiver.To) == 0 || !strings.EqualFold(receiver.State, serviceState) {
 			continue
 		}
+		if !esc.reached(receiver, notificationType) {
+			log.Infof("Skipping escalation step %s after %s", receiver.Notifier, receiver.EscalateAfter.Duration)
+			continue
+		}
+		if receiver.Period != "" {
+			inside, err := n.inTimePeriod(receiver.Period)
+			if err != nil {
+				// notify anyway, rather than losing the notification
+				log.Errorln(err)
+			} else if !inside {
+				log.Infof("Skipping receiver %s outside of time period %s", receiver.Notifier, receiver.Period)
+				continue
+			}
+		}
+
+		receiverLoader := loader
+		if receiver.NotifierConfig != "" {
+			if receiver, receiverLoader, err = n.getNotifierConfigLoader(receiver); err != nil {
+				log.Errorln(err)
+				continue
+			}
+		} else if receiverLoader == nil {
+			log.Errorf("No notifier secret for receiver %s", receiver.Notifier)
+			continue
+		}
 
-		if err = n.sendToReceiver(alert, receiver, loader); err != nil {
+		if err = n.sendToReceiver(alert, receiver, receiverLoader); err != nil {
 			log.Errorln(err)
 		} else {
 			log.Infof("Notification sent using %s", receiver.Notifier)
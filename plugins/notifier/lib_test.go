@@ -0,0 +1,37 @@
+package notifier
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	mon_fake "github.com/appscode/searchlight/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInTimePeriod(t *testing.T) {
+	officeHours := &api.TimePeriod{
+		ObjectMeta: metav1.ObjectMeta{Name: "office-hours"},
+		Spec: api.TimePeriodSpec{
+			TimeZone: "Europe/Berlin",
+			Ranges:   []api.TimeRange{{Days: []string{"Monday", "Friday"}, StartTime: "09:00", EndTime: "17:00"}},
+		},
+	}
+	extClient := mon_fake.NewSimpleClientset(officeHours).MonitoringV1alpha1()
+	newNotifier := func(at time.Time) *notifier {
+		return &notifier{extClient: extClient, options: options{time: at}}
+	}
+
+	// 2018-06-01 is a Friday, Berlin is UTC+2 in summer
+	inside, err := newNotifier(time.Date(2018, 6, 1, 7, 0, 0, 0, time.UTC)).inTimePeriod("office-hours")
+	assert.NoError(t, err)
+	assert.True(t, inside)
+
+	inside, err = newNotifier(time.Date(2018, 6, 1, 15, 0, 0, 0, time.UTC)).inTimePeriod("office-hours")
+	assert.NoError(t, err)
+	assert.False(t, inside)
+
+	_, err = newNotifier(time.Date(2018, 6, 1, 7, 0, 0, 0, time.UTC)).inTimePeriod("on-call")
+	assert.Error(t, err)
+}
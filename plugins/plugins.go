@@ -7,6 +7,9 @@ const (
 	FlagKubeConfigContext = "context"
 	FlagHost              = "host"
 	FlagCheckInterval     = "icinga.checkInterval"
+
+	// EnvIcingaHost is the environment variable Icinga sets to the name of the host checked by a CheckCommand
+	EnvIcingaHost = "ICINGA_HOST"
 )
 
 type PluginInterface interface {
@@ -0,0 +1,76 @@
+package plugins
+
+import (
+	"fmt"
+	"os"
+
+	api "github.com/appscode/searchlight/apis/monitoring/v1alpha1"
+	"github.com/appscode/searchlight/pkg/icinga"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"kmodules.xyz/client-go/tools/clientcmd"
+)
+
+// ResolveSecretVars replaces the values of flags referring to a key of a secret with the value of that key.
+// Icinga passes such references for vars set via varsFrom, so that secrets are only read at check time.
+// Only secrets in the namespace of the alert of the checked host, passed by Icinga in EnvIcingaHost, are read.
+func ResolveSecretVars(cmd *cobra.Command) error {
+	var refs []*pflag.Flag
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if api.IsSecretVarRef(f.Value.String()) {
+			refs = append(refs, f)
+		}
+	})
+	if len(refs) == 0 {
+		return nil
+	}
+
+	host, err := icinga.ParseHost(os.Getenv(EnvIcingaHost))
+	if err != nil {
+		return fmt.Errorf("can't resolve secret references without a valid %s: %v", EnvIcingaHost, err)
+	}
+
+	kubeconfigPath, err := cmd.Flags().GetString(FlagKubeConfig)
+	if err != nil {
+		return err
+	}
+	contextName, err := cmd.Flags().GetString(FlagKubeConfigContext)
+	if err != nil {
+		return err
+	}
+	config, err := clientcmd.BuildConfigFromContext(kubeconfigPath, contextName)
+	if err != nil {
+		return err
+	}
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	return resolveSecretVars(kubeClient, host.AlertNamespace, refs)
+}
+
+func resolveSecretVars(kubeClient kubernetes.Interface, alertNamespace string, refs []*pflag.Flag) error {
+	for _, f := range refs {
+		namespace, ref, err := api.ParseSecretVarRef(f.Value.String())
+		if err != nil {
+			return err
+		}
+		if namespace != alertNamespace {
+			return fmt.Errorf("flag %s refers to secret %s/%s outside of alert namespace %s", f.Name, namespace, ref.Name, alertNamespace)
+		}
+		secret, err := kubeClient.CoreV1().Secrets(namespace).Get(ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		data, found := secret.Data[ref.Key]
+		if !found {
+			return fmt.Errorf("key %s not found in secret %s/%s", ref.Key, namespace, ref.Name)
+		}
+		if err := f.Value.Set(string(data)); err != nil {
+			return fmt.Errorf("failed to set flag %s from secret %s/%s: %v", f.Name, namespace, ref.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResolveSecretVars(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(
+		&core.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "webhook", Namespace: "demo"},
+			Data:       map[string][]byte{"token": []byte("demo-token")},
+		},
+		&core.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "admin", Namespace: "kube-system"},
+			Data:       map[string][]byte{"token": []byte("admin-token")},
+		},
+	)
+
+	cases := []struct {
+		name    string
+		ref     string
+		want    string
+		wantErr bool
+	}{
+		{name: "alert namespace", ref: "secretKeyRef://demo/webhook/token", want: "demo-token"},
+		{name: "other namespace", ref: "secretKeyRef://kube-system/admin/token", wantErr: true},
+		{name: "missing key", ref: "secretKeyRef://demo/webhook/password", wantErr: true},
+		{name: "bad reference", ref: "secretKeyRef://demo/webhook", wantErr: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			fs := pflag.NewFlagSet(c.name, pflag.ContinueOnError)
+			fs.String("token", c.ref, "")
+			err := resolveSecretVars(kubeClient, "demo", []*pflag.Flag{fs.Lookup("token")})
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got token %q", fs.Lookup("token").Value.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := fs.Lookup("token").Value.String(); got != c.want {
+				t.Errorf("expected %q, got %q", c.want, got)
+			}
+		})
+	}
+}